@@ -105,6 +105,7 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("REMOTE_PLUGIN_DIR", "python/plugins")
 	v.SetDefault("SWAGGER_DOCS_DIR", "resources/swagger")
 	v.SetDefault("RESUME_PIPELINES", true)
+	v.SetDefault("PIPELINE_MAX_PARALLEL_PER_BLUEPRINT", 1)
 	v.SetDefault("CORS_ALLOW_ORIGIN", "*")
 }
 
@@ -115,6 +115,20 @@ func (m *migratorImpl) HasPendingScripts() bool {
 	return len(m.executed) > 0 && len(m.pending) > 0
 }
 
+// PendingScripts returns all registered migration scripts that haven't been applied yet, sorted by version
+func (m *migratorImpl) PendingScripts() []plugin.MigrationScript {
+	m.Lock()
+	defer m.Unlock()
+	scripts := make([]plugin.MigrationScript, 0, len(m.pending))
+	for _, swc := range m.pending {
+		scripts = append(scripts, swc.script)
+	}
+	sort.SliceStable(scripts, func(i, j int) bool {
+		return scripts[i].Version() < scripts[j].Version()
+	})
+	return scripts
+}
+
 // NewMigrator returns a new Migrator instance, which
 // implemented based on migration_history from the same database
 func NewMigrator(basicRes context.BasicRes) (plugin.Migrator, errors.Error) {
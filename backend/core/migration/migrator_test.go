@@ -75,12 +75,17 @@ func TestHasPendingScripts(t *testing.T) {
 
 	// we should have pending scripts
 	assert.True(t, migrator.HasPendingScripts())
+	pending := migrator.PendingScripts()
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "E", pending[0].Name())
+	assert.Equal(t, "D", pending[1].Name())
 
 	// lets try migrating
 	assert.Nil(t, migrator.Execute())
 
 	// should not be any pending scripts anymore
 	assert.False(t, migrator.HasPendingScripts())
+	assert.Empty(t, migrator.PendingScripts())
 
 	// make sure all method got called
 	mockDal.AssertExpectations(t)
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// CommitChurn breaks the lines changed by a commit down by the kind of work, the deleted and modified lines are
+// classified by the age and the author of the commit which wrote them. AuthorId is the id of crossdomain.Account,
+// which could be mapped to the user by user_accounts
+type CommitChurn struct {
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha    string `gorm:"primaryKey;type:varchar(40)"`
+	AuthorId     string `gorm:"index;type:varchar(255)"`
+	AuthoredDate time.Time
+	AddedLines   int
+	DeletedLines int
+	// lines added without replacing any existing line
+	NewWorkLines int
+	// lines rewritten which were written by the author within the rework window
+	ReworkLines int
+	// lines rewritten which were written by others within the rework window
+	HelpOthersLines int
+	// lines rewritten which were written before the rework window
+	RefactorLines int
+	common.NoPKModel
+}
+
+func (CommitChurn) TableName() string {
+	return "commit_churns"
+}
+
+// CommitLineRewrite records whose lines were rewritten by a commit, SourceCommitSha is the commit which wrote them
+type CommitLineRewrite struct {
+	RepoId          string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha       string `gorm:"primaryKey;type:varchar(40)"`
+	SourceCommitSha string `gorm:"primaryKey;type:varchar(40)"`
+	AuthorId        string `gorm:"index;type:varchar(255)"`
+	SourceAuthorId  string `gorm:"index;type:varchar(255)"`
+	Lines           int
+	// the number of days between the source commit and the commit
+	AgeDays float64
+	// whether the lines were rewritten within the rework window
+	IsRework bool
+	common.NoPKModel
+}
+
+func (CommitLineRewrite) TableName() string {
+	return "commit_line_rewrites"
+}
+
+// RepoAuthorChurn sums up the churn of an author in a repo. SurvivalHalfLifeDays is the median lifetime of the lines
+// written by the author, estimated by Kaplan-Meier with the lines still alive as the censored ones, it is nil
+// if more than half of the lines are still expected to survive
+type RepoAuthorChurn struct {
+	RepoId               string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId             string `gorm:"primaryKey;type:varchar(255)"`
+	Commits              int
+	AddedLines           int
+	DeletedLines         int
+	NewWorkLines         int
+	ReworkLines          int
+	HelpOthersLines      int
+	RefactorLines        int
+	ReworkedByOthers     int
+	SurvivalHalfLifeDays *float64
+	common.NoPKModel
+}
+
+func (RepoAuthorChurn) TableName() string {
+	return "repo_author_churns"
+}
@@ -45,12 +45,23 @@ func (Commit) TableName() string {
 	return "commits"
 }
 
+const (
+	FILE_CATEGORY_PRODUCTION = "PRODUCTION"
+	FILE_CATEGORY_TEST       = "TEST"
+	FILE_CATEGORY_DOCS       = "DOCS"
+	FILE_CATEGORY_CONFIG     = "CONFIG"
+	FILE_CATEGORY_VENDORED   = "VENDORED"
+	FILE_CATEGORY_GENERATED  = "GENERATED"
+)
+
 type CommitFile struct {
 	domainlayer.DomainEntity
 	CommitSha string `gorm:"index;type:varchar(40)"`
 	FilePath  string `gorm:"type:text"`
 	Additions int
 	Deletions int
+	Language  string `gorm:"type:varchar(100)"`
+	Category  string `gorm:"type:varchar(100)"`
 }
 
 func (CommitFile) TableName() string {
@@ -90,6 +101,8 @@ type RepoSnapshot struct {
 	CommitSha string `gorm:"primaryKey;type:varchar(40);"`
 	FilePath  string `gorm:"primaryKey;type:varchar(255);"`
 	LineNo    int    `gorm:"primaryKey;type:int;"`
+	Language  string `gorm:"type:varchar(100)"`
+	Category  string `gorm:"type:varchar(100)"`
 }
 
 func (RepoSnapshot) TableName() string {
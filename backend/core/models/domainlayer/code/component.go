@@ -17,10 +17,19 @@ limitations under the License.
 
 package code
 
+const (
+	COMPONENT_SOURCE_MANUAL     = "MANUAL"
+	COMPONENT_SOURCE_CODEOWNERS = "CODEOWNERS"
+)
+
+// Component groups the files of a repo by PathRegex, a file belongs to the matched Component with the
+// highest SortingIndex
 type Component struct {
-	RepoId    string `gorm:"type:varchar(255)"`
-	Name      string `gorm:"primaryKey;type:varchar(255)"`
-	PathRegex string `gorm:"type:varchar(255)"`
+	RepoId       string `json:"repoId" gorm:"primaryKey;type:varchar(255)"`
+	Name         string `json:"name" gorm:"primaryKey;type:varchar(255)"`
+	PathRegex    string `json:"pathRegex" gorm:"type:varchar(255)"`
+	Source       string `json:"source" gorm:"type:varchar(100)"`
+	SortingIndex int    `json:"sortingIndex"`
 }
 
 func (Component) TableName() string {
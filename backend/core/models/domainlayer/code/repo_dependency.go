@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// the ecosystems follow the package types of purl
+const (
+	DEPENDENCY_ECOSYSTEM_GOLANG = "golang"
+	DEPENDENCY_ECOSYSTEM_NPM    = "npm"
+	DEPENDENCY_ECOSYSTEM_MAVEN  = "maven"
+	DEPENDENCY_ECOSYSTEM_PYPI   = "pypi"
+	DEPENDENCY_ECOSYSTEM_CARGO  = "cargo"
+)
+
+// RepoDependency is a package the repo depends on at a ref, as declared in a dependency manifest or SBOM file
+type RepoDependency struct {
+	domainlayer.DomainEntity
+	RepoId string `gorm:"index;type:varchar(255)"`
+	// RefName is HEAD or the name of the tag
+	RefName      string `gorm:"type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	ManifestPath string `gorm:"type:text"`
+	Ecosystem    string `gorm:"type:varchar(50)"`
+	PackageName  string `gorm:"index;type:varchar(255)"`
+	Version      string `gorm:"type:varchar(255)"`
+	// Direct is false for the transitive dependencies
+	Direct bool
+}
+
+func (RepoDependency) TableName() string {
+	return "repo_dependencies"
+}
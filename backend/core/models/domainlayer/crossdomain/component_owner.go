@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ComponentOwner maps the owner of a Component, as declared in CODEOWNERS, to the Team, TeamId is empty if
+// the owner couldn't be resolved to any Team. Owner and TeamId are shorter than usual to keep the primary key
+// within the index size limit of MySQL
+type ComponentOwner struct {
+	RepoId        string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+	Owner         string `gorm:"primaryKey;type:varchar(100)"`
+	TeamId        string `gorm:"primaryKey;type:varchar(150)"`
+	common.NoPKModel
+}
+
+func (ComponentOwner) TableName() string {
+	return "component_owners"
+}
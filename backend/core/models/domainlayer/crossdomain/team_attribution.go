@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// TeamAttribution attributes the pull requests, commits and issues to the teams their authors belonged to at the time
+type TeamAttribution struct {
+	// RowTable is the table of the row, like pull_requests
+	RowTable string `gorm:"primaryKey;type:varchar(50)"`
+	RowId    string `gorm:"primaryKey;type:varchar(255)"`
+	TeamId   string `gorm:"primaryKey;type:varchar(255)"`
+	UserId   string `gorm:"index;type:varchar(255)"`
+	Date     *time.Time
+	common.NoPKModel
+}
+
+func (TeamAttribution) TableName() string {
+	return "team_attributions"
+}
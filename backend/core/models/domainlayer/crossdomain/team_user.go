@@ -18,15 +18,37 @@ limitations under the License.
 package crossdomain
 
 import (
+	"time"
+
 	"github.com/apache/incubator-devlake/core/models/common"
 )
 
+// OpenStartDate is the start date of the memberships starting at an unknown date, the start date is a part of the
+// primary key so that the user leaving and rejoining the team has a membership per period
+var OpenStartDate = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// TeamUser is the membership of the user in the team, the dates are inclusive and open-ended when absent
 type TeamUser struct {
-	TeamId string `gorm:"primaryKey;type:varchar(255)"`
-	UserId string `gorm:"primaryKey;type:varchar(255)"`
+	TeamId    string    `gorm:"primaryKey;type:varchar(255)"`
+	UserId    string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate time.Time `gorm:"primaryKey"`
+	EndDate   *time.Time
 	common.NoPKModel
 }
 
+// HasStartDate reports whether the membership starts at a known date
+func (tu *TeamUser) HasStartDate() bool {
+	return tu.StartDate.After(OpenStartDate)
+}
+
+// ActiveAt reports whether the user was in the team at the time
+func (tu *TeamUser) ActiveAt(t time.Time) bool {
+	if t.Before(tu.StartDate) {
+		return false
+	}
+	return tu.EndDate == nil || t.Before(tu.EndDate.AddDate(0, 0, 1))
+}
+
 func (TeamUser) TableName() string {
 	return "team_users"
 }
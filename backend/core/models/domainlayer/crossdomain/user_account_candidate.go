@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	USER_ACCOUNT_CANDIDATE_PENDING  = "PENDING"
+	USER_ACCOUNT_CANDIDATE_AUTO     = "AUTO"
+	USER_ACCOUNT_CANDIDATE_ACCEPTED = "ACCEPTED"
+	USER_ACCOUNT_CANDIDATE_REJECTED = "REJECTED"
+)
+
+// UserAccountCandidate is a scored guess that the account belongs to the user, the ones not linked automatically
+// wait in the review queue, and the decisions made are kept across runs
+type UserAccountCandidate struct {
+	AccountId string `gorm:"primaryKey;type:varchar(255)"`
+	UserId    string `gorm:"primaryKey;type:varchar(255)"`
+	Score     float64
+	Reasons   string `gorm:"type:varchar(255)"`
+	Status    string `gorm:"index;type:varchar(20)"`
+	common.NoPKModel
+}
+
+func (UserAccountCandidate) TableName() string {
+	return "user_account_candidates"
+}
@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// VersionRelease links a version of the issue tracker to the cicd_releases tagged with its name,
+// e.g. the version 1.2.0 to the releases of the tag v1.2.0 in the repos of the project
+type VersionRelease struct {
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (VersionRelease) TableName() string {
+	return "version_releases"
+}
@@ -30,11 +30,13 @@ func GetDomainTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		// code
 		&code.Commit{},
+		&code.CommitChurn{},
 		&code.CommitFile{},
 		&code.CommitFileComponent{},
 		&code.CommitParent{},
 		&code.Component{},
 		&code.CommitLineChange{},
+		&code.CommitLineRewrite{},
 		&code.PullRequest{},
 		&code.PullRequestComment{},
 		&code.PullRequestCommit{},
@@ -44,7 +46,9 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&code.RefCommit{},
 		&code.RefsPrCherrypick{},
 		&code.Repo{},
+		&code.RepoAuthorChurn{},
 		&code.RepoCommit{},
+		&code.RepoDependency{},
 		&code.RepoLanguage{},
 		&code.RepoSnapshot{},
 		// codequality
@@ -55,6 +59,7 @@ func GetDomainTablesInfo() []dal.Tabler {
 		// crossdomain
 		&crossdomain.Account{},
 		&crossdomain.BoardRepo{},
+		&crossdomain.ComponentOwner{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
@@ -63,9 +68,12 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&crossdomain.PullRequestIssue{},
 		&crossdomain.RefsIssuesDiffs{},
 		&crossdomain.Team{},
+		&crossdomain.TeamAttribution{},
 		&crossdomain.TeamUser{},
 		&crossdomain.User{},
 		&crossdomain.UserAccount{},
+		&crossdomain.UserAccountCandidate{},
+		&crossdomain.VersionRelease{},
 		// devops
 		&devops.CICDPipeline{},
 		&devops.CICDTask{},
@@ -89,5 +97,22 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.IssueAssignee{},
 		&ticket.IssueRelationship{},
 		&ticket.IssueCustomArrayField{},
+		&ticket.IssueStatusHistory{},
+		&ticket.IssueStatusDuration{},
+		&ticket.IssueFlowMetric{},
+		&ticket.SprintIssueEvent{},
+		&ticket.SprintScopeMetric{},
+		&ticket.Version{},
+		&ticket.IssueVersion{},
+		&ticket.Component{},
+		&ticket.IssueComponent{},
+		&ticket.IssueSla{},
+		&ticket.IssueCustomFieldHistory{},
+		&ticket.BoardWipLimit{},
+		&ticket.BoardStatusWip{},
+		&ticket.BoardWipItem{},
+		&ticket.BoardForecast{},
+		&ticket.IssueHierarchy{},
+		&ticket.IssueRollup{},
 	}
 }
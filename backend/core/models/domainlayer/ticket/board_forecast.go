@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	// ForecastScopeBacklog is the scope of the forecasts of all the open issues of the board, the other scopes are epic keys
+	ForecastScopeBacklog = "BACKLOG"
+	// ForecastWhen answers when the remaining items will be done
+	ForecastWhen = "WHEN"
+	// ForecastHowMany answers how many items will be done by the target date
+	ForecastHowMany = "HOW_MANY"
+)
+
+// BoardForecast is a Monte Carlo forecast of the delivery of the board based on its throughput history
+type BoardForecast struct {
+	BoardId  string `gorm:"primaryKey;type:varchar(255)"`
+	Scope    string `gorm:"primaryKey;type:varchar(255)"`
+	Question string `gorm:"primaryKey;type:varchar(20)"`
+	// HorizonDays is how many days after the calculation the TargetDate of the HOW_MANY question is, 0 for WHEN
+	HorizonDays int `gorm:"primaryKey"`
+	// Confidence is the percentage of the simulations meeting the result
+	Confidence     int `gorm:"primaryKey"`
+	TargetDate     *time.Time
+	RemainingItems int
+	// CompletionDate answers the WHEN question, it is nil when the board has no throughput
+	CompletionDate *time.Time
+	// CompletedItems answers the HOW_MANY question
+	CompletedItems *int
+	HistoryDays    int
+	Simulations    int
+	CalculatedDate time.Time
+	common.NoPKModel
+}
+
+func (BoardForecast) TableName() string {
+	return "board_forecasts"
+}
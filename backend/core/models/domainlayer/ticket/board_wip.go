@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BoardWipLimit is the maximum number of issues allowed in a status (column) of the board
+type BoardWipLimit struct {
+	BoardId        string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus string `gorm:"primaryKey;type:varchar(255)"`
+	WipLimit       int
+	common.NoPKModel
+}
+
+func (BoardWipLimit) TableName() string {
+	return "board_wip_limits"
+}
+
+// BoardStatusWip is the number of issues in an IN_PROGRESS status, or a TODO status with a WIP limit, of the board at
+// the end of the day
+type BoardStatusWip struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	Date           time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	Status         string    `gorm:"type:varchar(100)"`
+	WipCount       int
+	// WipLimit is nil when no limit is set for the status
+	WipLimit  *int
+	OverLimit bool
+	common.NoPKModel
+}
+
+func (BoardStatusWip) TableName() string {
+	return "board_status_wips"
+}
+
+// BoardWipItem is an issue of the board being in progress, and how long it has been
+type BoardWipItem struct {
+	BoardId         string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId         string `gorm:"primaryKey;type:varchar(255)"`
+	IssueKey        string `gorm:"type:varchar(255)"`
+	Title           string
+	OriginalStatus  string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	InProgressDate  *time.Time
+	StatusStartDate time.Time
+	// AgeMinutes is from the first time in progress to the calculation
+	AgeMinutes int64
+	// StatusAgeMinutes is from entering the current status to the calculation
+	StatusAgeMinutes int64
+	CalculatedDate   time.Time
+	common.NoPKModel
+}
+
+func (BoardWipItem) TableName() string {
+	return "board_wip_items"
+}
@@ -0,0 +1,39 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueCustomFieldHistory is a period the customized field of an issue held a value, derived from the changelogs
+type IssueCustomFieldHistory struct {
+	IssueId    string    `gorm:"primaryKey;type:varchar(255)"`
+	FieldId    string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate  time.Time `gorm:"primaryKey"`
+	EndDate    *time.Time
+	FieldValue string
+	IsCurrent  bool
+	common.NoPKModel
+}
+
+func (IssueCustomFieldHistory) TableName() string {
+	return "issue_custom_field_histories"
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueHierarchy is the closure of the issue hierarchy of a project, built from the parent issues, the epic keys and
+// the issue relationships, so that all the descendants of an issue are found without recursive queries
+type IssueHierarchy struct {
+	ProjectName       string `gorm:"primaryKey;type:varchar(100)"`
+	AncestorIssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	DescendantIssueId string `gorm:"primaryKey;type:varchar(255);index"`
+	// Depth is 1 for the children, 2 for the grandchildren and so on
+	Depth int
+	common.NoPKModel
+}
+
+func (IssueHierarchy) TableName() string {
+	return "issue_hierarchies"
+}
+
+// IssueRollup is the progress of an issue with descendants, like an epic or an initiative, rolled up from the work
+// items under it, which are the descendants without children
+type IssueRollup struct {
+	ProjectName string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId     string `gorm:"primaryKey;type:varchar(255)"`
+	IssueKey    string `gorm:"type:varchar(255)"`
+	Title       string
+	Type        string `gorm:"type:varchar(100)"`
+	Status      string `gorm:"type:varchar(100)"`
+	// ParentIssueId is the parent in the hierarchy, e.g. the initiative of an epic
+	ParentIssueId string `gorm:"type:varchar(255)"`
+	// Level is 1 for the issues whose children are all work items, 2 for the parents of those and so on
+	Level                 int
+	TotalItems            int
+	TodoItems             int
+	InProgressItems       int
+	DoneItems             int
+	TotalStoryPoints      float64
+	DoneStoryPoints       float64
+	ProgressByCount       *float64
+	ProgressByStoryPoints *float64
+	PullRequests          int
+	OpenPullRequests      int
+	MergedPullRequests    int
+	DeployedPullRequests  int
+	LastDeployedDate      *time.Time
+	// ForecastCompletionDate comes from the board_forecasts of the issue key on the boards of the issue and its
+	// descendants when available
+	ForecastCompletionDate *time.Time
+	common.NoPKModel
+}
+
+func (IssueRollup) TableName() string {
+	return "issue_rollups"
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueSla is an SLA metric of an issue, like time to first response or time to resolution, over all its cycles
+type IssueSla struct {
+	IssueId string `gorm:"primaryKey;type:varchar(255)"`
+	Name    string `gorm:"primaryKey;type:varchar(255)"`
+	// GoalMinutes is the goal of the latest cycle, nil when the SLA has no goal
+	GoalMinutes    *int64
+	ElapsedMinutes int64
+	Cycles         int
+	BreachedCycles int
+	// Breached tells any of the cycles breached the goal
+	Breached bool
+	Ongoing  bool
+	Paused   bool
+	// StartDate is when the first cycle started, StopDate when the last one stopped
+	StartDate *time.Time
+	StopDate  *time.Time
+	// BreachDate is when the goal was first breached, or will be by the ongoing cycle
+	BreachDate *time.Time
+	common.NoPKModel
+}
+
+func (IssueSla) TableName() string {
+	return "issue_slas"
+}
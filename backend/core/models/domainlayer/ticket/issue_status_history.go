@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueStatusHistory is a period the issue stayed in a status, derived from the status changelogs
+type IssueStatusHistory struct {
+	IssueId         string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate       time.Time `gorm:"primaryKey"`
+	EndDate         *time.Time
+	Status          string `gorm:"type:varchar(100)"`
+	OriginalStatus  string `gorm:"type:varchar(255)"`
+	IsFirstStatus   bool
+	IsCurrentStatus bool
+	// Reopened tells the issue came back from DONE
+	Reopened        bool
+	DurationMinutes int64
+	common.NoPKModel
+}
+
+func (IssueStatusHistory) TableName() string {
+	return "issue_status_histories"
+}
+
+// IssueStatusDuration is the total time the issue spent in an original status
+type IssueStatusDuration struct {
+	IssueId         string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus  string `gorm:"primaryKey;type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	DurationMinutes int64
+	Times           int
+	common.NoPKModel
+}
+
+func (IssueStatusDuration) TableName() string {
+	return "issue_status_durations"
+}
+
+// IssueFlowMetric is the time the issue spent in the standard statuses, and how efficiently it flowed
+type IssueFlowMetric struct {
+	IssueId           string `gorm:"primaryKey;type:varchar(255)"`
+	TodoMinutes       int64
+	InProgressMinutes int64
+	DoneMinutes       int64
+	OtherMinutes      int64
+	BlockedMinutes    int64
+	// ActiveMinutes is the time in progress and not blocked
+	ActiveMinutes       int64
+	ReopenCount         int
+	FirstInProgressDate *time.Time
+	DoneDate            *time.Time
+	// CycleTimeMinutes is from the first time in progress to done
+	CycleTimeMinutes *int64
+	// FlowEfficiency is the ratio of the active time to the cycle time
+	FlowEfficiency *float64
+	common.NoPKModel
+}
+
+func (IssueFlowMetric) TableName() string {
+	return "issue_flow_metrics"
+}
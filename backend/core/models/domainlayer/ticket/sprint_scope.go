@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	SprintIssueAdded   = "ADDED"
+	SprintIssueRemoved = "REMOVED"
+)
+
+// SprintIssueEvent is an issue entering or leaving a sprint, derived from the Sprint changelogs
+type SprintIssueEvent struct {
+	SprintId  string    `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string    `gorm:"primaryKey;type:varchar(255)"`
+	EventDate time.Time `gorm:"primaryKey"`
+	Action    string    `gorm:"primaryKey;type:varchar(20)"`
+	// AfterSprintStart tells the change happened while the sprint was running
+	AfterSprintStart bool
+	StoryPoint       *float64
+	common.NoPKModel
+}
+
+func (SprintIssueEvent) TableName() string {
+	return "sprint_issue_events"
+}
+
+// SprintScopeMetric is the committed and delivered scope of a started sprint
+type SprintScopeMetric struct {
+	SprintId string `gorm:"primaryKey;type:varchar(255)"`
+	// Committed issues were in the sprint when it started
+	CommittedIssues      int
+	CommittedStoryPoints float64
+	// Added issues joined the sprint after it started
+	AddedIssues      int
+	AddedStoryPoints float64
+	// Removed issues were in the sprint at some point after it started, but not when it ended
+	RemovedIssues      int
+	RemovedStoryPoints float64
+	// Completed issues were in the sprint and resolved when it ended
+	CompletedIssues      int
+	CompletedStoryPoints float64
+	// CarriedOver issues were committed and left unfinished by an earlier sprint of the board
+	CarriedOverIssues      int
+	CarriedOverStoryPoints float64
+	common.NoPKModel
+}
+
+func (SprintScopeMetric) TableName() string {
+	return "sprint_scope_metrics"
+}
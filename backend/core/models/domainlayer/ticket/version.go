@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+const (
+	FixVersion     = "FIX_VERSION"
+	AffectsVersion = "AFFECTS_VERSION"
+)
+
+// Version is a release planned in the issue tracker, its name usually matches the tag of the cicd_releases,
+// which are linked to it by the version_releases of the linker plugin
+type Version struct {
+	domainlayer.DomainEntity
+	Name            string `gorm:"type:varchar(255)"`
+	Description     string
+	Url             string `gorm:"type:varchar(255)"`
+	Released        bool
+	Archived        bool
+	StartDate       *time.Time
+	ReleaseDate     *time.Time
+	OriginalProject string `gorm:"type:varchar(255)"`
+}
+
+func (Version) TableName() string {
+	return "versions"
+}
+
+// IssueVersion relates an issue to the version it is fixed in, or the version it affects
+type IssueVersion struct {
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	Type      string `gorm:"primaryKey;type:varchar(20)"`
+	common.NoPKModel
+}
+
+func (IssueVersion) TableName() string {
+	return "issue_versions"
+}
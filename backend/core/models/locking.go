@@ -49,3 +49,13 @@ type LockingStub struct {
 func (LockingStub) TableName() string {
 	return "_devlake_locking_stub"
 }
+
+// MigrationLockingStub is locked while migrating the database, so that the devlake instances and the `lake migrate`
+// commands sharing the database migrate it one after another
+type MigrationLockingStub struct {
+	Stub string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (MigrationLockingStub) TableName() string {
+	return "_devlake_migration_locking_stub"
+}
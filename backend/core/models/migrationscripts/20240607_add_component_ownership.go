@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addComponentOwnership)(nil)
+
+type component20240607Before struct {
+	RepoId    string `gorm:"type:varchar(255)"`
+	Name      string `gorm:"primaryKey;type:varchar(255)"`
+	PathRegex string `gorm:"type:varchar(255)"`
+}
+
+type component20240607After struct {
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	PathRegex    string `gorm:"type:varchar(255)"`
+	Source       string `gorm:"type:varchar(100)"`
+	SortingIndex int
+}
+
+type componentOwner20240607 struct {
+	RepoId        string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentName string `gorm:"primaryKey;type:varchar(255)"`
+	Owner         string `gorm:"primaryKey;type:varchar(100)"`
+	TeamId        string `gorm:"primaryKey;type:varchar(150)"`
+	archived.NoPKModel
+}
+
+func (componentOwner20240607) TableName() string {
+	return "component_owners"
+}
+
+type addComponentOwnership struct{}
+
+func (script *addComponentOwnership) Up(basicRes context.BasicRes) errors.Error {
+	// components are managed per repo now, so the repo_id becomes part of the primary key
+	err := migrationhelper.TransformTable(
+		basicRes,
+		script,
+		"components",
+		func(s *component20240607Before) (*component20240607After, errors.Error) {
+			return &component20240607After{
+				RepoId:    s.RepoId,
+				Name:      s.Name,
+				PathRegex: s.PathRegex,
+				Source:    "MANUAL",
+			}, nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return basicRes.GetDal().AutoMigrate(&componentOwner20240607{})
+}
+
+func (*addComponentOwnership) Version() uint64 {
+	return 20240607100000
+}
+
+func (*addComponentOwnership) Name() string {
+	return "add repo_id to the primary key of components and add component_owners"
+}
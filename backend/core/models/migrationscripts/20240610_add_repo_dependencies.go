@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addRepoDependencies)(nil)
+
+type repoDependency20240610 struct {
+	archived.DomainEntity
+	RepoId       string `gorm:"index;type:varchar(255)"`
+	RefName      string `gorm:"type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	ManifestPath string `gorm:"type:text"`
+	Ecosystem    string `gorm:"type:varchar(50)"`
+	PackageName  string `gorm:"index;type:varchar(255)"`
+	Version      string `gorm:"type:varchar(255)"`
+	Direct       bool
+}
+
+func (repoDependency20240610) TableName() string {
+	return "repo_dependencies"
+}
+
+type addRepoDependencies struct{}
+
+func (*addRepoDependencies) Up(basicRes context.BasicRes) errors.Error {
+	return basicRes.GetDal().AutoMigrate(&repoDependency20240610{})
+}
+
+func (*addRepoDependencies) Version() uint64 {
+	return 20240610100000
+}
+
+func (*addRepoDependencies) Name() string {
+	return "add repo_dependencies table"
+}
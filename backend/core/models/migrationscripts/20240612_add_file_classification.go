@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*addFileClassification)(nil)
+
+type commitFile20240612 struct {
+	Language string `gorm:"type:varchar(100)"`
+	Category string `gorm:"type:varchar(100)"`
+}
+
+func (commitFile20240612) TableName() string {
+	return "commit_files"
+}
+
+type repoSnapshot20240612 struct {
+	Language string `gorm:"type:varchar(100)"`
+	Category string `gorm:"type:varchar(100)"`
+}
+
+func (repoSnapshot20240612) TableName() string {
+	return "repo_snapshot"
+}
+
+type addFileClassification struct{}
+
+func (*addFileClassification) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	if err := db.AutoMigrate(&commitFile20240612{}); err != nil {
+		return err
+	}
+	return db.AutoMigrate(&repoSnapshot20240612{})
+}
+
+func (*addFileClassification) Version() uint64 {
+	return 20240612100000
+}
+
+func (*addFileClassification) Name() string {
+	return "add language and category to commit_files and repo_snapshot"
+}
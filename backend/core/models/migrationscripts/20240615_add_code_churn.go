@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCodeChurn)(nil)
+
+type commitChurn20240615 struct {
+	RepoId          string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha       string `gorm:"primaryKey;type:varchar(40)"`
+	AuthorId        string `gorm:"index;type:varchar(255)"`
+	AuthoredDate    time.Time
+	AddedLines      int
+	DeletedLines    int
+	NewWorkLines    int
+	ReworkLines     int
+	HelpOthersLines int
+	RefactorLines   int
+	archived.NoPKModel
+}
+
+func (commitChurn20240615) TableName() string {
+	return "commit_churns"
+}
+
+type commitLineRewrite20240615 struct {
+	RepoId          string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha       string `gorm:"primaryKey;type:varchar(40)"`
+	SourceCommitSha string `gorm:"primaryKey;type:varchar(40)"`
+	AuthorId        string `gorm:"index;type:varchar(255)"`
+	SourceAuthorId  string `gorm:"index;type:varchar(255)"`
+	Lines           int
+	AgeDays         float64
+	IsRework        bool
+	archived.NoPKModel
+}
+
+func (commitLineRewrite20240615) TableName() string {
+	return "commit_line_rewrites"
+}
+
+type repoAuthorChurn20240615 struct {
+	RepoId               string `gorm:"primaryKey;type:varchar(255)"`
+	AuthorId             string `gorm:"primaryKey;type:varchar(255)"`
+	Commits              int
+	AddedLines           int
+	DeletedLines         int
+	NewWorkLines         int
+	ReworkLines          int
+	HelpOthersLines      int
+	RefactorLines        int
+	ReworkedByOthers     int
+	SurvivalHalfLifeDays *float64
+	archived.NoPKModel
+}
+
+func (repoAuthorChurn20240615) TableName() string {
+	return "repo_author_churns"
+}
+
+type addCodeChurn struct{}
+
+func (*addCodeChurn) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&commitChurn20240615{},
+		&commitLineRewrite20240615{},
+		&repoAuthorChurn20240615{},
+	)
+}
+
+func (*addCodeChurn) Version() uint64 {
+	return 20240615100000
+}
+
+func (*addCodeChurn) Name() string {
+	return "add commit_churns, commit_line_rewrites and repo_author_churns tables"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addUserAccountCandidates)(nil)
+
+type userAccountCandidate20240620 struct {
+	AccountId string `gorm:"primaryKey;type:varchar(255)"`
+	UserId    string `gorm:"primaryKey;type:varchar(255)"`
+	Score     float64
+	Reasons   string `gorm:"type:varchar(255)"`
+	Status    string `gorm:"index;type:varchar(20)"`
+	archived.NoPKModel
+}
+
+func (userAccountCandidate20240620) TableName() string {
+	return "user_account_candidates"
+}
+
+type addUserAccountCandidates struct{}
+
+func (*addUserAccountCandidates) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&userAccountCandidate20240620{},
+	)
+}
+
+func (*addUserAccountCandidates) Version() uint64 {
+	return 20240620100000
+}
+
+func (*addUserAccountCandidates) Name() string {
+	return "add user_account_candidates table"
+}
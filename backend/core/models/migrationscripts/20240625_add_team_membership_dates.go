@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addTeamMembershipDates)(nil)
+
+type teamUser20240625Before struct {
+	TeamId string `gorm:"primaryKey;type:varchar(255)"`
+	UserId string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+type teamUser20240625After struct {
+	TeamId    string    `gorm:"primaryKey;type:varchar(255)"`
+	UserId    string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate time.Time `gorm:"primaryKey"`
+	EndDate   *time.Time
+	archived.NoPKModel
+}
+
+type teamAttribution20240625 struct {
+	RowTable string `gorm:"primaryKey;type:varchar(50)"`
+	RowId    string `gorm:"primaryKey;type:varchar(255)"`
+	TeamId   string `gorm:"primaryKey;type:varchar(255)"`
+	UserId   string `gorm:"index;type:varchar(255)"`
+	Date     *time.Time
+	archived.NoPKModel
+}
+
+func (teamAttribution20240625) TableName() string {
+	return "team_attributions"
+}
+
+type addTeamMembershipDates struct{}
+
+func (script *addTeamMembershipDates) Up(basicRes context.BasicRes) errors.Error {
+	// a user may leave and rejoin a team, so the start_date becomes part of the primary key
+	err := migrationhelper.TransformTable(
+		basicRes,
+		script,
+		"team_users",
+		func(s *teamUser20240625Before) (*teamUser20240625After, errors.Error) {
+			return &teamUser20240625After{
+				TeamId:    s.TeamId,
+				UserId:    s.UserId,
+				StartDate: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+				NoPKModel: s.NoPKModel,
+			}, nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(basicRes, &teamAttribution20240625{})
+}
+
+func (*addTeamMembershipDates) Version() uint64 {
+	return 20240625100000
+}
+
+func (*addTeamMembershipDates) Name() string {
+	return "add start_date to the primary key of team_users, add end_date to team_users and team_attributions table"
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueFlow)(nil)
+
+type issueStatusHistory20240701 struct {
+	IssueId         string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate       time.Time `gorm:"primaryKey"`
+	EndDate         *time.Time
+	Status          string `gorm:"type:varchar(100)"`
+	OriginalStatus  string `gorm:"type:varchar(255)"`
+	IsFirstStatus   bool
+	IsCurrentStatus bool
+	Reopened        bool
+	DurationMinutes int64
+	archived.NoPKModel
+}
+
+func (issueStatusHistory20240701) TableName() string {
+	return "issue_status_histories"
+}
+
+type issueStatusDuration20240701 struct {
+	IssueId         string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus  string `gorm:"primaryKey;type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	DurationMinutes int64
+	Times           int
+	archived.NoPKModel
+}
+
+func (issueStatusDuration20240701) TableName() string {
+	return "issue_status_durations"
+}
+
+type issueFlowMetric20240701 struct {
+	IssueId             string `gorm:"primaryKey;type:varchar(255)"`
+	TodoMinutes         int64
+	InProgressMinutes   int64
+	DoneMinutes         int64
+	OtherMinutes        int64
+	BlockedMinutes      int64
+	ActiveMinutes       int64
+	ReopenCount         int
+	FirstInProgressDate *time.Time
+	DoneDate            *time.Time
+	CycleTimeMinutes    *int64
+	FlowEfficiency      *float64
+	archived.NoPKModel
+}
+
+func (issueFlowMetric20240701) TableName() string {
+	return "issue_flow_metrics"
+}
+
+type addIssueFlow struct{}
+
+func (*addIssueFlow) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&issueStatusHistory20240701{},
+		&issueStatusDuration20240701{},
+		&issueFlowMetric20240701{},
+	)
+}
+
+func (*addIssueFlow) Version() uint64 {
+	return 20240701100000
+}
+
+func (*addIssueFlow) Name() string {
+	return "add issue_status_histories, issue_status_durations and issue_flow_metrics tables"
+}
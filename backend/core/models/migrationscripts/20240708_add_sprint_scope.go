@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addSprintScope)(nil)
+
+type sprintIssueEvent20240708 struct {
+	SprintId         string    `gorm:"primaryKey;type:varchar(255)"`
+	IssueId          string    `gorm:"primaryKey;type:varchar(255)"`
+	EventDate        time.Time `gorm:"primaryKey"`
+	Action           string    `gorm:"primaryKey;type:varchar(20)"`
+	AfterSprintStart bool
+	StoryPoint       *float64
+	archived.NoPKModel
+}
+
+func (sprintIssueEvent20240708) TableName() string {
+	return "sprint_issue_events"
+}
+
+type sprintScopeMetric20240708 struct {
+	SprintId               string `gorm:"primaryKey;type:varchar(255)"`
+	CommittedIssues        int
+	CommittedStoryPoints   float64
+	AddedIssues            int
+	AddedStoryPoints       float64
+	RemovedIssues          int
+	RemovedStoryPoints     float64
+	CompletedIssues        int
+	CompletedStoryPoints   float64
+	CarriedOverIssues      int
+	CarriedOverStoryPoints float64
+	archived.NoPKModel
+}
+
+func (sprintScopeMetric20240708) TableName() string {
+	return "sprint_scope_metrics"
+}
+
+type addSprintScope struct{}
+
+func (*addSprintScope) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sprintIssueEvent20240708{},
+		&sprintScopeMetric20240708{},
+	)
+}
+
+func (*addSprintScope) Version() uint64 {
+	return 20240708100000
+}
+
+func (*addSprintScope) Name() string {
+	return "add sprint_issue_events and sprint_scope_metrics tables"
+}
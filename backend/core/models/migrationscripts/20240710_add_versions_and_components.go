@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addVersionsAndComponents)(nil)
+
+type version20240710 struct {
+	archived.DomainEntity
+	Name            string `gorm:"type:varchar(255)"`
+	Description     string
+	Url             string `gorm:"type:varchar(255)"`
+	Released        bool
+	Archived        bool
+	StartDate       *time.Time
+	ReleaseDate     *time.Time
+	OriginalProject string `gorm:"type:varchar(255)"`
+}
+
+func (version20240710) TableName() string {
+	return "versions"
+}
+
+type issueVersion20240710 struct {
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	Type      string `gorm:"primaryKey;type:varchar(20)"`
+	archived.NoPKModel
+}
+
+func (issueVersion20240710) TableName() string {
+	return "issue_versions"
+}
+
+type component20240710 struct {
+	archived.DomainEntity
+	Name            string `gorm:"type:varchar(255)"`
+	Description     string
+	Url             string `gorm:"type:varchar(255)"`
+	LeadId          string `gorm:"type:varchar(255)"`
+	OriginalProject string `gorm:"type:varchar(255)"`
+}
+
+func (component20240710) TableName() string {
+	return "components"
+}
+
+type issueComponent20240710 struct {
+	IssueId     string `gorm:"primaryKey;type:varchar(255)"`
+	ComponentId string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (issueComponent20240710) TableName() string {
+	return "issue_components"
+}
+
+type addVersionsAndComponents struct{}
+
+func (*addVersionsAndComponents) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&version20240710{},
+		&issueVersion20240710{},
+		&component20240710{},
+		&issueComponent20240710{},
+	)
+}
+
+func (*addVersionsAndComponents) Version() uint64 {
+	return 20240710100000
+}
+
+func (*addVersionsAndComponents) Name() string {
+	return "add versions, issue_versions, components and issue_components tables"
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueCustomFieldHistories)(nil)
+
+type issueCustomFieldHistory20240718 struct {
+	IssueId    string    `gorm:"primaryKey;type:varchar(255)"`
+	FieldId    string    `gorm:"primaryKey;type:varchar(255)"`
+	StartDate  time.Time `gorm:"primaryKey"`
+	EndDate    *time.Time
+	FieldValue string
+	IsCurrent  bool
+	archived.NoPKModel
+}
+
+func (issueCustomFieldHistory20240718) TableName() string {
+	return "issue_custom_field_histories"
+}
+
+type addIssueCustomFieldHistories struct{}
+
+func (*addIssueCustomFieldHistories) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &issueCustomFieldHistory20240718{})
+}
+
+func (*addIssueCustomFieldHistories) Version() uint64 {
+	return 20240718100000
+}
+
+func (*addIssueCustomFieldHistories) Name() string {
+	return "add issue_custom_field_histories table"
+}
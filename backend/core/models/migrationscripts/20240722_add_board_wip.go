@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBoardWip)(nil)
+
+type boardWipLimit20240722 struct {
+	BoardId        string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus string `gorm:"primaryKey;type:varchar(255)"`
+	WipLimit       int
+	archived.NoPKModel
+}
+
+func (boardWipLimit20240722) TableName() string {
+	return "board_wip_limits"
+}
+
+type boardStatusWip20240722 struct {
+	BoardId        string    `gorm:"primaryKey;type:varchar(255)"`
+	Date           time.Time `gorm:"primaryKey"`
+	OriginalStatus string    `gorm:"primaryKey;type:varchar(255)"`
+	Status         string    `gorm:"type:varchar(100)"`
+	WipCount       int
+	WipLimit       *int
+	OverLimit      bool
+	archived.NoPKModel
+}
+
+func (boardStatusWip20240722) TableName() string {
+	return "board_status_wips"
+}
+
+type boardWipItem20240722 struct {
+	BoardId          string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId          string `gorm:"primaryKey;type:varchar(255)"`
+	IssueKey         string `gorm:"type:varchar(255)"`
+	Title            string
+	OriginalStatus   string `gorm:"type:varchar(255)"`
+	Status           string `gorm:"type:varchar(100)"`
+	InProgressDate   *time.Time
+	StatusStartDate  time.Time
+	AgeMinutes       int64
+	StatusAgeMinutes int64
+	CalculatedDate   time.Time
+	archived.NoPKModel
+}
+
+func (boardWipItem20240722) TableName() string {
+	return "board_wip_items"
+}
+
+type addBoardWip struct{}
+
+func (*addBoardWip) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&boardWipLimit20240722{},
+		&boardStatusWip20240722{},
+		&boardWipItem20240722{},
+	)
+}
+
+func (*addBoardWip) Version() uint64 {
+	return 20240722100000
+}
+
+func (*addBoardWip) Name() string {
+	return "add board_wip_limits, board_status_wips and board_wip_items tables"
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBoardForecasts)(nil)
+
+type boardForecast20240724 struct {
+	BoardId        string `gorm:"primaryKey;type:varchar(255)"`
+	Scope          string `gorm:"primaryKey;type:varchar(255)"`
+	Question       string `gorm:"primaryKey;type:varchar(20)"`
+	HorizonDays    int    `gorm:"primaryKey"`
+	Confidence     int    `gorm:"primaryKey"`
+	TargetDate     *time.Time
+	RemainingItems int
+	CompletionDate *time.Time
+	CompletedItems *int
+	HistoryDays    int
+	Simulations    int
+	CalculatedDate time.Time
+	archived.NoPKModel
+}
+
+func (boardForecast20240724) TableName() string {
+	return "board_forecasts"
+}
+
+type addBoardForecasts struct{}
+
+func (*addBoardForecasts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &boardForecast20240724{})
+}
+
+func (*addBoardForecasts) Version() uint64 {
+	return 20240724100000
+}
+
+func (*addBoardForecasts) Name() string {
+	return "add board_forecasts table"
+}
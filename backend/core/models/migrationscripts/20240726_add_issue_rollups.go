@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueRollups)(nil)
+
+type issueHierarchy20240726 struct {
+	ProjectName       string `gorm:"primaryKey;type:varchar(100)"`
+	AncestorIssueId   string `gorm:"primaryKey;type:varchar(255)"`
+	DescendantIssueId string `gorm:"primaryKey;type:varchar(255);index"`
+	Depth             int
+	archived.NoPKModel
+}
+
+func (issueHierarchy20240726) TableName() string {
+	return "issue_hierarchies"
+}
+
+type issueRollup20240726 struct {
+	ProjectName            string `gorm:"primaryKey;type:varchar(100)"`
+	IssueId                string `gorm:"primaryKey;type:varchar(255)"`
+	IssueKey               string `gorm:"type:varchar(255)"`
+	Title                  string
+	Type                   string `gorm:"type:varchar(100)"`
+	Status                 string `gorm:"type:varchar(100)"`
+	ParentIssueId          string `gorm:"type:varchar(255)"`
+	Level                  int
+	TotalItems             int
+	TodoItems              int
+	InProgressItems        int
+	DoneItems              int
+	TotalStoryPoints       float64
+	DoneStoryPoints        float64
+	ProgressByCount        *float64
+	ProgressByStoryPoints  *float64
+	PullRequests           int
+	OpenPullRequests       int
+	MergedPullRequests     int
+	DeployedPullRequests   int
+	LastDeployedDate       *time.Time
+	ForecastCompletionDate *time.Time
+	archived.NoPKModel
+}
+
+func (issueRollup20240726) TableName() string {
+	return "issue_rollups"
+}
+
+type addIssueRollups struct{}
+
+func (*addIssueRollups) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &issueHierarchy20240726{}, &issueRollup20240726{})
+}
+
+func (*addIssueRollups) Version() uint64 {
+	return 20240726100000
+}
+
+func (*addIssueRollups) Name() string {
+	return "add issue_hierarchies and issue_rollups tables"
+}
@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addVersionReleases)(nil)
+
+type versionRelease20240731 struct {
+	VersionId string `gorm:"primaryKey;type:varchar(255)"`
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (versionRelease20240731) TableName() string {
+	return "version_releases"
+}
+
+type addVersionReleases struct{}
+
+func (*addVersionReleases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, &versionRelease20240731{})
+}
+
+func (*addVersionReleases) Version() uint64 {
+	return 20240731100000
+}
+
+func (*addVersionReleases) Name() string {
+	return "add version_releases table"
+}
@@ -119,5 +119,22 @@ func All() []plugin.MigrationScript {
 		new(updatePluginOptionInProjectMetricSetting),
 		new(modifyCicdDeploymentCommitsRepoUrlLength),
 		new(modifyCicdPipelineCommitsRepoUrlLength),
+		new(addPriorityToPipelines),
+		new(addSourceWebhooks),
+		new(addComponentOwnership),
+		new(addRepoDependencies),
+		new(addFileClassification),
+		new(addCodeChurn),
+		new(addUserAccountCandidates),
+		new(addTeamMembershipDates),
+		new(addIssueFlow),
+		new(addSprintScope),
+		new(addVersionsAndComponents),
+		new(addIssueSlas),
+		new(addIssueCustomFieldHistories),
+		new(addBoardWip),
+		new(addBoardForecasts),
+		new(addIssueRollups),
+		new(addVersionReleases),
 	}
 }
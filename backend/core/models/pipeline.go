@@ -68,7 +68,10 @@ type Pipeline struct {
 	ErrorName     string       `json:"errorName"`
 	SpentSeconds  int          `json:"spentSeconds"`
 	Stage         int          `json:"stage"`
+	Priority      int          `json:"priority"`
 	Labels        []string     `json:"labels" gorm:"-"`
+	// QueuePosition is the 1-based position of a waiting pipeline in the queue, 0 if it is not waiting
+	QueuePosition int `json:"queuePosition" gorm:"-"`
 	SyncPolicy    `gorm:"embedded"`
 }
 
@@ -79,7 +82,9 @@ type NewPipeline struct {
 	Plan        PipelinePlan `json:"plan" swaggertype:"array,string" example:"please check api /pipelines/<PLUGIN_NAME>/pipeline-plan"`
 	Labels      []string     `json:"labels"`
 	BlueprintId uint64
-	SyncPolicy  `gorm:"embedded"`
+	// Priority decides the order of waiting pipelines, the higher the earlier
+	Priority   int `json:"priority"`
+	SyncPolicy `gorm:"embedded"`
 }
 
 func (Pipeline) TableName() string {
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// SourceWebhook holds the secret to verify the webhooks sent by the data source of a plugin connection
+type SourceWebhook struct {
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	PluginName   string    `json:"pluginName" gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64    `json:"connectionId" gorm:"primaryKey"`
+	Secret       string    `json:"secret,omitempty" gorm:"serializer:encdec"`
+}
+
+func (SourceWebhook) TableName() string {
+	return "_devlake_source_webhooks"
+}
@@ -36,6 +36,9 @@ const (
 
 var PendingTaskStatus = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING}
 
+// WaitingTaskStatus are the statuses of pipelines waiting in the queue
+var WaitingTaskStatus = []string{TASK_CREATED, TASK_RERUN, TASK_RESUME}
+
 type TaskProgressDetail struct {
 	TotalSubTasks        int    `json:"totalSubTasks"`
 	FinishedSubTasks     int    `json:"finishedSubTasks"`
@@ -31,7 +31,8 @@ type PluginMetric interface {
 	IsProjectMetric() bool
 
 	// indicates which plugins must be executed before executing this one.
-	// declare a set of dependencies with this
+	// declare a set of dependencies with this, the blueprints put the plan of the plugin in the stages
+	// after the plans of these plugins when they are enabled as well
 	RunAfter() ([]string, errors.Error)
 
 	// returns an empty pointer of the plugin setting struct.
@@ -34,6 +34,7 @@ type Migrator interface {
 	Register(scripts []MigrationScript, comment string)
 	Execute() errors.Error
 	HasPendingScripts() bool
+	PendingScripts() []MigrationScript
 }
 
 // PluginMigration is implemented by the plugin to declare all migration script that have to be applied to the database
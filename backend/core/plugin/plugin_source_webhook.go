@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// SourceWebhookEvent is an event sent by the data source, e.g. a Pull Request was opened on GitHub
+type SourceWebhookEvent struct {
+	// Name of the event, for logging purpose only
+	Name string
+	// ScopeIds are the ids of the Scopes affected by the event, leave it empty if the event should be ignored
+	ScopeIds []string
+}
+
+// DataSourcePluginWebhook is implemented by the data source plugins which could receive webhooks from the source,
+// the framework would trigger incremental pipelines for the affected scopes of the blueprints that contain them
+type DataSourcePluginWebhook interface {
+	// ParseSourceWebhook verifies the request with the webhook secret of the connection,
+	// and returns the scopes affected by the event
+	ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*SourceWebhookEvent, errors.Error)
+}
@@ -0,0 +1,333 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// SprintChangelogField is the standard field name of the changelogs moving issues between sprints,
+// their original values are the comma separated domain ids of the sprints
+const SprintChangelogField = "Sprint"
+
+type sprintMembershipChange struct {
+	date  time.Time
+	added bool
+}
+
+// SprintScopeTracker rebuilds the membership history of the issues in a set of sprints from the Sprint
+// changelogs, and derives the committed, added, removed, completed and carried-over scope of each sprint
+type SprintScopeTracker struct {
+	sprints    []*ticket.Sprint
+	sprintById map[string]*ticket.Sprint
+	issues     map[string]*ticket.Issue
+	// sprint id => issue id => membership changes ordered by date
+	membership map[string]map[string][]sprintMembershipChange
+	events     []*ticket.SprintIssueEvent
+}
+
+// NewSprintScopeTracker creates a tracker for the sprints, changes to any other sprint are ignored
+func NewSprintScopeTracker(sprints []*ticket.Sprint) *SprintScopeTracker {
+	tracker := &SprintScopeTracker{
+		sprints:    sprints,
+		sprintById: make(map[string]*ticket.Sprint, len(sprints)),
+		issues:     make(map[string]*ticket.Issue),
+		membership: make(map[string]map[string][]sprintMembershipChange, len(sprints)),
+	}
+	for _, sprint := range sprints {
+		tracker.sprintById[sprint.Id] = sprint
+		tracker.membership[sprint.Id] = make(map[string][]sprintMembershipChange)
+	}
+	return tracker
+}
+
+// AddIssue replays the Sprint changelogs of the issue. The sprints the issue belonged to before its first change,
+// or its current sprints if it never changed, are considered joined when the issue was created
+func (t *SprintScopeTracker) AddIssue(issue *ticket.Issue, currentSprintIds []string, changelogs []*ticket.IssueChangelogs) {
+	t.issues[issue.Id] = issue
+	sprintChangelogs := make([]*ticket.IssueChangelogs, 0, len(changelogs))
+	for _, changelog := range changelogs {
+		if changelog.FieldName == SprintChangelogField {
+			sprintChangelogs = append(sprintChangelogs, changelog)
+		}
+	}
+	sort.SliceStable(sprintChangelogs, func(i, j int) bool {
+		return sprintChangelogs[i].CreatedDate.Before(sprintChangelogs[j].CreatedDate)
+	})
+
+	initialSprintIds := currentSprintIds
+	var createdDate time.Time
+	if len(sprintChangelogs) > 0 {
+		initialSprintIds = splitSprintIds(sprintChangelogs[0].OriginalFromValue)
+		createdDate = sprintChangelogs[0].CreatedDate
+	}
+	if issue.CreatedDate != nil {
+		createdDate = *issue.CreatedDate
+	}
+	current := make(map[string]bool)
+	for _, sprintId := range initialSprintIds {
+		current[sprintId] = true
+		t.change(issue, sprintId, createdDate, true)
+	}
+	for _, changelog := range sprintChangelogs {
+		next := make(map[string]bool)
+		for _, sprintId := range splitSprintIds(changelog.OriginalToValue) {
+			next[sprintId] = true
+			if !current[sprintId] {
+				t.change(issue, sprintId, changelog.CreatedDate, true)
+			}
+		}
+		for sprintId := range current {
+			if !next[sprintId] {
+				t.change(issue, sprintId, changelog.CreatedDate, false)
+			}
+		}
+		current = next
+	}
+}
+
+func (t *SprintScopeTracker) change(issue *ticket.Issue, sprintId string, date time.Time, added bool) {
+	sprint, ok := t.sprintById[sprintId]
+	if !ok {
+		return
+	}
+	t.membership[sprintId][issue.Id] = append(t.membership[sprintId][issue.Id], sprintMembershipChange{date: date, added: added})
+	// the creation date of the issue is unknown, the membership still counts but the event can't be dated
+	if date.IsZero() {
+		return
+	}
+	action := ticket.SprintIssueRemoved
+	if added {
+		action = ticket.SprintIssueAdded
+	}
+	t.events = append(t.events, &ticket.SprintIssueEvent{
+		SprintId:         sprintId,
+		IssueId:          issue.Id,
+		EventDate:        date,
+		Action:           action,
+		AfterSprintStart: sprint.StartedDate != nil && date.After(*sprint.StartedDate),
+		StoryPoint:       issue.StoryPoint,
+	})
+}
+
+// Events returns the sprint-issue events of all the replayed issues
+func (t *SprintScopeTracker) Events() []*ticket.SprintIssueEvent {
+	return t.events
+}
+
+// Metrics returns the scope metrics of the started sprints. A sprint ends when it is completed, or at its
+// planned end date if it is not, story points are the current story points of the issues
+func (t *SprintScopeTracker) Metrics() []*ticket.SprintScopeMetric {
+	metrics := make([]*ticket.SprintScopeMetric, 0, len(t.sprints))
+	for _, sprint := range t.sprints {
+		if sprint.StartedDate == nil {
+			continue
+		}
+		start := *sprint.StartedDate
+		end := sprintEndDate(sprint)
+		metric := &ticket.SprintScopeMetric{SprintId: sprint.Id}
+		for issueId, changes := range t.membership[sprint.Id] {
+			issue := t.issues[issueId]
+			storyPoint := 0.0
+			if issue.StoryPoint != nil {
+				storyPoint = *issue.StoryPoint
+			}
+			if isSprintMember(changes, start) {
+				metric.CommittedIssues++
+				metric.CommittedStoryPoints += storyPoint
+				if t.isCarriedOver(issue, sprint) {
+					metric.CarriedOverIssues++
+					metric.CarriedOverStoryPoints += storyPoint
+				}
+			} else if joinedSprintWithin(changes, start, end) {
+				metric.AddedIssues++
+				metric.AddedStoryPoints += storyPoint
+			} else {
+				continue
+			}
+			memberAtEnd := len(changes) > 0 && changes[len(changes)-1].added
+			if end != nil {
+				memberAtEnd = isSprintMember(changes, *end)
+			}
+			if !memberAtEnd {
+				metric.RemovedIssues++
+				metric.RemovedStoryPoints += storyPoint
+			} else if isResolvedBy(issue, end) {
+				metric.CompletedIssues++
+				metric.CompletedStoryPoints += storyPoint
+			}
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// isCarriedOver tells whether the issue was left unfinished in another sprint which ended before the sprint started
+func (t *SprintScopeTracker) isCarriedOver(issue *ticket.Issue, sprint *ticket.Sprint) bool {
+	for _, other := range t.sprints {
+		if other.Id == sprint.Id {
+			continue
+		}
+		otherEnd := sprintEndDate(other)
+		if otherEnd == nil || otherEnd.After(*sprint.StartedDate) {
+			continue
+		}
+		if isSprintMember(t.membership[other.Id][issue.Id], *otherEnd) && !isResolvedBy(issue, otherEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+func sprintEndDate(sprint *ticket.Sprint) *time.Time {
+	if sprint.CompletedDate != nil {
+		return sprint.CompletedDate
+	}
+	return sprint.EndedDate
+}
+
+func isSprintMember(changes []sprintMembershipChange, date time.Time) bool {
+	member := false
+	for _, change := range changes {
+		if change.date.After(date) {
+			break
+		}
+		member = change.added
+	}
+	return member
+}
+
+func joinedSprintWithin(changes []sprintMembershipChange, start time.Time, end *time.Time) bool {
+	for _, change := range changes {
+		if change.added && change.date.After(start) && (end == nil || !change.date.After(*end)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isResolvedBy(issue *ticket.Issue, date *time.Time) bool {
+	return issue.ResolutionDate != nil && (date == nil || !issue.ResolutionDate.After(*date))
+}
+
+func splitSprintIds(value string) []string {
+	var sprintIds []string
+	for _, sprintId := range strings.Split(value, ",") {
+		sprintId = strings.TrimSpace(sprintId)
+		if sprintId != "" {
+			sprintIds = append(sprintIds, sprintId)
+		}
+	}
+	return sprintIds
+}
+
+// TrackSprintScope replaces the sprint_issue_events and sprint_scope_metrics of the sprints of the board with the
+// ones derived from the domain layer sprints, sprint_issues and Sprint changelogs of the board issues
+func TrackSprintScope(taskCtx plugin.SubTaskContext, boardId string) errors.Error {
+	db := taskCtx.GetDal()
+	boardSprintIds := "SELECT sprint_id FROM board_sprints WHERE board_id = ?"
+	for _, table := range []interface{}{&ticket.SprintIssueEvent{}, &ticket.SprintScopeMetric{}} {
+		err := db.Delete(table, dal.Where("sprint_id IN ("+boardSprintIds+")", boardId))
+		if err != nil {
+			return err
+		}
+	}
+
+	var sprints []*ticket.Sprint
+	err := db.All(&sprints, dal.Where("id IN ("+boardSprintIds+")", boardId))
+	if err != nil {
+		return err
+	}
+	if len(sprints) == 0 {
+		return nil
+	}
+	var issues []*ticket.Issue
+	err = db.All(
+		&issues,
+		dal.Select("i.id, i.created_date, i.resolution_date, i.story_point"),
+		dal.From("issues i"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+		dal.Where("bi.board_id = ?", boardId),
+	)
+	if err != nil {
+		return err
+	}
+	var sprintIssues []*ticket.SprintIssue
+	err = db.All(&sprintIssues, dal.Where("sprint_id IN ("+boardSprintIds+")", boardId))
+	if err != nil {
+		return err
+	}
+	var changelogs []*ticket.IssueChangelogs
+	err = db.All(
+		&changelogs,
+		dal.Select("ic.*"),
+		dal.From("issue_changelogs ic"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = ic.issue_id"),
+		dal.Where("bi.board_id = ? AND ic.field_name = ?", boardId, SprintChangelogField),
+	)
+	if err != nil {
+		return err
+	}
+
+	currentSprintIds := make(map[string][]string)
+	for _, sprintIssue := range sprintIssues {
+		currentSprintIds[sprintIssue.IssueId] = append(currentSprintIds[sprintIssue.IssueId], sprintIssue.SprintId)
+	}
+	issueChangelogs := make(map[string][]*ticket.IssueChangelogs)
+	for _, changelog := range changelogs {
+		issueChangelogs[changelog.IssueId] = append(issueChangelogs[changelog.IssueId], changelog)
+	}
+	tracker := NewSprintScopeTracker(sprints)
+	for _, issue := range issues {
+		tracker.AddIssue(issue, currentSprintIds[issue.Id], issueChangelogs[issue.Id])
+	}
+
+	eventSaver, err := NewBatchSave(taskCtx, reflect.TypeOf(&ticket.SprintIssueEvent{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, event := range tracker.Events() {
+		err = eventSaver.Add(event)
+		if err != nil {
+			return err
+		}
+	}
+	err = eventSaver.Close()
+	if err != nil {
+		return err
+	}
+	metricSaver, err := NewBatchSave(taskCtx, reflect.TypeOf(&ticket.SprintScopeMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, metric := range tracker.Metrics() {
+		err = metricSaver.Add(metric)
+		if err != nil {
+			return err
+		}
+	}
+	return metricSaver.Close()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func day(d int) *time.Time {
+	t := time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func points(p float64) *float64 {
+	return &p
+}
+
+func sprintChangelog(issueId string, date *time.Time, from, to string) *ticket.IssueChangelogs {
+	return &ticket.IssueChangelogs{
+		IssueId:           issueId,
+		FieldName:         SprintChangelogField,
+		OriginalFromValue: from,
+		OriginalToValue:   to,
+		CreatedDate:       *date,
+	}
+}
+
+func TestSprintScopeTracker(t *testing.T) {
+	sprint1 := &ticket.Sprint{DomainEntity: domainlayer.DomainEntity{Id: "s1"}, StartedDate: day(1), CompletedDate: day(14)}
+	sprint2 := &ticket.Sprint{DomainEntity: domainlayer.DomainEntity{Id: "s2"}, StartedDate: day(15), EndedDate: day(28)}
+	future := &ticket.Sprint{DomainEntity: domainlayer.DomainEntity{Id: "s3"}}
+	tracker := NewSprintScopeTracker([]*ticket.Sprint{sprint1, sprint2, future})
+
+	// committed to s1, unfinished and carried over to s2 where it is completed
+	carried := &ticket.Issue{DomainEntity: domainlayer.DomainEntity{Id: "i1"}, CreatedDate: day(1), StoryPoint: points(3), ResolutionDate: day(20)}
+	tracker.AddIssue(carried, []string{"s2"}, []*ticket.IssueChangelogs{
+		sprintChangelog("i1", day(15), "s1", "s2"),
+	})
+	// never changed, committed and completed in s1
+	done := &ticket.Issue{DomainEntity: domainlayer.DomainEntity{Id: "i2"}, CreatedDate: day(1), StoryPoint: points(5), ResolutionDate: day(10)}
+	tracker.AddIssue(done, []string{"s1"}, nil)
+	// added to s1 mid-sprint then moved out
+	removed := &ticket.Issue{DomainEntity: domainlayer.DomainEntity{Id: "i3"}, CreatedDate: day(1), StoryPoint: points(2)}
+	tracker.AddIssue(removed, []string{"s3"}, []*ticket.IssueChangelogs{
+		sprintChangelog("i3", day(5), "", "s1"),
+		sprintChangelog("i3", day(8), "s1", "s3,other"),
+	})
+
+	actions := make(map[string][]string)
+	for _, event := range tracker.Events() {
+		key := event.SprintId + "/" + event.IssueId
+		actions[key] = append(actions[key], event.Action)
+	}
+	assert.Equal(t, []string{ticket.SprintIssueAdded, ticket.SprintIssueRemoved}, actions["s1/i1"])
+	assert.Equal(t, []string{ticket.SprintIssueAdded}, actions["s2/i1"])
+	assert.Equal(t, []string{ticket.SprintIssueAdded}, actions["s1/i2"])
+	assert.Equal(t, []string{ticket.SprintIssueAdded, ticket.SprintIssueRemoved}, actions["s1/i3"])
+	assert.Equal(t, []string{ticket.SprintIssueAdded}, actions["s3/i3"])
+	assert.Len(t, tracker.Events(), 7)
+
+	metrics := tracker.Metrics()
+	assert.Len(t, metrics, 2)
+	assert.Equal(t, &ticket.SprintScopeMetric{
+		SprintId:             "s1",
+		CommittedIssues:      2,
+		CommittedStoryPoints: 8,
+		AddedIssues:          1,
+		AddedStoryPoints:     2,
+		RemovedIssues:        1,
+		RemovedStoryPoints:   2,
+		CompletedIssues:      1,
+		CompletedStoryPoints: 5,
+	}, metrics[0])
+	assert.Equal(t, &ticket.SprintScopeMetric{
+		SprintId:               "s2",
+		CommittedIssues:        1,
+		CommittedStoryPoints:   3,
+		CompletedIssues:        1,
+		CompletedStoryPoints:   3,
+		CarriedOverIssues:      1,
+		CarriedOverStoryPoints: 3,
+	}, metrics[1])
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// VerifyHmacSha256Signature verifies the signature in the format of "sha256=<hex digest>" which is
+// the HMAC-SHA256 of the body keyed by the secret, as sent by GitHub, Bitbucket and Jira webhooks
+func VerifyHmacSha256Signature(secret string, body []byte, signature string) errors.Error {
+	if secret == "" {
+		return errors.Unauthorized.New("webhook secret is not configured")
+	}
+	digest, found := strings.CutPrefix(signature, "sha256=")
+	if !found {
+		return errors.Unauthorized.New("missing or unsupported webhook signature")
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return errors.Unauthorized.Wrap(err, "malformed webhook signature")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.Unauthorized.New("webhook signature mismatched")
+	}
+	return nil
+}
+
+// VerifyWebhookToken verifies the plain token sent along with the webhook, as GitLab does
+func VerifyWebhookToken(secret string, token string) errors.Error {
+	if secret == "" {
+		return errors.Unauthorized.New("webhook secret is not configured")
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return errors.Unauthorized.New("webhook token mismatched")
+	}
+	return nil
+}
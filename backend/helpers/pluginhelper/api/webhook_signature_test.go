@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHmacSha256Signature(t *testing.T) {
+	body := []byte("Hello, World!")
+	// example from https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+	signature := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	assert.Nil(t, VerifyHmacSha256Signature("It's a Secret to Everybody", body, signature))
+	assert.NotNil(t, VerifyHmacSha256Signature("another secret", body, signature))
+	assert.NotNil(t, VerifyHmacSha256Signature("It's a Secret to Everybody", []byte("tampered"), signature))
+	assert.NotNil(t, VerifyHmacSha256Signature("It's a Secret to Everybody", body, "sha1=757107ea"))
+	assert.NotNil(t, VerifyHmacSha256Signature("It's a Secret to Everybody", body, "sha256=xyz"))
+	assert.NotNil(t, VerifyHmacSha256Signature("", body, signature))
+}
+
+func TestVerifyWebhookToken(t *testing.T) {
+	assert.Nil(t, VerifyWebhookToken("secret", "secret"))
+	assert.NotNil(t, VerifyWebhookToken("secret", "Secret"))
+	assert.NotNil(t, VerifyWebhookToken("", ""))
+}
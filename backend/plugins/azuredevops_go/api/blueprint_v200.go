@@ -176,15 +176,19 @@ func makePipelinePlanV200(
 			if scope.Scope.Type == models.RepositoryTypeADO {
 				cloneUrl.User = url.UserPassword("git", connection.Token)
 			}
+			gitextractorOptions := map[string]interface{}{
+				"url":            cloneUrl.String(),
+				"name":           azuredevopsRepo.Name,
+				"repoId":         didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(connection.ID, azuredevopsRepo.Id),
+				"proxy":          connection.Proxy,
+				"noShallowClone": true,
+			}
+			if len(scopeConfig.FileCategoryPatterns) > 0 {
+				gitextractorOptions["fileCategoryPatterns"] = scopeConfig.FileCategoryPatterns
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":            cloneUrl.String(),
-					"name":           azuredevopsRepo.Name,
-					"repoId":         didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(connection.ID, azuredevopsRepo.Id),
-					"proxy":          connection.Proxy,
-					"noShallowClone": true,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOptions,
 			})
 		}
 
@@ -31,6 +31,8 @@ type AzuredevopsScopeConfig struct {
 	DeploymentPattern string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern"`
 	ProductionPattern string            `mapstructure:"productionPattern,omitempty" json:"productionPattern"`
 	Refdiff           datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	// FileCategoryPatterns are regular expressions by file category to override the classification of gitextractor
+	FileCategoryPatterns map[string]string `mapstructure:"fileCategoryPatterns,omitempty" json:"fileCategoryPatterns" gorm:"type:json;serializer:json"`
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
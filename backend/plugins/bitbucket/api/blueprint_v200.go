@@ -122,15 +122,19 @@ func makeDataSourcePipelinePlanV200(
 				return nil, err
 			}
 			cloneUrl.User = url.UserPassword(connection.Username, connection.Password)
+			gitextractorOptions := map[string]interface{}{
+				"url":      cloneUrl.String(),
+				"name":     bitbucketRepo.BitbucketId,
+				"fullName": bitbucketRepo.BitbucketId,
+				"repoId":   didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(connection.ID, bitbucketRepo.BitbucketId),
+				"proxy":    connection.Proxy,
+			}
+			if len(scopeConfig.FileCategoryPatterns) > 0 {
+				gitextractorOptions["fileCategoryPatterns"] = scopeConfig.FileCategoryPatterns
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":      cloneUrl.String(),
-					"name":     bitbucketRepo.BitbucketId,
-					"fullName": bitbucketRepo.BitbucketId,
-					"repoId":   didgen.NewDomainIdGenerator(&models.BitbucketRepo{}).Generate(connection.ID, bitbucketRepo.BitbucketId),
-					"proxy":    connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOptions,
 			})
 
 		}
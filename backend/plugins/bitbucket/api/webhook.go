@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// webhookEventPrefixes are the event keys which might change the data we collect
+var webhookEventPrefixes = []string{"repo:push", "repo:commit_status_", "pullrequest:", "issue:"}
+
+type bitbucketWebhookPayload struct {
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseSourceWebhook verifies the X-Hub-Signature header and maps the event to the repo it was sent for
+func ParseSourceWebhook(secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	err := api.VerifyHmacSha256Signature(secret, body, header.Get("X-Hub-Signature"))
+	if err != nil {
+		return nil, err
+	}
+	event := &plugin.SourceWebhookEvent{Name: header.Get("X-Event-Key")}
+	relevant := false
+	for _, prefix := range webhookEventPrefixes {
+		if strings.HasPrefix(event.Name, prefix) {
+			relevant = true
+			break
+		}
+	}
+	if !relevant {
+		return event, nil
+	}
+	payload := &bitbucketWebhookPayload{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode the bitbucket webhook payload")
+	}
+	if payload.Repository != nil && payload.Repository.FullName != "" {
+		event.ScopeIds = []string{payload.Repository.FullName}
+	}
+	return event, nil
+}
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -42,6 +43,7 @@ var _ interface {
 	plugin.PluginMigration
 	plugin.CloseablePluginTask
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourcePluginWebhook
 	plugin.PluginSource
 } = (*Bitbucket)(nil)
 
@@ -193,6 +195,10 @@ func (p Bitbucket) MakeDataSourcePipelinePlanV200(
 	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
 }
 
+func (p Bitbucket) ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	return api.ParseSourceWebhook(secret, header, body)
+}
+
 func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	return map[string]map[string]plugin.ApiResourceHandler{
 		"test": {
@@ -32,6 +32,8 @@ type BitbucketServerScopeConfig struct {
 	// DeploymentPattern  string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern" gorm:"type:varchar(255)"`
 	// ProductionPattern  string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	Refdiff datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	// FileCategoryPatterns are regular expressions by file category to override the classification of gitextractor
+	FileCategoryPatterns map[string]string `mapstructure:"fileCategoryPatterns,omitempty" json:"fileCategoryPatterns" gorm:"type:json;serializer:json"`
 
 	// a string array, split by `,`.
 }
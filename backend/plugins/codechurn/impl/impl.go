@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/codechurn/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/codechurn/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginTask
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
+} = (*CodeChurn)(nil)
+
+type CodeChurn struct{}
+
+func (p CodeChurn) Description() string {
+	return "calculate rework and code churn metrics from the commit line changes"
+}
+
+func (p CodeChurn) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{
+		{
+			"model": "commit_line_change",
+		},
+	}, nil
+}
+
+func (p CodeChurn) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p CodeChurn) Name() string {
+	return "codechurn"
+}
+
+func (p CodeChurn) IsProjectMetric() bool {
+	return true
+}
+
+func (p CodeChurn) RunAfter() ([]string, errors.Error) {
+	return []string{}, nil
+}
+
+func (p CodeChurn) Settings() interface{} {
+	return nil
+}
+
+func (p CodeChurn) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CalculateCodeChurnMeta,
+	}
+}
+
+func (p CodeChurn) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.CodeChurnTaskData{
+		Options: op,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p CodeChurn) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/codechurn"
+}
+
+func (p CodeChurn) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p CodeChurn) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.CodeChurnOptions{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "codechurn",
+				Options: map[string]interface{}{
+					"projectName":      projectName,
+					"reworkWindowDays": op.ReworkWindowDays,
+				},
+				Subtasks: []string{
+					"calculateCodeChurn",
+				},
+			},
+		},
+	}
+	return plan, nil
+}
@@ -0,0 +1,216 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+type churnCommit struct {
+	Sha          string
+	AuthorId     string
+	AuthoredDate time.Time
+}
+
+// hunkChange counts the lines added and deleted by a hunk of a commit
+type hunkChange struct {
+	CommitSha string
+	Added     int
+	Deleted   int
+}
+
+// lineDeletion counts the lines written by PrevCommit and deleted by the commit
+type lineDeletion struct {
+	CommitSha  string
+	PrevCommit string
+	LineCount  int
+}
+
+// lineLifetime is how long the lines lived, the lines are censored if they are still alive
+type lineLifetime struct {
+	Days    float64
+	Lines   int
+	Deleted bool
+}
+
+// calculateCommitChurns classifies the changed lines of each commit. Within a hunk, the added lines replacing the
+// deleted ones are modifications, the rest are new work. The deleted lines are rework, help others or refactor by
+// the author and the age of the lines, the lines with unknown origin are counted as refactor.
+func calculateCommitChurns(
+	repoId string,
+	commits map[string]*churnCommit,
+	hunks []*hunkChange,
+	deletions []*lineDeletion,
+	reworkWindow time.Duration,
+) ([]*code.CommitChurn, []*code.CommitLineRewrite) {
+	churns := make(map[string]*code.CommitChurn)
+	getChurn := func(sha string) *code.CommitChurn {
+		churn, ok := churns[sha]
+		if !ok {
+			churn = &code.CommitChurn{RepoId: repoId, CommitSha: sha}
+			if commit, ok := commits[sha]; ok {
+				churn.AuthorId = commit.AuthorId
+				churn.AuthoredDate = commit.AuthoredDate
+			}
+			churns[sha] = churn
+		}
+		return churn
+	}
+	for _, hunk := range hunks {
+		churn := getChurn(hunk.CommitSha)
+		churn.AddedLines += hunk.Added
+		churn.DeletedLines += hunk.Deleted
+		if hunk.Added > hunk.Deleted {
+			churn.NewWorkLines += hunk.Added - hunk.Deleted
+		}
+	}
+	var rewrites []*code.CommitLineRewrite
+	for _, deletion := range deletions {
+		churn := getChurn(deletion.CommitSha)
+		source, ok := commits[deletion.PrevCommit]
+		if !ok {
+			churn.RefactorLines += deletion.LineCount
+			continue
+		}
+		rewrite := &code.CommitLineRewrite{
+			RepoId:          repoId,
+			CommitSha:       deletion.CommitSha,
+			SourceCommitSha: deletion.PrevCommit,
+			AuthorId:        churn.AuthorId,
+			SourceAuthorId:  source.AuthorId,
+			Lines:           deletion.LineCount,
+		}
+		age := churn.AuthoredDate.Sub(source.AuthoredDate)
+		rewrite.AgeDays = age.Hours() / 24
+		rewrite.IsRework = age <= reworkWindow
+		switch {
+		case !rewrite.IsRework:
+			churn.RefactorLines += deletion.LineCount
+		case source.AuthorId == churn.AuthorId:
+			churn.ReworkLines += deletion.LineCount
+		default:
+			churn.HelpOthersLines += deletion.LineCount
+		}
+		rewrites = append(rewrites, rewrite)
+	}
+	result := make([]*code.CommitChurn, 0, len(churns))
+	for _, churn := range churns {
+		result = append(result, churn)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CommitSha < result[j].CommitSha
+	})
+	sort.Slice(rewrites, func(i, j int) bool {
+		if rewrites[i].CommitSha != rewrites[j].CommitSha {
+			return rewrites[i].CommitSha < rewrites[j].CommitSha
+		}
+		return rewrites[i].SourceCommitSha < rewrites[j].SourceCommitSha
+	})
+	return result, rewrites
+}
+
+// calculateAuthorChurns sums up the churns by the authors, the lines still alive are censored at the latest commit
+// when estimating the survival half-life
+func calculateAuthorChurns(repoId string, churns []*code.CommitChurn, rewrites []*code.CommitLineRewrite) []*code.RepoAuthorChurn {
+	var observedUntil time.Time
+	for _, churn := range churns {
+		if churn.AuthoredDate.After(observedUntil) {
+			observedUntil = churn.AuthoredDate
+		}
+	}
+	deletedLines := make(map[string]int)
+	lifetimes := make(map[string][]lineLifetime)
+	authors := make(map[string]*code.RepoAuthorChurn)
+	getAuthor := func(authorId string) *code.RepoAuthorChurn {
+		author, ok := authors[authorId]
+		if !ok {
+			author = &code.RepoAuthorChurn{RepoId: repoId, AuthorId: authorId}
+			authors[authorId] = author
+		}
+		return author
+	}
+	for _, rewrite := range rewrites {
+		deletedLines[rewrite.SourceCommitSha] += rewrite.Lines
+		lifetimes[rewrite.SourceAuthorId] = append(lifetimes[rewrite.SourceAuthorId], lineLifetime{
+			Days:    rewrite.AgeDays,
+			Lines:   rewrite.Lines,
+			Deleted: true,
+		})
+		if rewrite.IsRework && rewrite.SourceAuthorId != rewrite.AuthorId {
+			getAuthor(rewrite.SourceAuthorId).ReworkedByOthers += rewrite.Lines
+		}
+	}
+	for _, churn := range churns {
+		author := getAuthor(churn.AuthorId)
+		author.Commits++
+		author.AddedLines += churn.AddedLines
+		author.DeletedLines += churn.DeletedLines
+		author.NewWorkLines += churn.NewWorkLines
+		author.ReworkLines += churn.ReworkLines
+		author.HelpOthersLines += churn.HelpOthersLines
+		author.RefactorLines += churn.RefactorLines
+		if alive := churn.AddedLines - deletedLines[churn.CommitSha]; alive > 0 {
+			lifetimes[churn.AuthorId] = append(lifetimes[churn.AuthorId], lineLifetime{
+				Days:  observedUntil.Sub(churn.AuthoredDate).Hours() / 24,
+				Lines: alive,
+			})
+		}
+	}
+	result := make([]*code.RepoAuthorChurn, 0, len(authors))
+	for authorId, author := range authors {
+		author.SurvivalHalfLifeDays = survivalHalfLife(lifetimes[authorId])
+		result = append(result, author)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AuthorId < result[j].AuthorId
+	})
+	return result
+}
+
+// survivalHalfLife returns the median of the Kaplan-Meier estimated lifetime, nil is returned if the estimated
+// survival never drops to a half
+func survivalHalfLife(lifetimes []lineLifetime) *float64 {
+	sort.Slice(lifetimes, func(i, j int) bool {
+		return lifetimes[i].Days < lifetimes[j].Days
+	})
+	atRisk := 0
+	for _, lifetime := range lifetimes {
+		atRisk += lifetime.Lines
+	}
+	survival := 1.0
+	for i := 0; i < len(lifetimes) && atRisk > 0; {
+		days := lifetimes[i].Days
+		deleted, censored := 0, 0
+		for ; i < len(lifetimes) && lifetimes[i].Days == days; i++ {
+			if lifetimes[i].Deleted {
+				deleted += lifetimes[i].Lines
+			} else {
+				censored += lifetimes[i].Lines
+			}
+		}
+		survival *= 1 - float64(deleted)/float64(atRisk)
+		if survival <= 0.5 {
+			return &days
+		}
+		atRisk -= deleted + censored
+	}
+	return nil
+}
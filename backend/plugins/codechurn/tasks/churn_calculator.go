@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateCodeChurnMeta = plugin.SubTaskMeta{
+	Name:             "calculateCodeChurn",
+	EntryPoint:       CalculateCodeChurn,
+	EnabledByDefault: true,
+	Description:      "Calculate rework, new work, refactor and help others churn of commits and authors from the commit line changes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{
+		code.CommitLineChange{}.TableName(),
+		code.Commit{}.TableName(),
+		code.RepoCommit{}.TableName(),
+	},
+	ProductTables: []string{
+		code.CommitChurn{}.TableName(),
+		code.CommitLineRewrite{}.TableName(),
+		code.RepoAuthorChurn{}.TableName(),
+	},
+}
+
+// CalculateCodeChurn calculates the churn of each repo in the project
+func CalculateCodeChurn(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	data := taskCtx.GetData().(*CodeChurnTaskData)
+	reworkWindow := time.Duration(data.Options.ReworkWindowDays) * 24 * time.Hour
+
+	var repoIds []string
+	err := db.Pluck("pm.row_id", &repoIds,
+		dal.From("project_mapping pm"),
+		dal.Where("pm.project_name = ? AND pm.table = ?", data.Options.ProjectName, "repos"),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to load the repos of the project")
+	}
+	taskCtx.SetProgress(0, len(repoIds))
+	for _, repoId := range repoIds {
+		commits, hunks, deletions, err := loadLineChanges(db, repoId)
+		if err != nil {
+			return err
+		}
+		churns, rewrites := calculateCommitChurns(repoId, commits, hunks, deletions, reworkWindow)
+		authorChurns := calculateAuthorChurns(repoId, churns, rewrites)
+		logger.Info("repo %s: %d commits, %d rewrites and %d authors of churn", repoId, len(churns), len(rewrites), len(authorChurns))
+		if err = saveChurns(taskCtx, repoId, churns, rewrites, authorChurns); err != nil {
+			return err
+		}
+		taskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+func loadLineChanges(db dal.Dal, repoId string) (map[string]*churnCommit, []*hunkChange, []*lineDeletion, errors.Error) {
+	var commitList []*churnCommit
+	err := db.All(&commitList,
+		dal.Select("c.sha, c.author_id, c.authored_date"),
+		dal.From("commits c"),
+		dal.Join("JOIN repo_commits rc ON rc.commit_sha = c.sha"),
+		dal.Where("rc.repo_id = ?", repoId),
+	)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "failed to load the commits")
+	}
+	commits := make(map[string]*churnCommit, len(commitList))
+	for _, commit := range commitList {
+		commits[commit.Sha] = commit
+	}
+	var hunks []*hunkChange
+	err = db.All(&hunks,
+		dal.Select(`clc.commit_sha,
+			SUM(CASE WHEN clc.changed_type = 'Addition' THEN 1 ELSE 0 END) AS added,
+			SUM(CASE WHEN clc.changed_type = 'Deletion' THEN 1 ELSE 0 END) AS deleted`),
+		dal.From("commit_line_change clc"),
+		dal.Join("JOIN repo_commits rc ON rc.commit_sha = clc.commit_sha"),
+		dal.Where("rc.repo_id = ?", repoId),
+		dal.Groupby("clc.commit_sha, clc.new_file_path, clc.hunk_num"),
+	)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "failed to load the hunks")
+	}
+	var deletions []*lineDeletion
+	err = db.All(&deletions,
+		dal.Select("clc.commit_sha, clc.prev_commit, COUNT(*) AS line_count"),
+		dal.From("commit_line_change clc"),
+		dal.Join("JOIN repo_commits rc ON rc.commit_sha = clc.commit_sha"),
+		dal.Where("rc.repo_id = ? AND clc.changed_type = 'Deletion'", repoId),
+		dal.Groupby("clc.commit_sha, clc.prev_commit"),
+	)
+	if err != nil {
+		return nil, nil, nil, errors.Default.Wrap(err, "failed to load the deleted lines")
+	}
+	return commits, hunks, deletions, nil
+}
+
+func saveChurns(
+	taskCtx plugin.SubTaskContext,
+	repoId string,
+	churns []*code.CommitChurn,
+	rewrites []*code.CommitLineRewrite,
+	authorChurns []*code.RepoAuthorChurn,
+) errors.Error {
+	db := taskCtx.GetDal()
+	for _, model := range []interface{}{&code.CommitChurn{}, &code.CommitLineRewrite{}, &code.RepoAuthorChurn{}} {
+		if err := db.Delete(model, dal.Where("repo_id = ?", repoId)); err != nil {
+			return errors.Default.Wrap(err, "failed to delete the previous churns")
+		}
+	}
+	if err := saveAll(taskCtx, churns); err != nil {
+		return err
+	}
+	if err := saveAll(taskCtx, rewrites); err != nil {
+		return err
+	}
+	return saveAll(taskCtx, authorChurns)
+}
+
+func saveAll[T any](taskCtx plugin.SubTaskContext, rows []*T) errors.Error {
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(new(T)), 500)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err = batch.Add(row); err != nil {
+			return err
+		}
+	}
+	return batch.Close()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateChurns(t *testing.T) {
+	day := 24 * time.Hour
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := map[string]*churnCommit{
+		"c1": {Sha: "c1", AuthorId: "alice@example.com", AuthoredDate: base},
+		"c2": {Sha: "c2", AuthorId: "alice@example.com", AuthoredDate: base.Add(2 * day)},
+		"c3": {Sha: "c3", AuthorId: "bob@example.com", AuthoredDate: base.Add(5 * day)},
+		"c4": {Sha: "c4", AuthorId: "bob@example.com", AuthoredDate: base.Add(40 * day)},
+	}
+	hunks := []*hunkChange{
+		{CommitSha: "c1", Added: 10},
+		{CommitSha: "c2", Added: 3, Deleted: 2},
+		{CommitSha: "c2", Added: 4},
+		{CommitSha: "c3", Added: 1, Deleted: 3},
+		{CommitSha: "c4", Added: 2, Deleted: 6},
+	}
+	deletions := []*lineDeletion{
+		{CommitSha: "c2", PrevCommit: "c1", LineCount: 2},
+		{CommitSha: "c3", PrevCommit: "c1", LineCount: 1},
+		{CommitSha: "c3", PrevCommit: "c2", LineCount: 2},
+		{CommitSha: "c4", PrevCommit: "c1", LineCount: 4},
+		{CommitSha: "c4", PrevCommit: "c3", LineCount: 1},
+		{CommitSha: "c4", PrevCommit: "", LineCount: 1},
+	}
+	churns, rewrites := calculateCommitChurns("repo1", commits, hunks, deletions, 21*day)
+
+	assert.Equal(t, 4, len(churns))
+	assert.Equal(t, &code.CommitChurn{
+		RepoId: "repo1", CommitSha: "c2", AuthorId: "alice@example.com", AuthoredDate: base.Add(2 * day),
+		AddedLines: 7, DeletedLines: 2, NewWorkLines: 5, ReworkLines: 2,
+	}, churns[1])
+	assert.Equal(t, 3, churns[2].HelpOthersLines)
+	assert.Equal(t, 0, churns[2].NewWorkLines)
+	// the old lines of alice and bob, and the line with unknown origin
+	assert.Equal(t, 6, churns[3].RefactorLines)
+	assert.Equal(t, 0, churns[3].ReworkLines)
+
+	assert.Equal(t, 5, len(rewrites))
+	assert.Equal(t, &code.CommitLineRewrite{
+		RepoId: "repo1", CommitSha: "c3", SourceCommitSha: "c2", AuthorId: "bob@example.com",
+		SourceAuthorId: "alice@example.com", Lines: 2, AgeDays: 3, IsRework: true,
+	}, rewrites[2])
+	assert.False(t, rewrites[3].IsRework)
+
+	authors := calculateAuthorChurns("repo1", churns, rewrites)
+	assert.Equal(t, 2, len(authors))
+	alice, bob := authors[0], authors[1]
+	assert.Equal(t, "alice@example.com", alice.AuthorId)
+	assert.Equal(t, 2, alice.Commits)
+	assert.Equal(t, 17, alice.AddedLines)
+	assert.Equal(t, 2, alice.ReworkLines)
+	assert.Equal(t, 3, alice.ReworkedByOthers)
+	assert.Equal(t, 3, bob.HelpOthersLines)
+	assert.Equal(t, 0, bob.ReworkedByOthers)
+	// 11 of the 17 lines written by alice were deleted, the survival drops below a half at day 40
+	assert.Equal(t, 40.0, *alice.SurvivalHalfLifeDays)
+	// the lines of c4 are censored at day 0, which leaves the line of c3 the only one at risk
+	assert.Equal(t, 35.0, *bob.SurvivalHalfLifeDays)
+}
+
+func TestSurvivalHalfLife(t *testing.T) {
+	assert.Nil(t, survivalHalfLife(nil))
+	assert.Equal(t, 2.0, *survivalHalfLife([]lineLifetime{
+		{Days: 3, Lines: 1, Deleted: true},
+		{Days: 1, Lines: 1, Deleted: true},
+		{Days: 2, Lines: 1, Deleted: true},
+		{Days: 10, Lines: 1},
+	}))
+	// censored lines leave the risk set without dropping the survival
+	assert.Equal(t, 5.0, *survivalHalfLife([]lineLifetime{
+		{Days: 1, Lines: 2},
+		{Days: 5, Lines: 1, Deleted: true},
+		{Days: 10, Lines: 1},
+	}))
+}
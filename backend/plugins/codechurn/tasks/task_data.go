@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// DefaultReworkWindowDays is how recent the lines must be for the rewrites to be counted as rework
+const DefaultReworkWindowDays = 21
+
+type CodeChurnOptions struct {
+	ProjectName      string `json:"projectName" mapstructure:"projectName"`
+	ReworkWindowDays int    `json:"reworkWindowDays" mapstructure:"reworkWindowDays"`
+}
+
+type CodeChurnTaskData struct {
+	Options *CodeChurnOptions
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*CodeChurnOptions, errors.Error) {
+	var op CodeChurnOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding codechurn task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required for codechurn")
+	}
+	if op.ReworkWindowDays <= 0 {
+		op.ReworkWindowDays = DefaultReworkWindowDays
+	}
+	return &op, nil
+}
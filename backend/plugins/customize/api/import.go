@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/customize/service"
+)
+
+// ImportTable accepts a CSV or JSON file and imports it into a domain layer table
+// @Summary      Import a CSV or JSON file into a domain layer table
+// @Description  Import a CSV file or a JSON array of objects into any domain layer table, e.g. cicd_deployments, pull_requests, accounts or sprints.
+// @Description  The records are validated before saving, nothing is saved if any of them is invalid.
+// @Tags 		 plugins/customize
+// @Accept       multipart/form-data
+// @Param        table path string true "the domain layer table"
+// @Param        file formData file true "select file to upload"
+// @Param        rawDataParams formData string true "identifies the data source, the records are saved with it as _raw_data_params"
+// @Param        format formData string false "csv or json, detected from the file extension by default"
+// @Param        mode formData string false "incremental (default) inserts or updates the records, replace deletes the records with the same rawDataParams first"
+// @Param        dryRun formData bool false "validate the file without saving anything"
+// @Param        mapping formData string false "JSON object mapping the columns of the table to the columns of the file, e.g. {\"id\":\"Deploy ID\"}"
+// @Param        projectName formData string false "attach the records to the project through project_mapping"
+// @Produce      json
+// @Success      200  {object} service.ImportResult
+// @Failure 400  {object} service.ImportResult "Invalid records"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/customize/imports/{table} [post]
+func (h *Handlers) ImportTable(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	file, err := h.extractFile(input)
+	if err != nil {
+		return nil, err
+	}
+	// nolint
+	defer file.Close()
+	opts := &service.ImportOptions{
+		Table:         input.Params["table"],
+		Format:        strings.ToLower(strings.TrimSpace(input.Request.FormValue("format"))),
+		Mode:          strings.TrimSpace(input.Request.FormValue("mode")),
+		RawDataParams: strings.TrimSpace(input.Request.FormValue("rawDataParams")),
+		ProjectName:   strings.TrimSpace(input.Request.FormValue("projectName")),
+	}
+	if opts.Format == "" {
+		if files := input.Request.MultipartForm.File["file"]; len(files) > 0 {
+			opts.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(files[0].Filename)), ".")
+		}
+	}
+	if dryRun := input.Request.FormValue("dryRun"); dryRun != "" {
+		opts.DryRun, err = errors.Convert01(strconv.ParseBool(dryRun))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid dryRun")
+		}
+	}
+	if mapping := input.Request.FormValue("mapping"); mapping != "" {
+		if e := json.Unmarshal([]byte(mapping), &opts.Mapping); e != nil {
+			return nil, errors.BadInput.Wrap(e, "the mapping should be a JSON object")
+		}
+	}
+	result, err := h.svc.ImportTable(file, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 && !result.DryRun {
+		return &plugin.ApiResourceOutput{Body: result, Status: http.StatusBadRequest}, nil
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
@@ -56,6 +56,8 @@ func (p Customize) GetTablesInfo() []dal.Tabler {
 func (p Customize) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ExtractCustomizedFieldsMeta,
+		tasks.ExtractCustomizedFieldHistoriesMeta,
+		tasks.EvaluateDerivedFieldsMeta,
 	}
 }
 
@@ -68,6 +70,12 @@ func (p Customize) PrepareTaskData(taskCtx plugin.TaskContext, options map[strin
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "could not decode Jira options")
 	}
+	for _, rule := range op.TransformationRules {
+		_, err = tasks.ParseDerivedFields(rule.Expressions)
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+	}
 	taskData := &tasks.TaskData{
 		Options: &op,
 	}
@@ -108,5 +116,8 @@ func (p Customize) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 		"csvfiles/issue_repo_commits.csv": {
 			"POST": handlers.ImportIssueRepoCommit,
 		},
+		"imports/:table": {
+			"POST": handlers.ImportTable,
+		},
 	}
 }
@@ -0,0 +1,416 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper"
+)
+
+const (
+	ImportFormatCsv  = "csv"
+	ImportFormatJson = "json"
+	// ImportModeIncremental inserts or updates the imported records
+	ImportModeIncremental = "incremental"
+	// ImportModeReplace deletes the records imported before with the same `_raw_data_params`
+	ImportModeReplace = "replace"
+	// ImportRawDataTable is saved into `_raw_data_table` of the imported records, so that replacing them doesn't
+	// delete the records of the same `_raw_data_params` collected by the plugins
+	ImportRawDataTable = "_customize_table_import"
+)
+
+// ImportOptions describes how a file is imported into a domain layer table
+type ImportOptions struct {
+	Table  string
+	Format string
+	Mode   string
+	DryRun bool
+	// RawDataParams identifies the data source, it is saved into `_raw_data_params` of every record
+	RawDataParams string
+	// Mapping maps the columns of the table to the columns of the file, the columns of the file
+	// which are not mapped are imported into the columns of the same name
+	Mapping map[string]string
+	// ProjectName attaches the imported records to the project through `project_mapping`
+	ProjectName string
+}
+
+// ImportError is an invalid record of the imported file, Row is the 1-based index of the record
+type ImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes an import
+type ImportResult struct {
+	Table          string        `json:"table"`
+	Mode           string        `json:"mode"`
+	DryRun         bool          `json:"dryRun"`
+	Total          int           `json:"total"`
+	Imported       int           `json:"imported"`
+	IgnoredColumns []string      `json:"ignoredColumns"`
+	Errors         []ImportError `json:"errors"`
+}
+
+// importColumn is a column of the table the file is imported into
+type importColumn struct {
+	DatabaseType  string
+	PrimaryKey    bool
+	AutoIncrement bool
+}
+
+// ImportTable imports a CSV or JSON file into any of the domain layer tables, the records are validated before
+// saving anything. The errors of the result are not empty when some records are invalid, nothing is saved then.
+func (s *Service) ImportTable(file io.ReadCloser, opts *ImportOptions) (*ImportResult, errors.Error) {
+	if opts.RawDataParams == "" {
+		return nil, errors.BadInput.New("empty rawDataParams")
+	}
+	if opts.Mode == "" {
+		opts.Mode = ImportModeIncremental
+	}
+	if opts.Mode != ImportModeIncremental && opts.Mode != ImportModeReplace {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid mode %s", opts.Mode))
+	}
+	var tabler dal.Tabler
+	for _, t := range domaininfo.GetDomainTablesInfo() {
+		if t.TableName() == opts.Table {
+			tabler = t
+			break
+		}
+	}
+	if tabler == nil {
+		return nil, errors.NotFound.New(fmt.Sprintf("%s is not a domain layer table", opts.Table))
+	}
+	columns, err := s.getImportColumns(tabler)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["_raw_data_table"]; !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("table %s doesn't support importing", opts.Table))
+	}
+	if _, ok := columns["id"]; opts.ProjectName != "" && !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("table %s can't be attached to a project", opts.Table))
+	}
+
+	var rows []map[string]interface{}
+	switch opts.Format {
+	case ImportFormatCsv, "":
+		rows, err = readCsvRows(file)
+	case ImportFormatJson:
+		rows, err = readJsonRows(file)
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid format %s", opts.Format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Table: opts.Table, Mode: opts.Mode, DryRun: opts.DryRun, Total: len(rows)}
+	ignored := make(map[string]bool)
+	records := make([]map[string]interface{}, 0, len(rows))
+	for i, row := range rows {
+		record, ignoredColumns, e := convertImportRow(row, opts.Mapping, columns)
+		for _, column := range ignoredColumns {
+			ignored[column] = true
+		}
+		if e != nil {
+			result.Errors = append(result.Errors, ImportError{Row: i + 1, Message: e.Error()})
+			continue
+		}
+		record["_raw_data_table"] = ImportRawDataTable
+		record["_raw_data_params"] = opts.RawDataParams
+		records = append(records, record)
+	}
+	for column := range ignored {
+		result.IgnoredColumns = append(result.IgnoredColumns, column)
+	}
+	sort.Strings(result.IgnoredColumns)
+	if opts.DryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	tx := s.dal.Begin()
+	err = saveImportedRecords(tx, tabler, columns, records, opts)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, errors.Default.Wrap(e, "transaction Rollback")
+		}
+		return nil, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = len(records)
+	return result, nil
+}
+
+func (s *Service) getImportColumns(tabler dal.Tabler) (map[string]importColumn, errors.Error) {
+	metas, err := s.dal.GetColumns(tabler, func(columnMeta dal.ColumnMeta) bool {
+		return true
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "GetColumns error")
+	}
+	columns := make(map[string]importColumn, len(metas))
+	for _, meta := range metas {
+		primaryKey, _ := meta.PrimaryKey()
+		autoIncrement, _ := meta.AutoIncrement()
+		columns[meta.Name()] = importColumn{
+			DatabaseType:  strings.ToLower(meta.DatabaseTypeName()),
+			PrimaryKey:    primaryKey,
+			AutoIncrement: autoIncrement,
+		}
+	}
+	return columns, nil
+}
+
+// saveImportedRecords inserts or updates the records, `created_at` is only set for the records not existing yet
+func saveImportedRecords(tx dal.Transaction, tabler dal.Tabler, columns map[string]importColumn, records []map[string]interface{}, opts *ImportOptions) errors.Error {
+	if opts.Mode == ImportModeReplace {
+		if opts.ProjectName != "" {
+			err := tx.Delete(
+				&crossdomain.ProjectMapping{},
+				dal.Where(
+					fmt.Sprintf("project_mapping.project_name = ? AND project_mapping.table = ? AND project_mapping.row_id IN (SELECT id FROM %s WHERE _raw_data_table = ? AND _raw_data_params = ?)", opts.Table),
+					opts.ProjectName, opts.Table, ImportRawDataTable, opts.RawDataParams,
+				),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		err := tx.Delete(tabler, dal.Where("_raw_data_table = ? AND _raw_data_params = ?", ImportRawDataTable, opts.RawDataParams))
+		if err != nil {
+			return err
+		}
+	}
+	var primaryKeys []string
+	for name, column := range columns {
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, name)
+		}
+	}
+	sort.Strings(primaryKeys)
+	_, hasCreatedAt := columns["created_at"]
+	_, hasUpdatedAt := columns["updated_at"]
+	now := time.Now()
+	for _, record := range records {
+		if hasCreatedAt {
+			exists, err := importedRecordExists(tx, opts.Table, primaryKeys, record)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				record["created_at"] = now
+			}
+		}
+		if hasUpdatedAt {
+			record["updated_at"] = now
+		}
+		err := tx.CreateWithMap(tabler, record)
+		if err != nil {
+			return err
+		}
+		if opts.ProjectName != "" {
+			err = tx.CreateOrUpdate(&crossdomain.ProjectMapping{
+				ProjectName: opts.ProjectName,
+				Table:       opts.Table,
+				RowId:       fmt.Sprint(record["id"]),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importedRecordExists checks whether the table has a record with the primary key of the imported record
+func importedRecordExists(tx dal.Transaction, table string, primaryKeys []string, record map[string]interface{}) (bool, errors.Error) {
+	conditions := make([]string, 0, len(primaryKeys))
+	params := make([]interface{}, 0, len(primaryKeys))
+	for _, name := range primaryKeys {
+		if record[name] == nil {
+			// the auto-increment key is generated for the new records
+			return false, nil
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = ?", name))
+		params = append(params, record[name])
+	}
+	if len(conditions) == 0 {
+		return false, nil
+	}
+	count, err := tx.Count(dal.From(table), dal.Where(strings.Join(conditions, " AND "), params...))
+	return count > 0, err
+}
+
+// readCsvRows reads the rows of a CSV file, the string `NULL` stands for null values
+func readCsvRows(file io.ReadCloser) ([]map[string]interface{}, errors.Error) {
+	iterator, err := pluginhelper.NewCsvFileIteratorFromFile(file)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to read the CSV header")
+	}
+	var rows []map[string]interface{}
+	for {
+		hasNext, err := iterator.HasNextWithError()
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("error on reading the line:%d", len(rows)+1))
+		}
+		if !hasNext {
+			return rows, nil
+		}
+		row := iterator.Fetch()
+		for k, v := range row {
+			if v == "NULL" {
+				row[k] = nil
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// readJsonRows reads a JSON array of objects, nested values are kept as JSON strings
+func readJsonRows(file io.Reader) ([]map[string]interface{}, errors.Error) {
+	var objects []map[string]json.RawMessage
+	if err := json.NewDecoder(file).Decode(&objects); err != nil {
+		return nil, errors.BadInput.Wrap(err, "the JSON file should be an array of objects")
+	}
+	rows := make([]map[string]interface{}, len(objects))
+	for i, object := range objects {
+		row := make(map[string]interface{}, len(object))
+		for k, raw := range object {
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid value of %s in object %d", k, i+1))
+			}
+			switch v := value.(type) {
+			case nil:
+				row[k] = nil
+			case string:
+				row[k] = v
+			case map[string]interface{}, []interface{}:
+				row[k] = string(raw)
+			default:
+				row[k] = strings.TrimSpace(string(raw))
+			}
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// convertImportRow renames the columns of the row according to the mapping and converts the values to the types
+// of the columns, it returns the columns of the row that don't exist in the table
+func convertImportRow(row map[string]interface{}, mapping map[string]string, columns map[string]importColumn) (map[string]interface{}, []string, error) {
+	sourceColumns := make(map[string]string, len(row))
+	for column := range row {
+		sourceColumns[column] = column
+	}
+	for target, source := range mapping {
+		if _, ok := columns[target]; !ok {
+			return nil, nil, fmt.Errorf("the mapped column %s doesn't exist", target)
+		}
+		if _, ok := row[source]; !ok {
+			return nil, nil, fmt.Errorf("the column %s mapped to %s is missing", source, target)
+		}
+		delete(sourceColumns, target)
+		sourceColumns[source] = target
+	}
+	record := make(map[string]interface{}, len(row))
+	var ignored []string
+	for source, target := range sourceColumns {
+		column, ok := columns[target]
+		if !ok || strings.HasPrefix(target, "_raw_data_") {
+			ignored = append(ignored, source)
+			continue
+		}
+		value, err := convertImportValue(row[source], column.DatabaseType)
+		if err != nil {
+			return nil, ignored, fmt.Errorf("invalid value of %s: %s", target, err.Error())
+		}
+		record[target] = value
+	}
+	sort.Strings(ignored)
+	var missing []string
+	for name, column := range columns {
+		if column.PrimaryKey && !column.AutoIncrement && record[name] == nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, ignored, fmt.Errorf("primary key %s is required", strings.Join(missing, ", "))
+	}
+	return record, ignored, nil
+}
+
+var importTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// convertImportValue converts the string value to the type of the column
+func convertImportValue(value interface{}, databaseType string) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+	s = strings.TrimSpace(s)
+	isString := strings.Contains(databaseType, "char") || strings.Contains(databaseType, "text") || strings.Contains(databaseType, "json")
+	if s == "" && !isString {
+		return nil, nil
+	}
+	switch {
+	case isString:
+		return value, nil
+	case strings.Contains(databaseType, "bool"):
+		return strconv.ParseBool(s)
+	case databaseType == "tinyint":
+		// booleans are stored as tinyint in MySQL
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+		return strconv.ParseInt(s, 10, 64)
+	case strings.Contains(databaseType, "int"):
+		return strconv.ParseInt(s, 10, 64)
+	case strings.Contains(databaseType, "decimal"), strings.Contains(databaseType, "numeric"),
+		strings.Contains(databaseType, "float"), strings.Contains(databaseType, "double"), strings.Contains(databaseType, "real"):
+		return strconv.ParseFloat(s, 64)
+	case strings.Contains(databaseType, "date"), strings.Contains(databaseType, "time"):
+		for _, layout := range importTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("unrecognized time %s", s)
+	}
+	return value, nil
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var deploymentColumns = map[string]importColumn{
+	"id":               {DatabaseType: "varchar", PrimaryKey: true},
+	"name":             {DatabaseType: "varchar"},
+	"duration_sec":     {DatabaseType: "double"},
+	"queued_duration":  {DatabaseType: "bigint"},
+	"is_child":         {DatabaseType: "tinyint"},
+	"started_date":     {DatabaseType: "datetime"},
+	"_raw_data_params": {DatabaseType: "varchar"},
+}
+
+func TestConvertImportRow(t *testing.T) {
+	record, ignored, err := convertImportRow(
+		map[string]interface{}{
+			"Deploy ID":        "d1",
+			"name":             "release",
+			"duration_sec":     "12.5",
+			"queued_duration":  "",
+			"is_child":         "true",
+			"started_date":     "2024-07-01 10:00:00",
+			"owner":            "alice",
+			"_raw_data_params": "hacked",
+		},
+		map[string]string{"id": "Deploy ID"},
+		deploymentColumns,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"_raw_data_params", "owner"}, ignored)
+	assert.Equal(t, map[string]interface{}{
+		"id":              "d1",
+		"name":            "release",
+		"duration_sec":    12.5,
+		"queued_duration": nil,
+		"is_child":        true,
+		"started_date":    time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC),
+	}, record)
+
+	_, _, err = convertImportRow(map[string]interface{}{"name": "release"}, nil, deploymentColumns)
+	assert.EqualError(t, err, "primary key id is required")
+	_, _, err = convertImportRow(map[string]interface{}{"id": "d1", "duration_sec": "fast"}, nil, deploymentColumns)
+	assert.NotNil(t, err)
+	_, _, err = convertImportRow(map[string]interface{}{"id": "d1"}, map[string]string{"x_missing": "id"}, deploymentColumns)
+	assert.NotNil(t, err)
+	_, _, err = convertImportRow(map[string]interface{}{"id": "d1"}, map[string]string{"name": "Title"}, deploymentColumns)
+	assert.NotNil(t, err)
+}
+
+func TestConvertImportValue(t *testing.T) {
+	value, err := convertImportValue("2024-07-01T10:00:00+08:00", "timestamp with time zone")
+	assert.Nil(t, err)
+	assert.True(t, value.(time.Time).Equal(time.Date(2024, 7, 1, 2, 0, 0, 0, time.UTC)))
+	value, err = convertImportValue("3", "tinyint")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), value)
+	value, err = convertImportValue("", "text")
+	assert.Nil(t, err)
+	assert.Equal(t, "", value)
+	value, err = convertImportValue(nil, "bigint")
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+	_, err = convertImportValue("yesterday", "datetime")
+	assert.NotNil(t, err)
+}
+
+func TestReadImportRows(t *testing.T) {
+	rows, err := readCsvRows(io.NopCloser(strings.NewReader("id,name\nd1,NULL\nd2,release\n")))
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "d1", "name": nil},
+		{"id": "d2", "name": "release"},
+	}, rows)
+
+	rows, err = readJsonRows(strings.NewReader(`[{"id": "d1", "duration_sec": 12.5, "is_child": false, "name": null, "labels": ["a"]}]`))
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "d1", "duration_sec": "12.5", "is_child": "false", "name": nil, "labels": `["a"]`},
+	}, rows)
+
+	_, err = readJsonRows(strings.NewReader(`{"id": "d1"}`))
+	assert.NotNil(t, err)
+}
+
+func TestSaveImportedRecords(t *testing.T) {
+	tx := mockdal.NewTransaction(t)
+	tx.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause)
+		assert.Equal(t, "_raw_data_table = ? AND _raw_data_params = ?", where.Expr)
+		assert.Equal(t, []interface{}{ImportRawDataTable, "source"}, where.Params)
+	}).Return(nil)
+	tx.On("Count", mock.Anything, mock.Anything).Return(func(clauses ...dal.Clause) int64 {
+		if clauses[1].Data.(dal.DalClause).Params[0] == "existing" {
+			return 1
+		}
+		return 0
+	}, nil)
+	var saved []map[string]interface{}
+	tx.On("CreateWithMap", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(map[string]interface{}))
+	}).Return(nil)
+
+	columns := map[string]importColumn{
+		"id":         {DatabaseType: "varchar", PrimaryKey: true},
+		"created_at": {DatabaseType: "datetime"},
+		"updated_at": {DatabaseType: "datetime"},
+	}
+	records := []map[string]interface{}{{"id": "existing"}, {"id": "new"}}
+	err := saveImportedRecords(tx, &devops.CICDDeployment{}, columns, records, &ImportOptions{
+		Table:         "cicd_deployments",
+		Mode:          ImportModeReplace,
+		RawDataParams: "source",
+	})
+	assert.Nil(t, err)
+	assert.Len(t, saved, 2)
+	// the creation time of the existing records is kept
+	assert.NotContains(t, saved[0], "created_at")
+	assert.Contains(t, saved[0], "updated_at")
+	assert.Contains(t, saved[1], "created_at")
+	assert.Contains(t, saved[1], "updated_at")
+}
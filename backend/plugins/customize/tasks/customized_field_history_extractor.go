@@ -0,0 +1,199 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractCustomizedFieldHistories
+
+var ExtractCustomizedFieldHistoriesMeta = plugin.SubTaskMeta{Name: "extractCustomizedFieldHistories",
+	EntryPoint:       ExtractCustomizedFieldHistories,
+	EnabledByDefault: true,
+	Description:      "derive the history of customized fields of issues from issue_changelogs",
+}
+
+// fieldChange is a change of a customized field found in issue_changelogs
+type fieldChange struct {
+	IssueId           string
+	OriginalFromValue string
+	OriginalToValue   string
+	CreatedDate       time.Time
+}
+
+// ExtractCustomizedFieldHistories replays the changelogs of the customized fields into issue_custom_field_histories
+func ExtractCustomizedFieldHistories(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if data == nil || data.Options == nil {
+		return nil
+	}
+	for _, rule := range data.Options.TransformationRules {
+		if rule.Table != "issues" {
+			continue
+		}
+		for field, changelogField := range rule.ChangelogMapping {
+			err := extractCustomizedFieldHistory(taskCtx, rule, field, changelogField)
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("error extracting the history of customized field %s", field))
+			}
+		}
+	}
+	return nil
+}
+
+func extractCustomizedFieldHistory(taskCtx plugin.SubTaskContext, rule MappingRules, field, changelogField string) errors.Error {
+	d := taskCtx.GetDal()
+	scopeIssueIds := "SELECT id FROM issues WHERE _raw_data_table = ? AND _raw_data_params = ?"
+	err := d.Delete(
+		&ticket.IssueCustomFieldHistory{},
+		dal.Where("field_id = ? AND issue_id IN ("+scopeIssueIds+")", field, rule.RawDataTable, rule.RawDataParams),
+	)
+	if err != nil {
+		return err
+	}
+
+	var changes []fieldChange
+	err = d.All(
+		&changes,
+		dal.Select("c.issue_id, c.original_from_value, c.original_to_value, c.created_date"),
+		dal.From("issue_changelogs c"),
+		dal.Where("(c.field_id = ? OR c.field_name = ?) AND c.issue_id IN ("+scopeIssueIds+")",
+			changelogField, changelogField, rule.RawDataTable, rule.RawDataParams),
+		dal.Orderby("c.issue_id, c.created_date"),
+	)
+	if err != nil {
+		return err
+	}
+	issueChanges := make(map[string][]fieldChange)
+	for _, change := range changes {
+		issueChanges[change.IssueId] = append(issueChanges[change.IssueId], change)
+	}
+
+	// the current value is only known when the field is extracted from the raw data as well
+	columns := "id, created_date"
+	_, extracted := rule.Mapping[field]
+	if extracted {
+		columns += ", " + field
+	}
+	rows, err := d.Cursor(
+		dal.Select(columns),
+		dal.From("issues"),
+		dal.Where("_raw_data_table = ? AND _raw_data_params = ?", rule.RawDataTable, rule.RawDataParams),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.IssueCustomFieldHistory{}), 500)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		row := make(map[string]interface{})
+		err = d.Fetch(rows, &row)
+		if err != nil {
+			return err
+		}
+		issueId, _ := row["id"].(string)
+		var createdDate *time.Time
+		if t, ok := row["created_date"].(time.Time); ok {
+			createdDate = &t
+		}
+		var currentValue *string
+		if value := row[field]; extracted && value != nil {
+			s := fieldValueString(value)
+			currentValue = &s
+		}
+		for _, history := range buildCustomFieldHistory(issueId, field, createdDate, currentValue, issueChanges[issueId]) {
+			history.RawDataOrigin = common.RawDataOrigin{
+				RawDataTable:  rule.RawDataTable,
+				RawDataParams: rule.RawDataParams,
+			}
+			err = batch.Add(history)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Close()
+}
+
+// buildCustomFieldHistory turns the changes of a field of an issue, ordered by date, into consecutive periods.
+// The value before the first change holds since the issue was created, and an issue without any change
+// holds its current value since then
+func buildCustomFieldHistory(issueId, field string, createdDate *time.Time, currentValue *string, changes []fieldChange) []*ticket.IssueCustomFieldHistory {
+	var histories []*ticket.IssueCustomFieldHistory
+	if len(changes) == 0 {
+		if createdDate == nil || currentValue == nil {
+			return nil
+		}
+		return append(histories, &ticket.IssueCustomFieldHistory{
+			IssueId:    issueId,
+			FieldId:    field,
+			StartDate:  *createdDate,
+			FieldValue: *currentValue,
+			IsCurrent:  true,
+		})
+	}
+	if createdDate != nil && createdDate.Before(changes[0].CreatedDate) {
+		histories = append(histories, &ticket.IssueCustomFieldHistory{
+			IssueId:    issueId,
+			FieldId:    field,
+			StartDate:  *createdDate,
+			FieldValue: changes[0].OriginalFromValue,
+		})
+	}
+	for _, change := range changes {
+		if len(histories) > 0 {
+			last := histories[len(histories)-1]
+			// changes at the same moment collapse into the latest one
+			if !last.StartDate.Before(change.CreatedDate) {
+				last.FieldValue = change.OriginalToValue
+				continue
+			}
+			endDate := change.CreatedDate
+			last.EndDate = &endDate
+		}
+		histories = append(histories, &ticket.IssueCustomFieldHistory{
+			IssueId:    issueId,
+			FieldId:    field,
+			StartDate:  change.CreatedDate,
+			FieldValue: change.OriginalToValue,
+		})
+	}
+	histories[len(histories)-1].IsCurrent = true
+	return histories
+}
+
+func fieldValueString(value interface{}) string {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(value)
+}
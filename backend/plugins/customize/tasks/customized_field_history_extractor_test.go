@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCustomFieldHistory(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	created := day(1)
+	current := "5"
+
+	histories := buildCustomFieldHistory("i1", "x_story_points", &created, &current, nil)
+	assert.Len(t, histories, 1)
+	assert.Equal(t, created, histories[0].StartDate)
+	assert.Equal(t, "5", histories[0].FieldValue)
+	assert.Nil(t, histories[0].EndDate)
+	assert.True(t, histories[0].IsCurrent)
+
+	assert.Empty(t, buildCustomFieldHistory("i1", "x_story_points", &created, nil, nil))
+
+	histories = buildCustomFieldHistory("i1", "x_story_points", &created, &current, []fieldChange{
+		{IssueId: "i1", OriginalFromValue: "", OriginalToValue: "3", CreatedDate: day(2)},
+		{IssueId: "i1", OriginalFromValue: "3", OriginalToValue: "8", CreatedDate: day(5)},
+		{IssueId: "i1", OriginalFromValue: "8", OriginalToValue: "5", CreatedDate: day(5)},
+	})
+	assert.Len(t, histories, 3)
+	assert.Equal(t, "", histories[0].FieldValue)
+	assert.Equal(t, created, histories[0].StartDate)
+	assert.Equal(t, day(2), *histories[0].EndDate)
+	assert.Equal(t, "3", histories[1].FieldValue)
+	assert.Equal(t, day(5), *histories[1].EndDate)
+	assert.False(t, histories[1].IsCurrent)
+	assert.Equal(t, "5", histories[2].FieldValue)
+	assert.Equal(t, day(5), histories[2].StartDate)
+	assert.Nil(t, histories[2].EndDate)
+	assert.True(t, histories[2].IsCurrent)
+}
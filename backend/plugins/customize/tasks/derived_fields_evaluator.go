@@ -0,0 +1,217 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/customize/models"
+)
+
+var _ plugin.SubTaskEntryPoint = EvaluateDerivedFields
+
+var EvaluateDerivedFieldsMeta = plugin.SubTaskMeta{Name: "evaluateDerivedFields",
+	EntryPoint:       EvaluateDerivedFields,
+	EnabledByDefault: true,
+	Description:      "evaluate the expressions of derived fields",
+}
+
+// derived fields can only be customized fields, domain columns are read-only for expressions
+var derivedFieldChecker = regexp.MustCompile(`^x_[a-zA-Z0-9_]{0,50}$`)
+
+// DerivedField is a customized field computed by an expression
+type DerivedField struct {
+	Name       string
+	Expression *Expression
+}
+
+// ParseDerivedFields parses the expressions of derived fields and sorts them so that
+// every field is evaluated after the derived fields it refers to
+func ParseDerivedFields(expressions map[string]string) ([]DerivedField, errors.Error) {
+	parsed := make(map[string]*Expression, len(expressions))
+	names := make([]string, 0, len(expressions))
+	for name, source := range expressions {
+		if !derivedFieldChecker.MatchString(name) {
+			return nil, errors.BadInput.New(fmt.Sprintf("derived field %s must start with x_", name))
+		}
+		expression, err := ParseExpression(source)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = expression
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []DerivedField
+	// 0: not visited, 1: visiting, 2: done
+	state := make(map[string]int)
+	var visit func(name string, path []string) errors.Error
+	visit = func(name string, path []string) errors.Error {
+		switch state[name] {
+		case 1:
+			return errors.BadInput.New(fmt.Sprintf("circular reference between derived fields %s", strings.Join(append(path, name), " -> ")))
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		dependencies := append([]string(nil), parsed[name].Columns()...)
+		sort.Strings(dependencies)
+		for _, dependency := range dependencies {
+			if _, ok := parsed[dependency]; ok {
+				if err := visit(dependency, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		state[name] = 2
+		fields = append(fields, DerivedField{Name: name, Expression: parsed[name]})
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// EvaluateDerivedFields evaluates the expressions of derived fields against the rows of the domain layer tables
+func EvaluateDerivedFields(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TaskData)
+	if data == nil || data.Options == nil {
+		return nil
+	}
+	for _, rule := range data.Options.TransformationRules {
+		if len(rule.Expressions) == 0 {
+			continue
+		}
+		fields, err := ParseDerivedFields(rule.Expressions)
+		if err != nil {
+			return err
+		}
+		err = evaluateDerivedFields(taskCtx, rule, fields)
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error evaluating derived fields of %s", rule.Table))
+		}
+	}
+	return nil
+}
+
+func evaluateDerivedFields(taskCtx plugin.SubTaskContext, rule MappingRules, fields []DerivedField) errors.Error {
+	d := taskCtx.GetDal()
+	pkFields, err := dal.GetPrimarykeyColumns(d, &models.Table{Name: rule.Table})
+	if err != nil {
+		return err
+	}
+	columnSet := make(map[string]bool)
+	var pkColumns []string
+	for _, field := range pkFields {
+		columnSet[field.Name()] = true
+		pkColumns = append(pkColumns, field.Name())
+	}
+	var columns []string
+	for _, field := range fields {
+		for _, column := range field.Expression.Columns() {
+			if !columnSet[column] {
+				columnSet[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	rows, err := d.Cursor(
+		dal.Select(strings.Join(append(append([]string(nil), pkColumns...), columns...), ", ")),
+		dal.From(rule.Table),
+		dal.Where("_raw_data_table = ? AND _raw_data_params = ?", rule.RawDataTable, rule.RawDataParams),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	ctx := taskCtx.GetContext()
+	for rows.Next() {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		row := make(map[string]interface{})
+		err = d.Fetch(rows, &row)
+		if err != nil {
+			return err
+		}
+		updates, err := evaluateRow(fields, row)
+		if err != nil {
+			return err
+		}
+		pk := make(map[string]interface{}, len(pkColumns))
+		for _, column := range pkColumns {
+			pk[column] = row[column]
+		}
+		query, params := mkUpdate(rule.Table, updates, pk)
+		err = d.Exec(query, params...)
+		if err != nil {
+			return errors.Default.Wrap(err, "Exec SQL error")
+		}
+	}
+	return nil
+}
+
+// evaluateRow evaluates the derived fields in order, later fields see the values of the earlier ones
+func evaluateRow(fields []DerivedField, row map[string]interface{}) (map[string]interface{}, errors.Error) {
+	updates := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value, err := field.Expression.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		value = columnValue(value)
+		row[field.Name] = value
+		updates[field.Name] = value
+	}
+	return updates, nil
+}
+
+// columnValue converts the result of an expression to a value the database accepts
+func columnValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, bool, string, time.Time:
+		return v
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v)
+		}
+		return v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = toString(item)
+		}
+		return strings.Join(items, ",")
+	}
+	return toString(value)
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDerivedFields(t *testing.T) {
+	fields, err := ParseDerivedFields(map[string]string{
+		"x_is_urgent_hotfix": "x_is_hotfix && x_ticket != null",
+		"x_is_hotfix":        "type == 'BUG' && priority in ['P0', 'P1']",
+		"x_ticket":           "regex(title, '([A-Z]+-\\d+)')",
+	})
+	assert.Nil(t, err)
+	var names []string
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"x_is_hotfix", "x_ticket", "x_is_urgent_hotfix"}, names)
+
+	updates, err := evaluateRow(fields, map[string]interface{}{
+		"id":       "1",
+		"type":     "BUG",
+		"priority": "P0",
+		"title":    "DL-42 broken",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"x_is_hotfix":        true,
+		"x_ticket":           "DL-42",
+		"x_is_urgent_hotfix": true,
+	}, updates)
+
+	_, err = ParseDerivedFields(map[string]string{"x_a": "x_b + 1", "x_b": "x_a + 1"})
+	assert.NotNil(t, err)
+	_, err = ParseDerivedFields(map[string]string{"priority": "'P0'"})
+	assert.NotNil(t, err)
+}
+
+func TestColumnValue(t *testing.T) {
+	assert.Equal(t, int64(3), columnValue(float64(3)))
+	assert.Equal(t, 2.5, columnValue(2.5))
+	assert.Equal(t, "a,1", columnValue([]interface{}{"a", float64(1)}))
+	assert.Nil(t, columnValue(nil))
+}
@@ -0,0 +1,826 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// Expression is a parsed expression over the columns of a row, like `type == 'BUG' && priority in ['P0', 'P1']`.
+// It supports literals (numbers, quoted strings, true, false, null and [lists]), column names, the operators
+// `|| && ! == != < <= > >= in, not in, matches + - * / %`, parentheses and the functions listed in exprFunctions.
+// Nothing but the given row is accessible, so expressions are safe to evaluate
+type Expression struct {
+	source  string
+	root    exprNode
+	columns []string
+}
+
+// ParseExpression parses the source into an Expression
+func ParseExpression(source string) (*Expression, errors.Error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid expression %s", source))
+	}
+	p := &exprParser{tokens: tokens, columns: make(map[string]bool)}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s", p.peek().text)
+	}
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid expression %s", source))
+	}
+	expression := &Expression{source: source, root: root}
+	for column := range p.columns {
+		expression.columns = append(expression.columns, column)
+	}
+	return expression, nil
+}
+
+// Columns returns the names of the columns referred by the expression
+func (e *Expression) Columns() []string {
+	return e.columns
+}
+
+// Eval evaluates the expression against the row
+func (e *Expression) Eval(row map[string]interface{}) (interface{}, errors.Error) {
+	value, err := e.root.eval(row)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to evaluate %s", e.source))
+	}
+	return value, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+var exprOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ","}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[i:j])})
+			i = j
+		case r == '_' || unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, token{tokenIdent, string(runes[i:j])})
+			i = j
+		case r == '\'' || r == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && (runes[j+1] == r || runes[j+1] == '\\') {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokenString, sb.String()})
+			i = j + 1
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, token{tokenOperator, op})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at %d", r, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression"}), nil
+}
+
+type exprParser struct {
+	tokens  []token
+	pos     int
+	columns map[string]bool
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) isOperator(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *exprParser) expect(op string) error {
+	if !p.isOperator(op) {
+		return fmt.Errorf("expected %s but got %s", op, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.isOperator("||") {
+		p.next()
+		var right exprNode
+		right, err = p.parseAnd()
+		left = &logicalNode{op: "||", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseComparison()
+	for err == nil && p.isOperator("&&") {
+		p.next()
+		var right exprNode
+		right, err = p.parseComparison()
+		left = &logicalNode{op: "&&", left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	op := ""
+	switch {
+	case p.isOperator("==", "!=", "<", "<=", ">", ">="):
+		op = t.text
+	case t.kind == tokenIdent && (t.text == "in" || t.text == "matches"):
+		op = t.text
+	case t.kind == tokenIdent && t.text == "not" && p.tokens[p.pos+1].kind == tokenIdent && p.tokens[p.pos+1].text == "in":
+		p.next()
+		op = "not in"
+	default:
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if op == "matches" {
+		right, err = compileLiteralPattern(right)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	for err == nil && p.isOperator("+", "-") {
+		op := p.next().text
+		var right exprNode
+		right, err = p.parseMultiplicative()
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	for err == nil && p.isOperator("*", "/", "%") {
+		op := p.next().text
+		var right exprNode
+		right, err = p.parseUnary()
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, err
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.isOperator("!", "-") {
+		op := p.next().text
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t.text)
+		}
+		return &literalNode{value: f}, nil
+	case tokenString:
+		return &literalNode{value: t.text}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if p.isOperator("(") {
+			p.next()
+			fn, ok := exprFunctions[t.text]
+			if !ok {
+				return nil, fmt.Errorf("unknown function %s", t.text)
+			}
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+				return nil, fmt.Errorf("wrong number of arguments for %s", t.text)
+			}
+			if t.text == "regex" {
+				args[1], err = compileLiteralPattern(args[1])
+				if err != nil {
+					return nil, err
+				}
+			}
+			return &callNode{name: t.text, fn: fn.call, args: args}, nil
+		}
+		p.columns[t.text] = true
+		return &columnNode{name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		case "[":
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{items: items}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", t.text)
+}
+
+// parseList parses comma separated expressions until the closing operator
+func (p *exprParser) parseList(closing string) ([]exprNode, error) {
+	var items []exprNode
+	if p.isOperator(closing) {
+		p.next()
+		return items, nil
+	}
+	for {
+		item, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.isOperator(",") {
+			p.next()
+			continue
+		}
+		return items, p.expect(closing)
+	}
+}
+
+type exprNode interface {
+	eval(row map[string]interface{}) (interface{}, error)
+}
+
+// compileLiteralPattern compiles a string literal pattern once at parse time, other nodes are returned as is
+func compileLiteralPattern(node exprNode) (exprNode, error) {
+	literal, ok := node.(*literalNode)
+	if !ok {
+		return node, nil
+	}
+	pattern, ok := literal.value.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern %v is not a string", literal.value)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &regexpNode{re: re}, nil
+}
+
+type regexpNode struct {
+	re *regexp.Regexp
+}
+
+func (n *regexpNode) eval(_ map[string]interface{}) (interface{}, error) {
+	return n.re, nil
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type columnNode struct {
+	name string
+}
+
+func (n *columnNode) eval(row map[string]interface{}) (interface{}, error) {
+	return normalizeValue(row[n.name]), nil
+}
+
+type listNode struct {
+	items []exprNode
+}
+
+func (n *listNode) eval(row map[string]interface{}) (interface{}, error) {
+	values := make([]interface{}, len(n.items))
+	for i, item := range n.items {
+		value, err := item.eval(row)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) eval(row map[string]interface{}) (interface{}, error) {
+	value, err := n.operand.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "!" {
+		return !truthy(value), nil
+	}
+	f, ok := toNumber(value)
+	if !ok {
+		return nil, fmt.Errorf("cannot negate %v", value)
+	}
+	return -f, nil
+}
+
+type logicalNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *logicalNode) eval(row map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	// short circuit
+	if n.op == "&&" && !truthy(left) {
+		return false, nil
+	}
+	if n.op == "||" && truthy(left) {
+		return true, nil
+	}
+	right, err := n.right.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	return truthy(right), nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *binaryNode) eval(row map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(row)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		if left == nil || right == nil {
+			return false, nil
+		}
+		c, err := compareValues(left, right)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	case "in", "not in":
+		list, ok := right.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s requires a list", n.op)
+		}
+		found := false
+		for _, item := range list {
+			if valuesEqual(left, item) {
+				found = true
+				break
+			}
+		}
+		return found == (n.op == "in"), nil
+	case "matches":
+		if left == nil {
+			return false, nil
+		}
+		re, err := compileRegexp(right)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString(toString(left)), nil
+	case "+":
+		if l, ok := left.(string); ok {
+			return l + toString(right), nil
+		}
+		if r, ok := right.(string); ok {
+			return toString(left) + r, nil
+		}
+	}
+	return arithmetic(n.op, left, right)
+}
+
+type callNode struct {
+	name string
+	fn   func(args []interface{}) (interface{}, error)
+	args []exprNode
+}
+
+func (n *callNode) eval(row map[string]interface{}) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(row)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	value, err := n.fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return value, nil
+}
+
+type exprFunction struct {
+	minArgs int
+	// maxArgs is -1 for variadic functions
+	maxArgs int
+	call    func(args []interface{}) (interface{}, error)
+}
+
+var exprFunctions = map[string]exprFunction{
+	"lower": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.ToLower(toString(args[0])), nil
+	}},
+	"upper": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.ToUpper(toString(args[0])), nil
+	}},
+	"trim": {1, 1, func(args []interface{}) (interface{}, error) {
+		return strings.TrimSpace(toString(args[0])), nil
+	}},
+	"len": {1, 1, func(args []interface{}) (interface{}, error) {
+		if list, ok := args[0].([]interface{}); ok {
+			return float64(len(list)), nil
+		}
+		return float64(len([]rune(toString(args[0])))), nil
+	}},
+	"contains": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.Contains(toString(args[0]), toString(args[1])), nil
+	}},
+	"startsWith": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.HasPrefix(toString(args[0]), toString(args[1])), nil
+	}},
+	"endsWith": {2, 2, func(args []interface{}) (interface{}, error) {
+		return strings.HasSuffix(toString(args[0]), toString(args[1])), nil
+	}},
+	// regex returns the first capture group of the pattern, or the whole match if it has no group
+	"regex": {2, 2, func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		re, err := compileRegexp(args[1])
+		if err != nil {
+			return nil, err
+		}
+		groups := re.FindStringSubmatch(toString(args[0]))
+		if groups == nil {
+			return nil, nil
+		}
+		if len(groups) > 1 {
+			return groups[1], nil
+		}
+		return groups[0], nil
+	}},
+	"coalesce": {1, -1, func(args []interface{}) (interface{}, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}},
+	"if": {3, 3, func(args []interface{}) (interface{}, error) {
+		if truthy(args[0]) {
+			return args[1], nil
+		}
+		return args[2], nil
+	}},
+	"number": {1, 1, func(args []interface{}) (interface{}, error) {
+		if f, ok := toNumber(args[0]); ok {
+			return f, nil
+		}
+		return nil, nil
+	}},
+	"string": {1, 1, func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return toString(args[0]), nil
+	}},
+}
+
+// maxDynamicRegexps bounds the cache of patterns that come from column values
+const maxDynamicRegexps = 256
+
+var dynamicRegexps = struct {
+	sync.Mutex
+	cache map[string]*regexp.Regexp
+}{cache: make(map[string]*regexp.Regexp)}
+
+// compileRegexp returns the pattern compiled at parse time, or compiles a pattern computed from the row
+func compileRegexp(pattern interface{}) (*regexp.Regexp, error) {
+	if re, ok := pattern.(*regexp.Regexp); ok {
+		return re, nil
+	}
+	s, ok := pattern.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern %v is not a string", pattern)
+	}
+	dynamicRegexps.Lock()
+	defer dynamicRegexps.Unlock()
+	if re, ok := dynamicRegexps.cache[s]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(dynamicRegexps.cache) >= maxDynamicRegexps {
+		dynamicRegexps.cache = make(map[string]*regexp.Regexp)
+	}
+	dynamicRegexps.cache[s] = re
+	return re, nil
+}
+
+// normalizeValue turns the values fetched from the database into nil, bool, float64, string, time.Time or a list
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
+
+func valuesEqual(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	if _, ok := left.(float64); ok {
+		if r, ok := toNumber(right); ok {
+			return left.(float64) == r
+		}
+	}
+	if _, ok := right.(float64); ok {
+		if l, ok := toNumber(left); ok {
+			return l == right.(float64)
+		}
+	}
+	if l, ok := left.(bool); ok {
+		return l == truthy(right)
+	}
+	if r, ok := right.(bool); ok {
+		return r == truthy(left)
+	}
+	return toString(left) == toString(right)
+}
+
+func compareValues(left, right interface{}) (int, error) {
+	if l, ok := left.(time.Time); ok {
+		r, ok := right.(time.Time)
+		if !ok {
+			t, err := time.Parse(time.RFC3339, toString(right))
+			if err != nil {
+				return 0, fmt.Errorf("cannot compare %v with %v", left, right)
+			}
+			r = t
+		}
+		return l.Compare(r), nil
+	}
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	if lok && rok {
+		switch {
+		case l < r:
+			return -1, nil
+		case l > r:
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return strings.Compare(toString(left), toString(right)), nil
+}
+
+func arithmetic(op string, left, right interface{}) (interface{}, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	if !lok || !rok {
+		return nil, fmt.Errorf("cannot apply %s to %v and %v", op, left, right)
+	}
+	switch op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, nil
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, nil
+		}
+		return math.Mod(l, r), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", op)
+}
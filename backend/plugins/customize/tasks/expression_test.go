@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpressionEval(t *testing.T) {
+	row := map[string]interface{}{
+		"type":          "BUG",
+		"priority":      []byte("P1"),
+		"title":         "Fix login [JIRA-123] crash",
+		"story_point":   float64(3),
+		"lead_time":     int64(120),
+		"x_team":        nil,
+		"resolved_date": time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	cases := []struct {
+		source   string
+		expected interface{}
+	}{
+		{"type == 'BUG' && priority in ['P0', 'P1']", true},
+		{"type == 'BUG' && priority in ['P0']", false},
+		{"priority not in ['P0']", true},
+		{"!(type != \"BUG\") || x_team", true},
+		{"regex(title, '\\[([A-Z]+-\\d+)\\]')", "JIRA-123"},
+		{"regex(title, 'crash')", "crash"},
+		{"regex(title, 'HOTFIX-(\\d+)')", nil},
+		{"title matches '(?i)LOGIN'", true},
+		{"lower(type) + '-' + upper('x')", "bug-X"},
+		{"story_point * 2 + lead_time / 60", float64(8)},
+		{"lead_time >= 120 && story_point < 5", true},
+		{"lead_time == '120'", true},
+		{"x_team == null", true},
+		{"coalesce(x_team, 'unknown')", "unknown"},
+		{"if(story_point > 2, 'large', 'small')", "large"},
+		{"len(title) > 10 && contains(title, 'login')", true},
+		{"resolved_date >= '2024-06-30T00:00:00Z'", true},
+		{"-story_point % 2", float64(-1)},
+		{"x_team + 1", nil},
+	}
+	for _, c := range cases {
+		expression, err := ParseExpression(c.source)
+		if !assert.Nil(t, err, c.source) {
+			continue
+		}
+		value, err := expression.Eval(row)
+		assert.Nil(t, err, c.source)
+		assert.Equal(t, c.expected, value, c.source)
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	for _, source := range []string{
+		"type ==",
+		"type == 'BUG",
+		"(type == 'BUG'",
+		"type = 'BUG'",
+		"exec('rm -rf /')",
+		"title matches '(['",
+		"regex(title, '(')",
+		"lower()",
+		"a b",
+	} {
+		_, err := ParseExpression(source)
+		assert.NotNil(t, err, source)
+	}
+}
+
+func TestExpressionColumns(t *testing.T) {
+	expression, err := ParseExpression("type == 'BUG' && regex(title, 'x') != null && type != 'true'")
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"type", "title"}, expression.Columns())
+}
+
+func TestExpressionDynamicPatternConcurrently(t *testing.T) {
+	expression, err := ParseExpression("title matches pattern")
+	if !assert.Nil(t, err) {
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 2*maxDynamicRegexps; j++ {
+				row := map[string]interface{}{
+					"title":   fmt.Sprintf("JIRA-%d-%d", i, j),
+					"pattern": fmt.Sprintf("^JIRA-%d-%d$", i, j),
+				}
+				value, err := expression.Eval(row)
+				assert.Nil(t, err)
+				assert.Equal(t, true, value)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, len(dynamicRegexps.cache), maxDynamicRegexps)
+}
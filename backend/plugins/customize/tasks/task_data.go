@@ -22,6 +22,11 @@ type MappingRules struct {
 	RawDataTable  string            `json:"rawDataTable" example:"_raw_jira_api_issues"`
 	RawDataParams string            `json:"rawDataParams" example:"{\"ConnectionId\":1,\"BoardId\":8}"`
 	Mapping       map[string]string `json:"mapping" example:"x_text:fields.created"`
+	// ChangelogMapping maps the customized fields of issues to the id or name of the field in issue_changelogs,
+	// their history is derived from the changelogs
+	ChangelogMapping map[string]string `json:"changelogMapping" example:"x_story_points:customfield_10016"`
+	// Expressions maps the customized fields to expressions over the columns of the table, including other customized fields
+	Expressions map[string]string `json:"expressions" example:"x_is_hotfix:type == 'BUG' && priority in ['P0', 'P1']"`
 }
 
 type Options struct {
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/forecast/tasks"
+)
+
+type SimulateRequest struct {
+	// Items asks when these many items will be done
+	Items int `json:"items" mapstructure:"items"`
+	// EpicKey asks when the items of the epic left on the board will be done
+	EpicKey string `json:"epicKey" mapstructure:"epicKey"`
+	// TargetDate asks how many items will be done by then, in the format of 2006-01-02
+	TargetDate  string `json:"targetDate" mapstructure:"targetDate"`
+	HistoryDays int    `json:"historyDays" mapstructure:"historyDays"`
+	Simulations int    `json:"simulations" mapstructure:"simulations"`
+	Confidences []int  `json:"confidences" mapstructure:"confidences"`
+}
+
+type SimulateResponse struct {
+	BoardId    string `json:"boardId"`
+	Throughput []int  `json:"throughput"`
+	// Forecasts is empty when nothing was done on the board during the history
+	Forecasts []*ticket.BoardForecast `json:"forecasts"`
+}
+
+// GetForecasts returns the forecasts of the board calculated by the last pipeline
+// @Summary get the forecasts of the board
+// @Tags plugins/forecast
+// @Param boardId path string true "board id"
+// @Param scope query string false "BACKLOG or the key of an epic"
+// @Success 200  {object} []ticket.BoardForecast
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/forecast/boards/{boardId}/forecasts [GET]
+func GetForecasts(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	clauses := []dal.Clause{dal.Where("board_id = ?", input.Params["boardId"])}
+	if scope := input.Query.Get("scope"); scope != "" {
+		clauses = append(clauses, dal.Where("scope = ?", scope))
+	}
+	clauses = append(clauses, dal.Orderby("scope, question, horizon_days, confidence"))
+	forecasts := []*ticket.BoardForecast{}
+	err := basicRes.GetDal().All(&forecasts, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: forecasts, Status: http.StatusOK}, nil
+}
+
+// Simulate runs the Monte Carlo simulations of the board on demand without saving the forecasts
+// @Summary forecast the delivery of the board
+// @Description answers "when will these N items be done" given the items or an epic, and "how many items by date X" given the target date
+// @Description the backlog of the board is forecast when neither the items nor the epic is given
+// @Tags plugins/forecast
+// @Accept application/json
+// @Param boardId path string true "board id"
+// @Param body body SimulateRequest true "json"
+// @Success 200  {object} SimulateResponse
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/forecast/boards/{boardId}/simulate [POST]
+func Simulate(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	boardId := input.Params["boardId"]
+	var req SimulateRequest
+	if err := helper.Decode(input.Body, &req, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid simulation request")
+	}
+	if req.Items < 0 {
+		return nil, errors.BadInput.New("items should not be negative")
+	}
+	op := &tasks.ForecastOptions{
+		HistoryDays: req.HistoryDays,
+		Simulations: req.Simulations,
+		Confidences: req.Confidences,
+	}
+	if err := op.FillDefaults(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var horizonDays int
+	if req.TargetDate != "" {
+		targetDate, e := time.ParseInLocation("2006-01-02", req.TargetDate, now.Location())
+		if e != nil {
+			return nil, errors.BadInput.Wrap(e, "targetDate should be in the format of 2006-01-02")
+		}
+		horizonDays = int(targetDate.Sub(now).Hours()/24) + 1
+		if horizonDays <= 0 {
+			return nil, errors.BadInput.New("targetDate should be in the future")
+		}
+		if horizonDays > tasks.MaxForecastDays {
+			return nil, errors.BadInput.New(fmt.Sprintf("targetDate should be within %d days", tasks.MaxForecastDays))
+		}
+	}
+
+	db := basicRes.GetDal()
+	throughput, err := tasks.LoadThroughput(db, boardId, op.HistoryDays, now)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SimulateResponse{BoardId: boardId, Throughput: throughput, Forecasts: []*ticket.BoardForecast{}}
+	sim := tasks.NewSimulator(throughput, op.Simulations, now.UnixNano())
+	if !sim.CanForecast() {
+		return &plugin.ApiResourceOutput{Body: resp, Status: http.StatusOK}, nil
+	}
+	scope := ticket.ForecastScopeBacklog
+	remainingItems := req.Items
+	if req.Items == 0 {
+		if req.EpicKey != "" {
+			scope = req.EpicKey
+		}
+		remainingItems, err = tasks.CountRemainingItems(db, boardId, req.EpicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if horizonDays > 0 {
+		resp.Forecasts = tasks.ForecastHowMany(sim, op, boardId, scope, remainingItems, horizonDays, now)
+	} else {
+		resp.Forecasts = tasks.ForecastWhen(sim, op, boardId, scope, remainingItems, now)
+	}
+	return &plugin.ApiResourceOutput{Body: resp, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/forecast/api"
+	"github.com/apache/incubator-devlake/plugins/forecast/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/forecast/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
+} = (*Forecast)(nil)
+
+type Forecast struct{}
+
+func (p Forecast) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p Forecast) Description() string {
+	return "forecast the delivery of the boards by Monte Carlo simulations of their throughput"
+}
+
+func (p Forecast) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{
+		{
+			"model": "issues",
+		},
+		{
+			"model": "board_issues",
+		},
+	}, nil
+}
+
+func (p Forecast) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p Forecast) Name() string {
+	return "forecast"
+}
+
+func (p Forecast) IsProjectMetric() bool {
+	return true
+}
+
+func (p Forecast) RunAfter() ([]string, errors.Error) {
+	return []string{}, nil
+}
+
+func (p Forecast) Settings() interface{} {
+	return nil
+}
+
+func (p Forecast) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CalculateBoardForecastsMeta,
+	}
+}
+
+func (p Forecast) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.ForecastTaskData{
+		Options: op,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p Forecast) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/forecast"
+}
+
+func (p Forecast) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Forecast) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"boards/:boardId/forecasts": {
+			"GET": api.GetForecasts,
+		},
+		"boards/:boardId/simulate": {
+			"POST": api.Simulate,
+		},
+	}
+}
+
+func (p Forecast) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.ForecastOptions{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "forecast",
+				Options: map[string]interface{}{
+					"projectName": projectName,
+					"historyDays": op.HistoryDays,
+					"simulations": op.Simulations,
+					"confidences": op.Confidences,
+					"horizonDays": op.HorizonDays,
+				},
+				Subtasks: []string{
+					"calculateBoardForecasts",
+				},
+			},
+		},
+	}
+	return plan, nil
+}
@@ -0,0 +1,129 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// boardItems filters the issues of the board being work items, epics are made of the items and not counted
+const boardItems = `issues.id IN (SELECT issue_id FROM board_issues WHERE board_id = ?)
+	AND issues.issue_key NOT IN (SELECT epic_key FROM issues e WHERE e.epic_key != '')`
+
+// LoadThroughput counts the items of the board done on each of the days before today
+func LoadThroughput(db dal.Dal, boardId string, historyDays int, now time.Time) ([]int, errors.Error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -historyDays)
+	var resolutionDates []time.Time
+	err := db.Pluck("resolution_date", &resolutionDates,
+		dal.From(&ticket.Issue{}),
+		dal.Where(boardItems+" AND issues.status = ? AND issues.resolution_date >= ? AND issues.resolution_date < ?",
+			boardId, ticket.DONE, from, today),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return CountThroughput(resolutionDates, from, historyDays), nil
+}
+
+// CountThroughput counts the dates on each of the days from `from`
+func CountThroughput(dates []time.Time, from time.Time, days int) []int {
+	throughput := make([]int, days)
+	for _, date := range dates {
+		day := int(date.UTC().Sub(from) / (24 * time.Hour))
+		if day >= 0 && day < days {
+			throughput[day]++
+		}
+	}
+	return throughput
+}
+
+// CountRemainingItems counts the items of the board not done yet, only the ones of the epic if the epicKey is given
+func CountRemainingItems(db dal.Dal, boardId, epicKey string) (int, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&ticket.Issue{}),
+		dal.Where(boardItems+" AND issues.status != ?", boardId, ticket.DONE),
+	}
+	if epicKey != "" {
+		clauses = append(clauses, dal.Where("issues.epic_key = ?", epicKey))
+	}
+	count, err := db.Count(clauses...)
+	return int(count), err
+}
+
+// ListOpenEpics lists the keys of the epics with items of the board not done yet
+func ListOpenEpics(db dal.Dal, boardId string) ([]string, errors.Error) {
+	var epicKeys []string
+	err := db.Pluck("DISTINCT issues.epic_key", &epicKeys,
+		dal.From(&ticket.Issue{}),
+		dal.Where(boardItems+" AND issues.status != ? AND issues.epic_key != ''", boardId, ticket.DONE),
+		dal.Orderby("issues.epic_key"),
+	)
+	return epicKeys, err
+}
+
+// ForecastWhen forecasts when the remaining items of the scope will be done
+func ForecastWhen(sim *Simulator, op *ForecastOptions, boardId, scope string, remainingItems int, now time.Time) []*ticket.BoardForecast {
+	forecasts := make([]*ticket.BoardForecast, len(op.Confidences))
+	for i, days := range sim.DaysToComplete(remainingItems, op.Confidences) {
+		forecast := newForecast(op, boardId, scope, ticket.ForecastWhen, op.Confidences[i], now)
+		forecast.RemainingItems = remainingItems
+		if days != nil {
+			completionDate := now.AddDate(0, 0, *days)
+			forecast.CompletionDate = &completionDate
+		}
+		forecasts[i] = forecast
+	}
+	return forecasts
+}
+
+// ForecastHowMany forecasts how many items of the scope will be done within the horizon
+func ForecastHowMany(sim *Simulator, op *ForecastOptions, boardId, scope string, remainingItems, horizonDays int, now time.Time) []*ticket.BoardForecast {
+	forecasts := make([]*ticket.BoardForecast, len(op.Confidences))
+	targetDate := now.AddDate(0, 0, horizonDays)
+	for i, items := range sim.ItemsWithin(horizonDays, op.Confidences) {
+		// no more than the items left
+		if items > remainingItems {
+			items = remainingItems
+		}
+		items := items
+		forecast := newForecast(op, boardId, scope, ticket.ForecastHowMany, op.Confidences[i], now)
+		forecast.HorizonDays = horizonDays
+		forecast.TargetDate = &targetDate
+		forecast.RemainingItems = remainingItems
+		forecast.CompletedItems = &items
+		forecasts[i] = forecast
+	}
+	return forecasts
+}
+
+func newForecast(op *ForecastOptions, boardId, scope, question string, confidence int, now time.Time) *ticket.BoardForecast {
+	return &ticket.BoardForecast{
+		BoardId:        boardId,
+		Scope:          scope,
+		Question:       question,
+		Confidence:     confidence,
+		HistoryDays:    op.HistoryDays,
+		Simulations:    op.Simulations,
+		CalculatedDate: now,
+	}
+}
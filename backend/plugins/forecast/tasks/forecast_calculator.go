@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateBoardForecastsMeta = plugin.SubTaskMeta{
+	Name:             "calculateBoardForecasts",
+	EntryPoint:       CalculateBoardForecasts,
+	EnabledByDefault: true,
+	Description:      "Forecast when the backlog and the epics of the boards will be done and how many items will be done, by Monte Carlo simulations of the throughput",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{ticket.Issue{}.TableName(), ticket.BoardIssue{}.TableName()},
+	ProductTables:    []string{ticket.BoardForecast{}.TableName()},
+}
+
+func CalculateBoardForecasts(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	op := taskCtx.GetData().(*ForecastTaskData).Options
+
+	var boardIds []string
+	err := db.Pluck("row_id", &boardIds, dal.From("project_mapping pm"), dal.Where("pm.project_name = ? AND pm.table = 'boards'", op.ProjectName))
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&ticket.BoardForecast{}, dal.Where("board_id IN ?", boardIds))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete the previous board_forecasts")
+	}
+	saver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.BoardForecast{}), 500)
+	if err != nil {
+		return err
+	}
+	save := func(forecasts []*ticket.BoardForecast) errors.Error {
+		for _, forecast := range forecasts {
+			if err := saver.Add(forecast); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	now := time.Now()
+	taskCtx.SetProgress(0, len(boardIds))
+	for _, boardId := range boardIds {
+		throughput, err := LoadThroughput(db, boardId, op.HistoryDays, now)
+		if err != nil {
+			return err
+		}
+		sim := NewSimulator(throughput, op.Simulations, now.UnixNano())
+		if !sim.CanForecast() {
+			taskCtx.GetLogger().Info("nothing was done on board %s in the last %d days, skip forecasting", boardId, op.HistoryDays)
+			taskCtx.IncProgress(1)
+			continue
+		}
+		remainingItems, err := CountRemainingItems(db, boardId, "")
+		if err != nil {
+			return err
+		}
+		if err = save(ForecastWhen(sim, op, boardId, ticket.ForecastScopeBacklog, remainingItems, now)); err != nil {
+			return err
+		}
+		for _, horizonDays := range op.HorizonDays {
+			if err = save(ForecastHowMany(sim, op, boardId, ticket.ForecastScopeBacklog, remainingItems, horizonDays, now)); err != nil {
+				return err
+			}
+		}
+		epicKeys, err := ListOpenEpics(db, boardId)
+		if err != nil {
+			return err
+		}
+		for _, epicKey := range epicKeys {
+			epicItems, err := CountRemainingItems(db, boardId, epicKey)
+			if err != nil {
+				return err
+			}
+			if err = save(ForecastWhen(sim, op, boardId, epicKey, epicItems, now)); err != nil {
+				return err
+			}
+		}
+		taskCtx.IncProgress(1)
+	}
+	return saver.Close()
+}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// MaxForecastDays bounds the simulations, the items not done within it are considered never done
+const MaxForecastDays = 3650
+
+// Simulator runs Monte Carlo simulations of the delivery by sampling the daily throughput history
+type Simulator struct {
+	throughput  []int
+	simulations int
+	rand        *rand.Rand
+}
+
+func NewSimulator(throughput []int, simulations int, seed int64) *Simulator {
+	return &Simulator{
+		throughput:  throughput,
+		simulations: simulations,
+		rand:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+// CanForecast tells whether anything was done in the history
+func (s *Simulator) CanForecast() bool {
+	for _, count := range s.throughput {
+		if count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Simulator) sample() int {
+	return s.throughput[s.rand.Intn(len(s.throughput))]
+}
+
+// DaysToComplete returns, for each confidence, the number of days within which that percentage of the simulations
+// completed the items. It is nil for a confidence when the items are not completed within MaxForecastDays.
+func (s *Simulator) DaysToComplete(items int, confidences []int) []*int {
+	result := make([]*int, len(confidences))
+	if !s.CanForecast() {
+		return result
+	}
+	outcomes := make([]int, s.simulations)
+	for i := range outcomes {
+		done, days := 0, 0
+		for done < items && days <= MaxForecastDays {
+			done += s.sample()
+			days++
+		}
+		outcomes[i] = days
+	}
+	sort.Ints(outcomes)
+	for i, confidence := range confidences {
+		days := outcomes[rankIndex(confidence, len(outcomes))]
+		if days <= MaxForecastDays {
+			result[i] = &days
+		}
+	}
+	return result
+}
+
+// ItemsWithin returns, for each confidence, the number of items at least that percentage of the simulations
+// completed within the days
+func (s *Simulator) ItemsWithin(days int, confidences []int) []int {
+	result := make([]int, len(confidences))
+	if !s.CanForecast() {
+		return result
+	}
+	outcomes := make([]int, s.simulations)
+	for i := range outcomes {
+		for d := 0; d < days; d++ {
+			outcomes[i] += s.sample()
+		}
+	}
+	// the more confident, the fewer items
+	sort.Sort(sort.Reverse(sort.IntSlice(outcomes)))
+	for i, confidence := range confidences {
+		result[i] = outcomes[rankIndex(confidence, len(outcomes))]
+	}
+	return result
+}
+
+// rankIndex is the index of the nearest-rank percentile in n sorted outcomes
+func rankIndex(percentile int, n int) int {
+	index := int(math.Ceil(float64(percentile)/100*float64(n))) - 1
+	if index < 0 {
+		return 0
+	}
+	if index >= n {
+		return n - 1
+	}
+	return index
+}
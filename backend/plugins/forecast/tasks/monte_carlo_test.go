@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorConstantThroughput(t *testing.T) {
+	sim := NewSimulator([]int{2, 2, 2}, 100, 1)
+	assert.True(t, sim.CanForecast())
+	days := sim.DaysToComplete(9, []int{50, 95})
+	assert.Equal(t, 5, *days[0])
+	assert.Equal(t, 5, *days[1])
+	assert.Equal(t, []int{20, 20}, sim.ItemsWithin(10, []int{50, 95}))
+}
+
+func TestSimulatorConfidence(t *testing.T) {
+	// half of the days nothing is done, the other half 4 items
+	sim := NewSimulator([]int{0, 4}, 2000, 42)
+	days := sim.DaysToComplete(20, []int{50, 85, 95})
+	assert.True(t, *days[0] <= *days[1] && *days[1] <= *days[2])
+	assert.InDelta(t, 10, *days[0], 1)
+	items := sim.ItemsWithin(10, []int{50, 85, 95})
+	assert.True(t, items[0] >= items[1] && items[1] >= items[2])
+	assert.InDelta(t, 20, items[0], 4)
+}
+
+func TestSimulatorWithoutThroughput(t *testing.T) {
+	sim := NewSimulator([]int{0, 0}, 10, 1)
+	assert.False(t, sim.CanForecast())
+	assert.Equal(t, []*int{nil}, sim.DaysToComplete(3, []int{85}))
+	assert.Equal(t, []int{0}, sim.ItemsWithin(3, []int{85}))
+}
+
+func TestCountThroughput(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	throughput := CountThroughput([]time.Time{
+		time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 1, 18, 0, 0, 0, time.UTC),
+		time.Date(2024, 7, 3, 23, 59, 0, 0, time.UTC),
+		time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC),
+	}, from, 3)
+	assert.Equal(t, []int{2, 0, 1}, throughput)
+}
+
+func TestForecasts(t *testing.T) {
+	op := &ForecastOptions{HistoryDays: 3, Simulations: 10, Confidences: []int{50, 85}}
+	now := time.Date(2024, 7, 10, 0, 0, 0, 0, time.UTC)
+	sim := NewSimulator([]int{1}, 10, 1)
+
+	forecasts := ForecastWhen(sim, op, "b1", "EPIC-1", 3, now)
+	assert.Len(t, forecasts, 2)
+	assert.Equal(t, ticket.ForecastWhen, forecasts[1].Question)
+	assert.Equal(t, 85, forecasts[1].Confidence)
+	assert.Equal(t, "EPIC-1", forecasts[1].Scope)
+	assert.Equal(t, time.Date(2024, 7, 13, 0, 0, 0, 0, time.UTC), *forecasts[1].CompletionDate)
+
+	forecasts = ForecastHowMany(sim, op, "b1", ticket.ForecastScopeBacklog, 5, 14, now)
+	assert.Equal(t, ticket.ForecastHowMany, forecasts[0].Question)
+	assert.Equal(t, 14, forecasts[0].HorizonDays)
+	assert.Equal(t, time.Date(2024, 7, 24, 0, 0, 0, 0, time.UTC), *forecasts[0].TargetDate)
+	// no more than the remaining items
+	assert.Equal(t, 5, *forecasts[0].CompletedItems)
+}
+
+func TestForecastOptionsLimits(t *testing.T) {
+	op := &ForecastOptions{}
+	assert.Nil(t, op.FillDefaults())
+	assert.Equal(t, DefaultSimulations, op.Simulations)
+	assert.Nil(t, (&ForecastOptions{Simulations: MaxSimulations, HistoryDays: MaxHistoryDays, HorizonDays: []int{MaxForecastDays}}).FillDefaults())
+	for _, op := range []*ForecastOptions{
+		{Simulations: MaxSimulations + 1},
+		{HistoryDays: MaxHistoryDays + 1},
+		{HorizonDays: []int{MaxForecastDays + 1}},
+		{Confidences: []int{101}},
+	} {
+		err := op.FillDefaults()
+		if assert.NotNil(t, err) {
+			assert.Equal(t, http.StatusBadRequest, err.GetType().GetHttpCode())
+		}
+	}
+}
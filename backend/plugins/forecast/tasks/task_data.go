@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var (
+	DefaultHistoryDays = 90
+	DefaultSimulations = 10000
+	DefaultConfidences = []int{50, 85, 95}
+	DefaultHorizonDays = []int{14, 30}
+	// MaxSimulations and MaxHistoryDays bound the memory and time spent by the forecasts
+	MaxSimulations = 100000
+	MaxHistoryDays = 730
+)
+
+type ForecastOptions struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName"`
+	// HistoryDays is how many days of throughput history the simulations sample
+	HistoryDays int `json:"historyDays" mapstructure:"historyDays"`
+	Simulations int `json:"simulations" mapstructure:"simulations"`
+	// Confidences are the percentages of the simulations the forecasts are met by
+	Confidences []int `json:"confidences" mapstructure:"confidences"`
+	// HorizonDays are the target dates of the HOW_MANY forecasts, in days from now
+	HorizonDays []int `json:"horizonDays" mapstructure:"horizonDays"`
+}
+
+type ForecastTaskData struct {
+	Options *ForecastOptions
+}
+
+// FillDefaults sets the default values of the missing options and validates them
+func (op *ForecastOptions) FillDefaults() errors.Error {
+	if op.HistoryDays <= 0 {
+		op.HistoryDays = DefaultHistoryDays
+	}
+	if op.Simulations <= 0 {
+		op.Simulations = DefaultSimulations
+	}
+	if len(op.Confidences) == 0 {
+		op.Confidences = DefaultConfidences
+	}
+	if len(op.HorizonDays) == 0 {
+		op.HorizonDays = DefaultHorizonDays
+	}
+	if op.HistoryDays > MaxHistoryDays {
+		return errors.BadInput.New(fmt.Sprintf("invalid historyDays %d, it should be at most %d", op.HistoryDays, MaxHistoryDays))
+	}
+	if op.Simulations > MaxSimulations {
+		return errors.BadInput.New(fmt.Sprintf("invalid simulations %d, it should be at most %d", op.Simulations, MaxSimulations))
+	}
+	for _, confidence := range op.Confidences {
+		if confidence <= 0 || confidence > 100 {
+			return errors.BadInput.New(fmt.Sprintf("invalid confidence %d, it should be between 1 and 100", confidence))
+		}
+	}
+	for _, days := range op.HorizonDays {
+		if days <= 0 || days > MaxForecastDays {
+			return errors.BadInput.New(fmt.Sprintf("invalid horizon %d, it should be between 1 and %d", days, MaxForecastDays))
+		}
+	}
+	return nil
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*ForecastOptions, errors.Error) {
+	var op ForecastOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding forecast task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required for forecast")
+	}
+	return &op, op.FillDefaults()
+}
@@ -28,8 +28,9 @@ func _() {}
 type GitextractorBlueprintPlan [][]struct {
 	Plugin  string `json:"plugin"`
 	Options struct {
-		URL    string `json:"url"`
-		RepoID string `json:"repoId"`
+		URL                  string            `json:"url"`
+		RepoID               string            `json:"repoId"`
+		FileCategoryPatterns map[string]string `json:"fileCategoryPatterns"`
 	} `json:"options"`
 }
 
@@ -44,7 +45,8 @@ func _() {}
 type GitextractorPipelinePlan [][]struct {
 	Plugin  string `json:"plugin"`
 	Options struct {
-		URL    string `json:"url"`
-		RepoID string `json:"repoId"`
+		URL                  string            `json:"url"`
+		RepoID               string            `json:"repoId"`
+		FileCategoryPatterns map[string]string `json:"fileCategoryPatterns"`
 	} `json:"options"`
 }
@@ -24,6 +24,8 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/tasks"
 	giturls "github.com/chainguard-dev/git-urls"
@@ -33,12 +35,15 @@ var _ interface {
 	plugin.PluginMeta
 	plugin.PluginTask
 	plugin.PluginModel
+	plugin.PluginMigration
 } = (*GitExtractor)(nil)
 
 type GitExtractor struct{}
 
 func (p GitExtractor) GetTablesInfo() []dal.Tabler {
-	return []dal.Tabler{}
+	return []dal.Tabler{
+		&models.GitRefTip{},
+	}
 }
 
 func (p GitExtractor) Description() string {
@@ -53,10 +58,12 @@ func (p GitExtractor) Name() string {
 func (p GitExtractor) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.CloneGitRepoMeta,
+		tasks.ExtractCodeOwnersMeta,
 		tasks.CollectGitCommitMeta,
 		tasks.CollectGitBranchMeta,
 		tasks.CollectGitTagMeta,
 		tasks.CollectGitDiffLineMeta,
+		tasks.CollectGitDependencyMeta,
 	}
 }
 
@@ -99,9 +106,15 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 	log.Info("SkipCommitStat: %v", *op.SkipCommitStat)
 	log.Info("SkipCommitFiles: %v", *op.SkipCommitFiles)
 
+	fileClassifier, e := parser.NewFileClassifier(op.FileCategoryPatterns)
+	if e != nil {
+		return nil, e
+	}
+
 	taskData := &parser.GitExtractorTaskData{
-		Options:   &op,
-		ParsedURL: parsedURL,
+		Options:        &op,
+		ParsedURL:      parsedURL,
+		FileClassifier: fileClassifier,
 	}
 	return taskData, nil
 }
@@ -120,3 +133,7 @@ func (p GitExtractor) Close(taskCtx plugin.TaskContext) errors.Error {
 func (p GitExtractor) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/gitextractor"
 }
+
+func (p GitExtractor) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
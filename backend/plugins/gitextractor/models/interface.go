@@ -31,5 +31,13 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	RepoDependencies(dependency *code.RepoDependency) errors.Error
+	// DeleteRepoDependencies removes the dependencies previously extracted from the ref
+	DeleteRepoDependencies(repoId string, refName string) errors.Error
+	RefTips(tip *GitRefTip) errors.Error
+	// DeleteRefTip removes the tip previously processed for the ref
+	DeleteRefTip(repoId string, refName string) errors.Error
+	// SetIncrementalMode keeps the previously collected commits instead of deleting them before saving the new ones
+	SetIncrementalMode(incremental bool)
 	Close() errors.Error
 }
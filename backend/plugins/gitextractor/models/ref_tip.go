@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GitRefTip is the commit a ref pointed to when the commits of the repo were collected, the commits reachable from
+// the tips are skipped by the next incremental collection
+type GitRefTip struct {
+	RepoId    string `gorm:"primaryKey;type:varchar(255)"`
+	RefName   string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha string `gorm:"type:varchar(40)"`
+	// the options affect what are collected for each commit, commits have to be collected again once they are changed
+	SkipCommitStat  bool
+	SkipCommitFiles bool
+	// OptionsHash is the hash of the file category patterns and the components the commit files were classified by
+	OptionsHash string `gorm:"type:varchar(64)"`
+	common.NoPKModel
+}
+
+func (GitRefTip) TableName() string {
+	return "_tool_gitextractor_ref_tips"
+}
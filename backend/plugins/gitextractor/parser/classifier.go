@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+// languages by extension, following the names of github linguist
+var extensionLanguages = map[string]string{
+	".go": "Go", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin", ".scala": "Scala", ".groovy": "Groovy",
+	".gradle": "Groovy", ".py": "Python", ".pyi": "Python", ".rb": "Ruby", ".php": "PHP", ".js": "JavaScript",
+	".mjs": "JavaScript", ".cjs": "JavaScript", ".jsx": "JavaScript", ".ts": "TypeScript", ".tsx": "TSX",
+	".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++", ".cxx": "C++", ".hpp": "C++", ".hh": "C++", ".cs": "C#",
+	".m": "Objective-C", ".mm": "Objective-C++", ".swift": "Swift", ".rs": "Rust", ".dart": "Dart",
+	".ex": "Elixir", ".exs": "Elixir", ".erl": "Erlang", ".hs": "Haskell", ".clj": "Clojure", ".lua": "Lua",
+	".pl": "Perl", ".pm": "Perl", ".r": "R", ".sh": "Shell", ".bash": "Shell", ".zsh": "Shell",
+	".ps1": "PowerShell", ".sql": "SQL", ".html": "HTML", ".htm": "HTML", ".css": "CSS", ".scss": "SCSS",
+	".sass": "Sass", ".less": "Less", ".vue": "Vue", ".svelte": "Svelte", ".md": "Markdown",
+	".markdown": "Markdown", ".rst": "reStructuredText", ".adoc": "AsciiDoc", ".txt": "Text", ".yml": "YAML",
+	".yaml": "YAML", ".json": "JSON", ".xml": "XML", ".toml": "TOML", ".ini": "INI", ".cfg": "INI",
+	".properties": "Java Properties", ".proto": "Protocol Buffer", ".tf": "HCL", ".hcl": "HCL",
+	".graphql": "GraphQL", ".bzl": "Starlark", ".cmake": "CMake", ".mk": "Makefile", ".dockerfile": "Dockerfile",
+}
+
+// languages of the files without meaningful extension
+var filenameLanguages = map[string]string{
+	"Dockerfile": "Dockerfile", "Containerfile": "Dockerfile", "Makefile": "Makefile", "GNUmakefile": "Makefile",
+	"CMakeLists.txt": "CMake", "Jenkinsfile": "Groovy", "Gemfile": "Ruby", "Rakefile": "Ruby",
+	"go.mod": "Go Module", "go.sum": "Go Checksums", "BUILD": "Starlark", "BUILD.bazel": "Starlark",
+	"WORKSPACE": "Starlark", "Vagrantfile": "Ruby", "requirements.txt": "Pip Requirements",
+}
+
+// languages by the interpreter of the shebang
+var interpreterLanguages = map[string]string{
+	"python": "Python", "node": "JavaScript", "nodejs": "JavaScript", "bash": "Shell", "sh": "Shell",
+	"zsh": "Shell", "ksh": "Shell", "dash": "Shell", "ruby": "Ruby", "perl": "Perl", "php": "PHP", "lua": "Lua",
+	"Rscript": "R", "pwsh": "PowerShell",
+}
+
+var interpreterVersionRegex = regexp.MustCompile(`[\d.]+$`)
+
+var configLanguages = map[string]bool{
+	"YAML": true, "JSON": true, "XML": true, "TOML": true, "INI": true, "Java Properties": true,
+	"Go Module": true, "Pip Requirements": true,
+}
+
+var docsLanguages = map[string]bool{
+	"Markdown": true, "reStructuredText": true, "AsciiDoc": true, "Text": true,
+}
+
+var builtinCategoryRegexes = map[string]*regexp.Regexp{
+	code.FILE_CATEGORY_VENDORED: regexp.MustCompile(
+		`(^|/)(vendor|node_modules|third_party|thirdparty|bower_components|Godeps/_workspace)/`),
+	code.FILE_CATEGORY_GENERATED: regexp.MustCompile(`(\.pb\.go|\.pb\.gw\.go|\.pb\.cc|\.pb\.h|_pb2\.py|_pb2_grpc\.py|` +
+		`\.gen\.go|_generated\.\w+|(^|/)zz_generated[^/]*|\.min\.js|\.min\.css|\.js\.map|(^|/)(package-lock\.json|` +
+		`yarn\.lock|pnpm-lock\.yaml|go\.sum|Cargo\.lock|poetry\.lock|Pipfile\.lock|Gemfile\.lock|composer\.lock))$`),
+	code.FILE_CATEGORY_TEST: regexp.MustCompile(`((^|/)(tests?|__tests__|spec|testdata|e2e)/|_test\.go$|` +
+		`(^|/)test_[^/]*\.py$|_test\.py$|\.(test|spec)\.[jt]sx?$|Tests?\.(java|kt|scala|cs)$|_spec\.rb$)`),
+	code.FILE_CATEGORY_DOCS: regexp.MustCompile(`(^|/)(docs?|documentation)/|` +
+		`(^|/)(README|CHANGELOG|CHANGES|LICENSE|NOTICE|CONTRIBUTING|AUTHORS)([.-][^/]*)?$`),
+	code.FILE_CATEGORY_CONFIG: regexp.MustCompile(`(^|/)(\.[^/]+|\.github/.*)$`),
+}
+
+// categoryPrecedence is the order to apply the patterns, the first matched category wins
+var categoryPrecedence = []string{
+	code.FILE_CATEGORY_VENDORED,
+	code.FILE_CATEGORY_GENERATED,
+	code.FILE_CATEGORY_TEST,
+	code.FILE_CATEGORY_DOCS,
+	code.FILE_CATEGORY_CONFIG,
+	code.FILE_CATEGORY_PRODUCTION,
+}
+
+type fileClassification struct {
+	language string
+	category string
+}
+
+// FileClassifier tells the language and the category of the files of a repo, the results are cached by the
+// file path since the same files are changed by many commits
+type FileClassifier struct {
+	overrides map[string]*regexp.Regexp
+	cache     map[string]fileClassification
+}
+
+// NewFileClassifier creates a FileClassifier, the patterns are regular expressions by category, which override
+// the builtin rules of the category
+func NewFileClassifier(patterns map[string]string) (*FileClassifier, errors.Error) {
+	classifier := &FileClassifier{
+		overrides: make(map[string]*regexp.Regexp),
+		cache:     make(map[string]fileClassification),
+	}
+	for category, pattern := range patterns {
+		category = strings.ToUpper(category)
+		if !isFileCategory(category) {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown file category %s", category))
+		}
+		if pattern == "" {
+			continue
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid pattern of file category %s", category))
+		}
+		classifier.overrides[category] = regex
+	}
+	return classifier, nil
+}
+
+func isFileCategory(category string) bool {
+	for _, c := range categoryPrecedence {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify returns the language and the category of the file, readHead is called to detect the language by
+// shebang only when the file has no known extension, it may be nil or return nil if the content is unavailable
+func (c *FileClassifier) Classify(filePath string, readHead func() []byte) (language string, category string) {
+	if result, ok := c.cache[filePath]; ok {
+		return result.language, result.category
+	}
+	language = detectLanguage(filePath, readHead)
+	category = c.detectCategory(filePath, language)
+	c.cache[filePath] = fileClassification{language: language, category: category}
+	return language, category
+}
+
+func detectLanguage(filePath string, readHead func() []byte) string {
+	name := path.Base(filePath)
+	if language, ok := filenameLanguages[name]; ok {
+		return language
+	}
+	if language, ok := extensionLanguages[strings.ToLower(path.Ext(name))]; ok {
+		return language
+	}
+	if readHead == nil || path.Ext(name) != "" {
+		return ""
+	}
+	return detectShebangLanguage(readHead())
+}
+
+// detectShebangLanguage detects the language by the interpreter like `#!/usr/bin/env python3` or `#!/bin/bash -e`
+func detectShebangLanguage(head []byte) string {
+	if !bytes.HasPrefix(head, []byte("#!")) {
+		return ""
+	}
+	line, _, _ := bufio.NewReader(bytes.NewReader(head[2:])).ReadLine()
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		// skip the options of env like `-S`
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = field
+				break
+			}
+		}
+	}
+	return interpreterLanguages[interpreterVersionRegex.ReplaceAllString(interpreter, "")]
+}
+
+func (c *FileClassifier) detectCategory(filePath string, language string) string {
+	for _, category := range categoryPrecedence {
+		if regex, ok := c.overrides[category]; ok && regex.MatchString(filePath) {
+			return category
+		}
+	}
+	for _, category := range categoryPrecedence {
+		if regex, ok := builtinCategoryRegexes[category]; ok && regex.MatchString(filePath) {
+			return category
+		}
+		if category == code.FILE_CATEGORY_DOCS && docsLanguages[language] {
+			return category
+		}
+		if category == code.FILE_CATEGORY_CONFIG && configLanguages[language] {
+			return category
+		}
+	}
+	return code.FILE_CATEGORY_PRODUCTION
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileClassifierClassify(t *testing.T) {
+	classifier, err := NewFileClassifier(nil)
+	assert.Nil(t, err)
+	cases := []struct {
+		filePath string
+		head     string
+		language string
+		category string
+	}{
+		{"backend/server/main.go", "", "Go", code.FILE_CATEGORY_PRODUCTION},
+		{"backend/server/services/pipeline_test.go", "", "Go", code.FILE_CATEGORY_TEST},
+		{"config-ui/src/App.test.tsx", "", "TSX", code.FILE_CATEGORY_TEST},
+		{"src/test/java/org/example/AppTest.java", "", "Java", code.FILE_CATEGORY_TEST},
+		{"docs/getting-started.md", "", "Markdown", code.FILE_CATEGORY_DOCS},
+		{"README.md", "", "Markdown", code.FILE_CATEGORY_DOCS},
+		{"LICENSE", "", "", code.FILE_CATEGORY_DOCS},
+		{"deployment/helm/values.yaml", "", "YAML", code.FILE_CATEGORY_CONFIG},
+		{".github/workflows/test.yml", "", "YAML", code.FILE_CATEGORY_CONFIG},
+		{".gitignore", "", "", code.FILE_CATEGORY_CONFIG},
+		{"vendor/github.com/pkg/errors/errors.go", "", "Go", code.FILE_CATEGORY_VENDORED},
+		{"api/v1/service.pb.go", "", "Go", code.FILE_CATEGORY_GENERATED},
+		{"config-ui/package-lock.json", "", "JSON", code.FILE_CATEGORY_GENERATED},
+		{"scripts/deploy", "#!/usr/bin/env python3\nimport sys\n", "Python", code.FILE_CATEGORY_PRODUCTION},
+		{"scripts/build", "#!/bin/bash -e\n", "Shell", code.FILE_CATEGORY_PRODUCTION},
+		{"Dockerfile", "", "Dockerfile", code.FILE_CATEGORY_PRODUCTION},
+	}
+	for _, c := range cases {
+		t.Run(c.filePath, func(t *testing.T) {
+			language, category := classifier.Classify(c.filePath, func() []byte { return []byte(c.head) })
+			assert.Equal(t, c.language, language)
+			assert.Equal(t, c.category, category)
+		})
+	}
+}
+
+func TestFileClassifierOverrides(t *testing.T) {
+	classifier, err := NewFileClassifier(map[string]string{
+		"generated":  `^backend/mocks/`,
+		"PRODUCTION": `^backend/test/helper/`,
+	})
+	assert.Nil(t, err)
+	_, category := classifier.Classify("backend/mocks/core/dal/Dal.go", nil)
+	assert.Equal(t, code.FILE_CATEGORY_GENERATED, category)
+	// the builtin rules would take it as a test
+	_, category = classifier.Classify("backend/test/helper/api.go", nil)
+	assert.Equal(t, code.FILE_CATEGORY_PRODUCTION, category)
+
+	_, err = NewFileClassifier(map[string]string{"unknown": ".*"})
+	assert.NotNil(t, err)
+	_, err = NewFileClassifier(map[string]string{"TEST": "("})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnersPaths are the locations of the CODEOWNERS file supported by GitHub and GitLab, the first found is used
+var CodeOwnersPaths = []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersRule is a line of the CODEOWNERS file
+type CodeOwnersRule struct {
+	// Section is the GitLab section the rule belongs to, empty for GitHub
+	Section string
+	Pattern string
+	Owners  []string
+	// PathRegex is the equivalent regular expression of the Pattern
+	PathRegex string
+}
+
+var codeOwnersSectionRegex = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?\s*(.*)$`)
+
+// ParseCodeOwners parses the CODEOWNERS file in GitHub or GitLab syntax, the rules are returned in the order
+// of the file, which means the latter rule takes precedence over the former
+func ParseCodeOwners(content []byte) []*CodeOwnersRule {
+	var rules []*CodeOwnersRule
+	var section string
+	var sectionOwners []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripCodeOwnersComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		// GitLab section like `[Documentation] @docs-team` or `^[Optional Section][2]`
+		if matches := codeOwnersSectionRegex.FindStringSubmatch(line); matches != nil {
+			section = strings.TrimSpace(matches[1])
+			sectionOwners = strings.Fields(matches[2])
+			continue
+		}
+		fields := splitCodeOwnersFields(line)
+		pattern := fields[0]
+		owners := fields[1:]
+		if len(owners) == 0 {
+			// GitLab falls back to the default owners of the section, GitHub treats it as unowned
+			owners = sectionOwners
+		}
+		rules = append(rules, &CodeOwnersRule{
+			Section:   section,
+			Pattern:   pattern,
+			Owners:    owners,
+			PathRegex: CodeOwnersPatternToRegex(pattern),
+		})
+	}
+	return rules
+}
+
+// stripCodeOwnersComment removes the comment starting with an unescaped #
+func stripCodeOwnersComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if line[i] == '#' {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitCodeOwnersFields splits the line by unescaped spaces, escaped characters are unescaped
+func splitCodeOwnersFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case c == ' ' || c == '\t':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// CodeOwnersPatternToRegex converts the gitignore style pattern to the regular expression matching the file paths
+func CodeOwnersPatternToRegex(pattern string) string {
+	// a pattern with a slash in the beginning or middle is relative to the root, otherwise matches at any level
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// `docs/*` matches the files directly under docs, not the ones in the subdirectories
+	directChildren := strings.HasSuffix(pattern, "/*")
+
+	var body strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			body.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			body.WriteString(".*")
+			i++
+		case c == '*':
+			body.WriteString("[^/]*")
+		case c == '?':
+			body.WriteString("[^/]")
+		default:
+			body.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	var result strings.Builder
+	result.WriteString("^")
+	if !anchored && pattern != "*" {
+		result.WriteString("(?:.*/)?")
+	}
+	result.WriteString(body.String())
+	switch {
+	case pattern == "*" || pattern == "":
+		result.Reset()
+		result.WriteString("^.*$")
+	case directChildren:
+		result.WriteString("$")
+	case directoryOnly:
+		result.WriteString("/.*$")
+	default:
+		result.WriteString("(?:/.*)?$")
+	}
+	return result.String()
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	content := []byte(`
+# default owners
+*       @apache/devlake-committers
+
+/backend/ @apache/backend dev@example.com # backend team
+docs/*  @writer
+\#hash  @hash-owner
+
+[Config UI][2] @apache/frontend
+config-ui/
+config-ui/package.json @alice
+`)
+	rules := ParseCodeOwners(content)
+	assert.Equal(t, 6, len(rules))
+	assert.Equal(t, &CodeOwnersRule{Pattern: "*", Owners: []string{"@apache/devlake-committers"}, PathRegex: "^.*$"}, rules[0])
+	assert.Equal(t, []string{"@apache/backend", "dev@example.com"}, rules[1].Owners)
+	assert.Equal(t, "#hash", rules[3].Pattern)
+	assert.Equal(t, "Config UI", rules[4].Section)
+	assert.Equal(t, []string{"@apache/frontend"}, rules[4].Owners)
+	assert.Equal(t, []string{"@alice"}, rules[5].Owners)
+}
+
+func TestCodeOwnersPatternToRegex(t *testing.T) {
+	cases := []struct {
+		pattern    string
+		matched    []string
+		notMatched []string
+	}{
+		{"*", []string{"README.md", "a/b/c.go"}, nil},
+		{"*.js", []string{"app.js", "src/app.js"}, []string{"app.jsx"}},
+		{"/build/logs/", []string{"build/logs/a.log", "build/logs/x/y.log"}, []string{"src/build/logs/a.log", "build/logs"}},
+		{"docs/*", []string{"docs/getting-started.md"}, []string{"docs/build-app/troubleshooting.md", "src/docs/a.md"}},
+		{"apps/", []string{"apps/a.go", "src/apps/b.go"}, []string{"apps.go"}},
+		{"/docs", []string{"docs", "docs/a.md"}, []string{"src/docs/a.md", "docs.md"}},
+		{"**/logs", []string{"logs/a", "build/logs/a", "logs"}, []string{"build/logsx"}},
+		{"/src/**/test?.go", []string{"src/test1.go", "src/a/b/testx.go"}, []string{"src/a/test12.go"}},
+	}
+	for _, c := range cases {
+		r := regexp.MustCompile(CodeOwnersPatternToRegex(c.pattern))
+		for _, path := range c.matched {
+			assert.True(t, r.MatchString(path), "%s should match %s", c.pattern, path)
+		}
+		for _, path := range c.notMatched {
+			assert.False(t, r.MatchString(path), "%s should not match %s", c.pattern, path)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+)
+
+const defaultComponentName = "Default"
+
+type componentMatcher struct {
+	name      string
+	pathRegex *regexp.Regexp
+}
+
+// componentMatchers assigns a file to the first matched Component, they are sorted by SortingIndex descendingly
+type componentMatchers []componentMatcher
+
+func loadComponentMatchers(db dal.Dal, repoId string) (componentMatchers, errors.Error) {
+	components := make([]code.Component, 0)
+	err := db.All(&components,
+		dal.From(&code.Component{}),
+		dal.Where("repo_id = ?", repoId),
+		dal.Orderby("sorting_index DESC, name"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	matchers := make(componentMatchers, 0, len(components))
+	for _, component := range components {
+		pathRegex, e := regexp.Compile(component.PathRegex)
+		if e != nil {
+			return nil, errors.BadInput.Wrap(e, fmt.Sprintf("invalid path regex of component %s", component.Name))
+		}
+		matchers = append(matchers, componentMatcher{name: component.Name, pathRegex: pathRegex})
+	}
+	return matchers, nil
+}
+
+func (matchers componentMatchers) match(filePath string) string {
+	for _, matcher := range matchers {
+		if matcher.pathRegex.MatchString(filePath) {
+			return matcher.name
+		}
+	}
+	return defaultComponentName
+}
@@ -0,0 +1,596 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	"golang.org/x/mod/modfile"
+)
+
+// dependencyRef is a revision of the repo whose dependencies are extracted, HEAD or a tag
+type dependencyRef struct {
+	name      string
+	commitSha string
+}
+
+// Dependency is a package declared in a dependency manifest
+type Dependency struct {
+	Ecosystem string
+	Name      string
+	Version   string
+	Direct    bool
+}
+
+type dependencyManifestParser func(content []byte) ([]*Dependency, errors.Error)
+
+var requirementsTxtRegex = regexp.MustCompile(`^requirements[\w.-]*\.txt$`)
+
+// the vendored and installed packages are not declared by the repo itself
+var dependencySkippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+}
+
+func getDependencyManifestParser(filePath string) dependencyManifestParser {
+	name := path.Base(filePath)
+	switch {
+	case name == "go.mod":
+		return parseGoMod
+	case name == "package-lock.json":
+		return parsePackageLock
+	case name == "pom.xml":
+		return parsePom
+	case name == "Cargo.lock":
+		return parseCargoLock
+	case requirementsTxtRegex.MatchString(name):
+		return parseRequirementsTxt
+	case name == "bom.json", name == "sbom.json", strings.HasSuffix(name, ".cdx.json"), strings.HasSuffix(name, ".spdx.json"):
+		return parseSbom
+	}
+	return nil
+}
+
+// IsDependencyManifest returns true if the file is a dependency manifest or SBOM file supported by ParseDependencyManifest
+func IsDependencyManifest(filePath string) bool {
+	for _, dir := range strings.Split(path.Dir(filePath), "/") {
+		if dependencySkippedDirs[dir] {
+			return false
+		}
+	}
+	return getDependencyManifestParser(filePath) != nil
+}
+
+// IsDependencySkippedDir returns true if the manifests in the directory should be ignored
+func IsDependencySkippedDir(name string) bool {
+	return dependencySkippedDirs[name]
+}
+
+// ParseDependencyManifest parses the dependency manifest, duplicated packages are merged and the result is sorted
+// by name and version
+func ParseDependencyManifest(filePath string, content []byte) ([]*Dependency, errors.Error) {
+	parse := getDependencyManifestParser(filePath)
+	if parse == nil {
+		return nil, nil
+	}
+	dependencies, err := parse(content)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse dependency manifest "+filePath)
+	}
+	merged := make(map[string]*Dependency, len(dependencies))
+	result := make([]*Dependency, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		if dependency.Name == "" {
+			continue
+		}
+		key := dependency.Name + "@" + dependency.Version
+		if existing, ok := merged[key]; ok {
+			existing.Direct = existing.Direct || dependency.Direct
+			continue
+		}
+		merged[key] = dependency
+		result = append(result, dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+// storeDependencies parses the manifest of the ref and saves the dependencies, the malformed manifests are skipped
+func storeDependencies(store models.Store, logger log.Logger, repoId string, ref dependencyRef, filePath string, content []byte) errors.Error {
+	dependencies, err := ParseDependencyManifest(filePath, content)
+	if err != nil {
+		logger.Warn(err, "skip dependency manifest %s at %s", filePath, ref.name)
+		return nil
+	}
+	for _, dependency := range dependencies {
+		err = store.RepoDependencies(&code.RepoDependency{
+			DomainEntity: domainlayer.DomainEntity{Id: genRepoDependencyId(repoId, ref.name, filePath, dependency)},
+			RepoId:       repoId,
+			RefName:      ref.name,
+			CommitSha:    ref.commitSha,
+			ManifestPath: filePath,
+			Ecosystem:    dependency.Ecosystem,
+			PackageName:  dependency.Name,
+			Version:      dependency.Version,
+			Direct:       dependency.Direct,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func genRepoDependencyId(repoId, refName, filePath string, dependency *Dependency) string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.Join([]string{refName, filePath, dependency.Name, dependency.Version}, ":")))
+	return repoId + ":" + hex.EncodeToString(hash.Sum(nil))
+}
+
+func parseGoMod(content []byte) ([]*Dependency, errors.Error) {
+	file, err := modfile.ParseLax("go.mod", content, nil)
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	dependencies := make([]*Dependency, 0, len(file.Require))
+	for _, require := range file.Require {
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem: code.DEPENDENCY_ECOSYSTEM_GOLANG,
+			Name:      require.Mod.Path,
+			Version:   require.Mod.Version,
+			Direct:    !require.Indirect,
+		})
+	}
+	return dependencies, nil
+}
+
+type packageLockPackage struct {
+	Version              string            `json:"version"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+type packageLockV1Dependency struct {
+	Version      string                              `json:"version"`
+	Dependencies map[string]*packageLockV1Dependency `json:"dependencies"`
+}
+
+type packageLock struct {
+	LockfileVersion int                                 `json:"lockfileVersion"`
+	Packages        map[string]*packageLockPackage      `json:"packages"`
+	Dependencies    map[string]*packageLockV1Dependency `json:"dependencies"`
+}
+
+func parsePackageLock(content []byte) ([]*Dependency, errors.Error) {
+	lock := &packageLock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errors.Convert(err)
+	}
+	var dependencies []*Dependency
+	if lock.Packages != nil {
+		// lockfileVersion 2 and 3, the packages are keyed by their path in node_modules
+		direct := make(map[string]bool)
+		if root := lock.Packages[""]; root != nil {
+			for _, deps := range []map[string]string{root.Dependencies, root.DevDependencies, root.OptionalDependencies, root.PeerDependencies} {
+				for name := range deps {
+					direct[name] = true
+				}
+			}
+		}
+		for key, pkg := range lock.Packages {
+			i := strings.LastIndex(key, "node_modules/")
+			if i < 0 || pkg.Link {
+				continue
+			}
+			name := key[i+len("node_modules/"):]
+			dependencies = append(dependencies, &Dependency{
+				Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM,
+				Name:      name,
+				Version:   pkg.Version,
+				Direct:    key == "node_modules/"+name && direct[name],
+			})
+		}
+		return dependencies, nil
+	}
+	// lockfileVersion 1 doesn't tell the direct dependencies apart from the hoisted transitive ones
+	var walk func(deps map[string]*packageLockV1Dependency)
+	walk = func(deps map[string]*packageLockV1Dependency) {
+		for name, dep := range deps {
+			dependencies = append(dependencies, &Dependency{
+				Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM,
+				Name:      name,
+				Version:   dep.Version,
+			})
+			walk(dep.Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return dependencies, nil
+}
+
+type pomDependency struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+type pomProperty struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type pomProject struct {
+	GroupId string `xml:"groupId"`
+	Version string `xml:"version"`
+	Parent  struct {
+		GroupId string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []pomProperty `xml:",any"`
+	} `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+var pomPropertyRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parsePom parses the dependencies declared in pom.xml, they are all direct since the transitive ones are resolved
+// by maven
+func parsePom(content []byte) ([]*Dependency, errors.Error) {
+	project := &pomProject{}
+	if err := xml.Unmarshal(content, project); err != nil {
+		return nil, errors.Convert(err)
+	}
+	properties := map[string]string{
+		"project.version":        project.Version,
+		"project.groupId":        project.GroupId,
+		"project.parent.version": project.Parent.Version,
+		"project.parent.groupId": project.Parent.GroupId,
+	}
+	if project.Version == "" {
+		properties["project.version"] = project.Parent.Version
+	}
+	if project.GroupId == "" {
+		properties["project.groupId"] = project.Parent.GroupId
+	}
+	for _, property := range project.Properties.Entries {
+		properties[property.XMLName.Local] = strings.TrimSpace(property.Value)
+	}
+	resolve := func(value string) string {
+		return pomPropertyRegex.ReplaceAllStringFunc(strings.TrimSpace(value), func(s string) string {
+			if v, ok := properties[s[2:len(s)-1]]; ok {
+				return v
+			}
+			return s
+		})
+	}
+	managedVersions := make(map[string]string)
+	for _, dep := range project.DependencyManagement {
+		managedVersions[resolve(dep.GroupId)+":"+resolve(dep.ArtifactId)] = resolve(dep.Version)
+	}
+	dependencies := make([]*Dependency, 0, len(project.Dependencies))
+	for _, dep := range project.Dependencies {
+		name := resolve(dep.GroupId) + ":" + resolve(dep.ArtifactId)
+		version := resolve(dep.Version)
+		if version == "" {
+			version = managedVersions[name]
+		}
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem: code.DEPENDENCY_ECOSYSTEM_MAVEN,
+			Name:      name,
+			Version:   version,
+			Direct:    true,
+		})
+	}
+	return dependencies, nil
+}
+
+var requirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*([^;]*)`)
+var pypiNameNormalizer = regexp.MustCompile(`[-_.]+`)
+
+// parseRequirementsTxt parses the requirements of pip, the version is the pinned one if `==` is used,
+// otherwise it is the version specifier
+func parseRequirementsTxt(content []byte) ([]*Dependency, errors.Error) {
+	var dependencies []*Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		// skip options like `-r other.txt` and requirements of urls or local paths
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") || strings.Contains(line, "/") {
+			continue
+		}
+		matches := requirementRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		version := strings.TrimSpace(matches[2])
+		if strings.HasPrefix(version, "==") && !strings.Contains(version, ",") {
+			version = strings.TrimSpace(strings.TrimLeft(version, "="))
+		}
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem: code.DEPENDENCY_ECOSYSTEM_PYPI,
+			// https://peps.python.org/pep-0503/#normalized-names
+			Name:    strings.ToLower(pypiNameNormalizer.ReplaceAllString(matches[1], "-")),
+			Version: version,
+			Direct:  true,
+		})
+	}
+	return dependencies, errors.Convert(scanner.Err())
+}
+
+type cargoPackage struct {
+	name         string
+	version      string
+	source       string
+	dependencies []string
+}
+
+// parseCargoLock parses the `[[package]]` tables of Cargo.lock, the packages without source are the members of
+// the workspace, and their dependencies are the direct ones
+func parseCargoLock(content []byte) ([]*Dependency, errors.Error) {
+	var packages []*cargoPackage
+	var current *cargoPackage
+	inDependencies := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") && !inDependencies {
+			current = nil
+			if line == "[[package]]" {
+				current = &cargoPackage{}
+				packages = append(packages, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if inDependencies {
+			if strings.HasPrefix(line, "]") {
+				inDependencies = false
+				continue
+			}
+			current.dependencies = append(current.dependencies, strings.Trim(line, "\", "))
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		switch key {
+		case "name":
+			current.name = strings.Trim(value, "\"")
+		case "version":
+			current.version = strings.Trim(value, "\"")
+		case "source":
+			current.source = strings.Trim(value, "\"")
+		case "dependencies":
+			// either `dependencies = [` followed by one dependency per line, or all in one line
+			value = strings.TrimPrefix(value, "[")
+			if strings.HasSuffix(value, "]") {
+				for _, dep := range strings.Split(strings.TrimSuffix(value, "]"), ",") {
+					if dep = strings.Trim(strings.TrimSpace(dep), "\""); dep != "" {
+						current.dependencies = append(current.dependencies, dep)
+					}
+				}
+			} else {
+				inDependencies = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Convert(err)
+	}
+	// a dependency is referred by `name`, or `name version` if there are multiple versions of the package
+	direct := make(map[string]bool)
+	for _, pkg := range packages {
+		if pkg.source != "" {
+			continue
+		}
+		for _, dep := range pkg.dependencies {
+			fields := strings.Fields(dep)
+			direct[fields[0]] = true
+			if len(fields) > 1 {
+				direct[fields[0]+" "+fields[1]] = true
+			}
+		}
+	}
+	var versions = make(map[string]int)
+	for _, pkg := range packages {
+		versions[pkg.name]++
+	}
+	var dependencies []*Dependency
+	for _, pkg := range packages {
+		if pkg.source == "" {
+			continue
+		}
+		isDirect := direct[pkg.name+" "+pkg.version] || (versions[pkg.name] == 1 && direct[pkg.name])
+		dependencies = append(dependencies, &Dependency{
+			Ecosystem: code.DEPENDENCY_ECOSYSTEM_CARGO,
+			Name:      pkg.name,
+			Version:   pkg.version,
+			Direct:    isDirect,
+		})
+	}
+	return dependencies, nil
+}
+
+type cycloneDxComponent struct {
+	BomRef     string                `json:"bom-ref"`
+	Group      string                `json:"group"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	Purl       string                `json:"purl"`
+	Components []*cycloneDxComponent `json:"components"`
+}
+
+type spdxPackage struct {
+	SpdxId       string `json:"SPDXID"`
+	Name         string `json:"name"`
+	VersionInfo  string `json:"versionInfo"`
+	ExternalRefs []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+type sbomDocument struct {
+	// CycloneDX
+	BomFormat string `json:"bomFormat"`
+	Metadata  struct {
+		Component *cycloneDxComponent `json:"component"`
+	} `json:"metadata"`
+	Components   []*cycloneDxComponent `json:"components"`
+	Dependencies []struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	} `json:"dependencies"`
+	// SPDX
+	SpdxVersion       string         `json:"spdxVersion"`
+	SpdxId            string         `json:"SPDXID"`
+	DocumentDescribes []string       `json:"documentDescribes"`
+	Packages          []*spdxPackage `json:"packages"`
+	Relationships     []struct {
+		SpdxElementId      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSpdxElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+}
+
+// parseSbom parses the SBOM in CycloneDX or SPDX json format, the direct dependencies are the ones the described
+// component depends on
+func parseSbom(content []byte) ([]*Dependency, errors.Error) {
+	doc := &sbomDocument{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, errors.Convert(err)
+	}
+	var dependencies []*Dependency
+	switch {
+	case doc.BomFormat == "CycloneDX":
+		direct := make(map[string]bool)
+		if root := doc.Metadata.Component; root != nil && root.BomRef != "" {
+			for _, dep := range doc.Dependencies {
+				if dep.Ref != root.BomRef {
+					continue
+				}
+				for _, ref := range dep.DependsOn {
+					direct[ref] = true
+				}
+			}
+		}
+		var walk func(components []*cycloneDxComponent)
+		walk = func(components []*cycloneDxComponent) {
+			for _, component := range components {
+				ecosystem := purlType(component.Purl)
+				name := component.Name
+				if component.Group != "" {
+					separator := "/"
+					if ecosystem == code.DEPENDENCY_ECOSYSTEM_MAVEN {
+						separator = ":"
+					}
+					name = component.Group + separator + name
+				}
+				dependencies = append(dependencies, &Dependency{
+					Ecosystem: ecosystem,
+					Name:      name,
+					Version:   component.Version,
+					Direct:    component.BomRef != "" && direct[component.BomRef],
+				})
+				walk(component.Components)
+			}
+		}
+		walk(doc.Components)
+	case doc.SpdxVersion != "":
+		roots := make(map[string]bool)
+		for _, id := range doc.DocumentDescribes {
+			roots[id] = true
+		}
+		for _, r := range doc.Relationships {
+			if r.RelationshipType == "DESCRIBES" && r.SpdxElementId == doc.SpdxId {
+				roots[r.RelatedSpdxElement] = true
+			}
+		}
+		direct := make(map[string]bool)
+		for _, r := range doc.Relationships {
+			if r.RelationshipType == "DEPENDS_ON" && roots[r.SpdxElementId] {
+				direct[r.RelatedSpdxElement] = true
+			}
+			if r.RelationshipType == "DEPENDENCY_OF" && roots[r.RelatedSpdxElement] {
+				direct[r.SpdxElementId] = true
+			}
+		}
+		for _, pkg := range doc.Packages {
+			if roots[pkg.SpdxId] {
+				continue
+			}
+			ecosystem := ""
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					ecosystem = purlType(ref.ReferenceLocator)
+				}
+			}
+			dependencies = append(dependencies, &Dependency{
+				Ecosystem: ecosystem,
+				Name:      pkg.Name,
+				Version:   pkg.VersionInfo,
+				Direct:    direct[pkg.SpdxId],
+			})
+		}
+	}
+	return dependencies, nil
+}
+
+// purlType returns the type of the package url like `pkg:npm/lodash@4.17.21`
+func purlType(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	t, _, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+	return strings.ToLower(t)
+}
@@ -0,0 +1,313 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDependencyManifest(t *testing.T) {
+	assert.True(t, IsDependencyManifest("go.mod"))
+	assert.True(t, IsDependencyManifest("backend/go.mod"))
+	assert.True(t, IsDependencyManifest("config-ui/package-lock.json"))
+	assert.True(t, IsDependencyManifest("requirements-dev.txt"))
+	assert.True(t, IsDependencyManifest("sbom/app.spdx.json"))
+	assert.False(t, IsDependencyManifest("package.json"))
+	assert.False(t, IsDependencyManifest("web/node_modules/lodash/package-lock.json"))
+	assert.False(t, IsDependencyManifest("vendor/github.com/a/b/go.mod"))
+}
+
+func TestParseGoMod(t *testing.T) {
+	deps, err := ParseDependencyManifest("go.mod", []byte(`
+module github.com/apache/incubator-devlake
+
+go 1.20
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	golang.org/x/sync v0.6.0 // indirect
+)
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_GOLANG, Name: "github.com/gin-gonic/gin", Version: "v1.9.1", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_GOLANG, Name: "golang.org/x/sync", Version: "v0.6.0", Direct: false},
+	}, deps)
+}
+
+func TestParsePackageLock(t *testing.T) {
+	deps, err := ParseDependencyManifest("package-lock.json", []byte(`{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "dependencies": {"react": "^18.2.0"}, "devDependencies": {"jest": "^29.0.0"}},
+    "node_modules/react": {"version": "18.2.0"},
+    "node_modules/jest": {"version": "29.7.0", "dev": true},
+    "node_modules/loose-envify": {"version": "1.4.0"},
+    "node_modules/jest/node_modules/react": {"version": "17.0.2"},
+    "node_modules/shared": {"resolved": "packages/shared", "link": true}
+  }
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM, Name: "jest", Version: "29.7.0", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM, Name: "loose-envify", Version: "1.4.0", Direct: false},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM, Name: "react", Version: "17.0.2", Direct: false},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM, Name: "react", Version: "18.2.0", Direct: true},
+	}, deps)
+
+	deps, err = ParseDependencyManifest("package-lock.json", []byte(`{
+  "lockfileVersion": 1,
+  "dependencies": {"a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}}
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(deps))
+	assert.Equal(t, "b", deps[1].Name)
+}
+
+func TestParsePom(t *testing.T) {
+	deps, err := ParseDependencyManifest("service/pom.xml", []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>org.example</groupId>
+  <artifactId>service</artifactId>
+  <version>1.2.0</version>
+  <properties>
+    <spring.version>5.3.31</spring.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>junit</groupId>
+        <artifactId>junit</artifactId>
+        <version>4.13.2</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.springframework</groupId>
+      <artifactId>spring-core</artifactId>
+      <version>${spring.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>common</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+    <dependency>
+      <groupId>junit</groupId>
+      <artifactId>junit</artifactId>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_MAVEN, Name: "junit:junit", Version: "4.13.2", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_MAVEN, Name: "org.example:common", Version: "1.2.0", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_MAVEN, Name: "org.springframework:spring-core", Version: "5.3.31", Direct: true},
+	}, deps)
+}
+
+func TestParseRequirementsTxt(t *testing.T) {
+	deps, err := ParseDependencyManifest("requirements.txt", []byte(`
+# web
+Django==4.2.7
+requests[security] >= 2.31, <3 ; python_version >= "3.8"
+zope.interface
+-r requirements-dev.txt
+git+https://github.com/org/repo.git#egg=repo
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_PYPI, Name: "django", Version: "4.2.7", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_PYPI, Name: "requests", Version: ">= 2.31, <3", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_PYPI, Name: "zope-interface", Version: "", Direct: true},
+	}, deps)
+}
+
+func TestParseCargoLock(t *testing.T) {
+	deps, err := ParseDependencyManifest("Cargo.lock", []byte(`
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde 1.0.193",
+ "tokio",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.193"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "0.9.15"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "tokio"
+version = "1.35.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+dependencies = ["pin-project-lite"]
+
+[[package]]
+name = "pin-project-lite"
+version = "0.2.13"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_CARGO, Name: "pin-project-lite", Version: "0.2.13", Direct: false},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_CARGO, Name: "serde", Version: "0.9.15", Direct: false},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_CARGO, Name: "serde", Version: "1.0.193", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_CARGO, Name: "tokio", Version: "1.35.0", Direct: true},
+	}, deps)
+}
+
+func TestParseSbom(t *testing.T) {
+	deps, err := ParseDependencyManifest("bom.json", []byte(`{
+  "bomFormat": "CycloneDX",
+  "metadata": {"component": {"bom-ref": "app", "name": "app"}},
+  "components": [
+    {"bom-ref": "a", "group": "@babel", "name": "core", "version": "7.23.0", "purl": "pkg:npm/%40babel/core@7.23.0"},
+    {"bom-ref": "b", "group": "com.google.guava", "name": "guava", "version": "32.1.3-jre", "purl": "pkg:maven/com.google.guava/guava@32.1.3-jre"}
+  ],
+  "dependencies": [{"ref": "app", "dependsOn": ["a"]}, {"ref": "a", "dependsOn": ["b"]}]
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_NPM, Name: "@babel/core", Version: "7.23.0", Direct: true},
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_MAVEN, Name: "com.google.guava:guava", Version: "32.1.3-jre", Direct: false},
+	}, deps)
+
+	deps, err = ParseDependencyManifest("app.spdx.json", []byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "versionInfo": "1.0.0"},
+    {"SPDXID": "SPDXRef-flask", "name": "flask", "versionInfo": "3.0.0", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:pypi/flask@3.0.0"}]},
+    {"SPDXID": "SPDXRef-jinja2", "name": "jinja2", "versionInfo": "3.1.2"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": "SPDXRef-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-flask"},
+    {"spdxElementId": "SPDXRef-flask", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-jinja2"}
+  ]
+}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []*Dependency{
+		{Ecosystem: code.DEPENDENCY_ECOSYSTEM_PYPI, Name: "flask", Version: "3.0.0", Direct: true},
+		{Ecosystem: "", Name: "jinja2", Version: "3.1.2", Direct: false},
+	}, deps)
+
+	_, err = ParseDependencyManifest("sbom.json", []byte("not json"))
+	assert.NotNil(t, err)
+}
+
+// dependencyStore keeps the repo dependencies in memory, the other methods of the Store are not used
+type dependencyStore struct {
+	models.Store
+	dependencies map[string]*code.RepoDependency
+}
+
+func (s *dependencyStore) RepoDependencies(dependency *code.RepoDependency) errors.Error {
+	s.dependencies[dependency.Id] = dependency
+	return nil
+}
+
+func (s *dependencyStore) DeleteRepoDependencies(repoId string, refName string) errors.Error {
+	for id, dependency := range s.dependencies {
+		if dependency.RepoId == repoId && dependency.RefName == refName {
+			delete(s.dependencies, id)
+		}
+	}
+	return nil
+}
+
+func TestStoreDependenciesOfRefReplacesPreviousRun(t *testing.T) {
+	storage := memory.NewStorage()
+	repo, err := gogit.Init(storage, nil)
+	assert.Nil(t, err)
+	newCommit := func(goMod string) string {
+		blob := storage.NewEncodedObject()
+		blob.SetType(plumbing.BlobObject)
+		writer, err := blob.Writer()
+		assert.Nil(t, err)
+		_, err = writer.Write([]byte(goMod))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+		blobHash, err := storage.SetEncodedObject(blob)
+		assert.Nil(t, err)
+		tree := &object.Tree{Entries: []object.TreeEntry{{Name: "go.mod", Mode: filemode.Regular, Hash: blobHash}}}
+		treeObj := storage.NewEncodedObject()
+		assert.Nil(t, tree.Encode(treeObj))
+		treeHash, err := storage.SetEncodedObject(treeObj)
+		assert.Nil(t, err)
+		commit := &object.Commit{Message: "update go.mod", TreeHash: treeHash}
+		commitObj := storage.NewEncodedObject()
+		assert.Nil(t, commit.Encode(commitObj))
+		commitHash, err := storage.SetEncodedObject(commitObj)
+		assert.Nil(t, err)
+		return commitHash.String()
+	}
+	store := &dependencyStore{dependencies: make(map[string]*code.RepoDependency)}
+	collector := &GogitRepoCollector{id: "repo1", logger: unithelper.DummyLogger(), store: store, repo: repo}
+	// the dependencies of the other refs are kept
+	store.dependencies["other"] = &code.RepoDependency{RepoId: "repo1", RefName: "refs/tags/v1.0.0", PackageName: "github.com/old/pkg"}
+
+	first := newCommit(`
+module example.com/app
+
+require (
+	github.com/gin-gonic/gin v1.9.1
+	golang.org/x/sync v0.6.0
+)
+`)
+	assert.Nil(t, collector.storeDependenciesOfRef(dependencyRef{name: "HEAD", commitSha: first}))
+	assert.Len(t, store.dependencies, 3)
+
+	second := newCommit(`
+module example.com/app
+
+require github.com/gin-gonic/gin v1.10.0
+`)
+	assert.Nil(t, collector.storeDependenciesOfRef(dependencyRef{name: "HEAD", commitSha: second}))
+	var packages []string
+	for _, dependency := range store.dependencies {
+		if dependency.RefName == "HEAD" {
+			packages = append(packages, dependency.PackageName+"@"+dependency.Version)
+			assert.Equal(t, second, dependency.CommitSha)
+		}
+	}
+	assert.Equal(t, []string{"github.com/gin-gonic/gin@v1.10.0"}, packages)
+	assert.Contains(t, store.dependencies, "other")
+}
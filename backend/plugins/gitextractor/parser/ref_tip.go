@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+)
+
+// LoadProcessedRefTips returns the ref tips processed by the previous run, nil is returned if the commits have to
+// be collected from scratch since the options affecting what are collected for each commit have been changed
+func LoadProcessedRefTips(db dal.Dal, options *GitExtractorOptions) ([]*models.GitRefTip, errors.Error) {
+	var tips []*models.GitRefTip
+	err := db.All(&tips, dal.Where("repo_id = ?", options.RepoId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the processed ref tips")
+	}
+	for _, tip := range tips {
+		if tip.SkipCommitStat != *options.SkipCommitStat || tip.SkipCommitFiles != *options.SkipCommitFiles {
+			return nil, nil
+		}
+	}
+	return tips, nil
+}
+
+// refTipOptionsHash hashes the file category patterns and the components, the commit files classified by other
+// patterns or components have to be collected again
+func refTipOptionsHash(options *GitExtractorOptions, components componentMatchers) string {
+	hash := sha256.New()
+	categories := make([]string, 0, len(options.FileCategoryPatterns))
+	for category := range options.FileCategoryPatterns {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		hash.Write([]byte("category:" + category + "=" + options.FileCategoryPatterns[category] + "\n"))
+	}
+	// the components are in the order they are matched
+	for _, component := range components {
+		hash.Write([]byte("component:" + component.name + "=" + component.pathRegex.String() + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// validProcessedRefTips returns the processed ref tips if their commits were collected with the same file category
+// patterns and components, otherwise nil is returned and the store leaves the incremental mode so that the commits
+// are collected from scratch
+func validProcessedRefTips(store models.Store, logger log.Logger, tips []*models.GitRefTip, optionsHash string) []*models.GitRefTip {
+	for _, tip := range tips {
+		if tip.OptionsHash != optionsHash {
+			logger.Info("collect the commits from scratch since the file category patterns or the components have been changed")
+			store.SetIncrementalMode(false)
+			return nil
+		}
+	}
+	return tips
+}
+
+// storeRefTips saves the tips the commits were collected from, so the next run could skip the commits reachable
+// from them, the processed tips of the refs which no longer exist are deleted
+func storeRefTips(store models.Store, options *GitExtractorOptions, optionsHash string, tips map[string]string, processedTips []*models.GitRefTip) errors.Error {
+	for _, tip := range processedTips {
+		if _, ok := tips[tip.RefName]; ok {
+			continue
+		}
+		if err := store.DeleteRefTip(options.RepoId, tip.RefName); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(tips))
+	for name := range tips {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		err := store.RefTips(&models.GitRefTip{
+			RepoId:          options.RepoId,
+			RefName:         name,
+			CommitSha:       tips[name],
+			SkipCommitStat:  *options.SkipCommitStat,
+			SkipCommitFiles: *options.SkipCommitFiles,
+			OptionsHash:     optionsHash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
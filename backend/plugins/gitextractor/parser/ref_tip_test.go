@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// incrementalStore records the incremental mode, the other methods of the Store are not used
+type incrementalStore struct {
+	models.Store
+	incremental bool
+}
+
+func (s *incrementalStore) SetIncrementalMode(incremental bool) {
+	s.incremental = incremental
+}
+
+func TestValidProcessedRefTips(t *testing.T) {
+	options := &GitExtractorOptions{FileCategoryPatterns: map[string]string{"test": `_test\.go$`, "docs": `\.md$`}}
+	components := componentMatchers{{name: "api", pathRegex: regexp.MustCompile("^api/")}}
+	hash := refTipOptionsHash(options, components)
+	assert.Equal(t, hash, refTipOptionsHash(&GitExtractorOptions{FileCategoryPatterns: map[string]string{"docs": `\.md$`, "test": `_test\.go$`}}, components))
+
+	tips := []*models.GitRefTip{{RefName: "refs/heads/main", OptionsHash: hash}}
+	store := &incrementalStore{incremental: true}
+	assert.Equal(t, tips, validProcessedRefTips(store, unithelper.DummyLogger(), tips, hash))
+	assert.True(t, store.incremental)
+
+	// the tips are discarded once the components change
+	changed := refTipOptionsHash(options, componentMatchers{{name: "api", pathRegex: regexp.MustCompile("^services/api/")}})
+	assert.NotEqual(t, hash, changed)
+	assert.Nil(t, validProcessedRefTips(store, unithelper.DummyLogger(), tips, changed))
+	assert.False(t, store.incremental)
+
+	// as well as the file category patterns
+	assert.NotEqual(t, hash, refTipOptionsHash(&GitExtractorOptions{}, components))
+}
+
+// refTipStore records the saved and the deleted ref tips, the other methods of the Store are not used
+type refTipStore struct {
+	models.Store
+	saved   []string
+	deleted []string
+}
+
+func (s *refTipStore) RefTips(tip *models.GitRefTip) errors.Error {
+	s.saved = append(s.saved, tip.RefName)
+	return nil
+}
+
+func (s *refTipStore) DeleteRefTip(_ string, refName string) errors.Error {
+	s.deleted = append(s.deleted, refName)
+	return nil
+}
+
+func TestStoreRefTipsPrunesDeletedRefs(t *testing.T) {
+	skip := false
+	options := &GitExtractorOptions{SkipCommitStat: &skip, SkipCommitFiles: &skip}
+	processed := []*models.GitRefTip{
+		{RefName: "refs/heads/main", CommitSha: "1111111111111111111111111111111111111111"},
+		{RefName: "refs/heads/feature", CommitSha: "2222222222222222222222222222222222222222"},
+	}
+	tips := map[string]string{
+		"refs/heads/main":    "3333333333333333333333333333333333333333",
+		"refs/heads/release": "4444444444444444444444444444444444444444",
+	}
+	store := &refTipStore{}
+	assert.Nil(t, storeRefTips(store, options, "hash", tips, processed))
+	assert.Equal(t, []string{"refs/heads/main", "refs/heads/release"}, store.saved)
+	assert.Equal(t, []string{"refs/heads/feature"}, store.deleted)
+}
@@ -42,4 +42,10 @@ type RepoCollector interface {
 	CollectBranches(subtaskCtx plugin.SubTaskContext) error
 	CollectCommits(subtaskCtx plugin.SubTaskContext) error
 	CollectDiffLine(subtaskCtx plugin.SubTaskContext) error
+
+	// CollectDependencies extracts the dependencies declared in the manifests at HEAD and each tag
+	CollectDependencies(subtaskCtx plugin.SubTaskContext) error
+
+	// ReadFileAtHead returns the content of the file at HEAD, nil if the file doesn't exist
+	ReadFileAtHead(ctx context.Context, path string) ([]byte, error)
 }
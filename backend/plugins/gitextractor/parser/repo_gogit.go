@@ -22,9 +22,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"regexp"
+	"io"
 
-	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
@@ -263,43 +262,139 @@ func (r *GogitRepoCollector) CollectBranches(subtaskCtx plugin.SubTaskContext) e
 	return nil
 }
 
-func (r *GogitRepoCollector) getComponentMap(subtaskCtx plugin.SubTaskContext) (map[string]*regexp.Regexp, error) {
-	db := subtaskCtx.GetDal()
-	components := make([]code.Component, 0)
-	err := db.All(&components, dal.From(components), dal.Where("repo_id= ?", r.id))
+// CollectDependencies extracts the dependencies declared in the manifests at HEAD and each tag
+func (r *GogitRepoCollector) CollectDependencies(subtaskCtx plugin.SubTaskContext) error {
+	var refs []dependencyRef
+	head, err := r.repo.Head()
+	if err != nil && err != plumbing.ErrReferenceNotFound {
+		return err
+	}
+	if head != nil {
+		refs = append(refs, dependencyRef{name: "HEAD", commitSha: head.Hash().String()})
+	}
+	tagIter, err := r.repo.Tags()
+	if err != nil {
+		return err
+	}
+	err = tagIter.ForEach(func(ref *plumbing.Reference) error {
+		// annotated tags are resolved to the commits
+		h, err := r.repo.ResolveRevision(plumbing.Revision(ref.Name()))
+		if err != nil {
+			r.logger.Warn(err, "skip tag %s which doesn't point to a commit", ref.Name())
+			return nil
+		}
+		refs = append(refs, dependencyRef{name: ref.Name().String(), commitSha: h.String()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	subtaskCtx.SetProgress(0, len(refs))
+	for _, ref := range refs {
+		select {
+		case <-subtaskCtx.GetContext().Done():
+			return subtaskCtx.GetContext().Err()
+		default:
+		}
+		err = r.storeDependenciesOfRef(ref)
+		if err != nil {
+			return err
+		}
+		subtaskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+// storeDependenciesOfRef replaces the dependencies of the ref with the ones declared in its manifests
+func (r *GogitRepoCollector) storeDependenciesOfRef(ref dependencyRef) error {
+	if err := r.store.DeleteRepoDependencies(r.id, ref.name); err != nil {
+		return err
+	}
+	commit, err := r.repo.CommitObject(plumbing.NewHash(ref.commitSha))
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !entry.Mode.IsFile() || !IsDependencyManifest(name) {
+			continue
+		}
+		file, err := tree.TreeEntryFile(&entry)
+		if err != nil {
+			return err
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return err
+		}
+		err = storeDependencies(r.store, r.logger, r.id, ref, name, []byte(content))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFileAtHead returns the content of the file at HEAD, nil if the file doesn't exist
+func (r *GogitRepoCollector) ReadFileAtHead(ctx context.Context, path string) ([]byte, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	commit, err := r.repo.CommitObject(head.Hash())
 	if err != nil {
 		return nil, err
 	}
-	componentMap := make(map[string]*regexp.Regexp)
-	for _, component := range components {
-		componentMap[component.Name] = regexp.MustCompile(component.PathRegex)
+	file, err := commit.File(path)
+	if err != nil {
+		if err == object.ErrFileNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
 	}
-	return componentMap, nil
+	return []byte(contents), nil
 }
 
 // CollectCommits Collect data from each commit, we can also get the diff line
 func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (err error) {
 	taskOpts := subtaskCtx.GetData().(*GitExtractorTaskData).Options
+	classifier := subtaskCtx.GetData().(*GitExtractorTaskData).FileClassifier
 	// check it first
-	componentMap, err := r.getComponentMap(subtaskCtx)
-	if err != nil {
-		return err
+	components, e := loadComponentMatchers(subtaskCtx.GetDal(), r.id)
+	if e != nil {
+		return e
 	}
 
 	repo := r.repo
 	store := r.store
 
-	commitsObjectsIter, err := repo.CommitObjects()
+	tips, err := r.refTips()
 	if err != nil {
 		return err
 	}
+	optionsHash := refTipOptionsHash(taskOpts, components)
+	processedTips := validProcessedRefTips(store, r.logger, subtaskCtx.GetData().(*GitExtractorTaskData).ProcessedRefTips, optionsHash)
 
-	if err := commitsObjectsIter.ForEach(func(commit *object.Commit) error {
-		select {
-		case <-subtaskCtx.GetContext().Done():
-			return subtaskCtx.GetContext().Err()
-		default:
-		}
+	collectCommit := func(commit *object.Commit) error {
 		commitSha := commit.Hash.String()
 		codeCommit := &code.Commit{
 			Sha:            commitSha,
@@ -349,16 +444,83 @@ func (r *GogitRepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) (e
 			return err
 		}
 		if !*taskOpts.SkipCommitFiles {
-			if err := r.storeDiffCommitFilesComparedToParent(subtaskCtx, componentMap, commit); err != nil {
+			if err := r.storeDiffCommitFilesComparedToParent(subtaskCtx, components, classifier, commit); err != nil {
 				return err
 			}
 		}
 		subtaskCtx.IncProgress(1)
 		return nil
-	}); err != nil {
+	}
+
+	if len(processedTips) > 0 {
+		// only the commits reachable from the current tips but not from the processed ones are new
+		walk := newGogitRevWalk(repo)
+		for _, tip := range tips {
+			if err := walk.Push(plumbing.NewHash(tip)); err != nil {
+				return err
+			}
+		}
+		for _, tip := range processedTips {
+			if err := walk.Hide(plumbing.NewHash(tip.CommitSha)); err != nil {
+				return err
+			}
+		}
+		err = walk.Iterate(subtaskCtx.GetContext(), collectCommit)
+	} else {
+		var commitsObjectsIter object.CommitIter
+		commitsObjectsIter, err = repo.CommitObjects()
+		if err != nil {
+			return err
+		}
+		err = commitsObjectsIter.ForEach(func(commit *object.Commit) error {
+			select {
+			case <-subtaskCtx.GetContext().Done():
+				return subtaskCtx.GetContext().Err()
+			default:
+			}
+			return collectCommit(commit)
+		})
+	}
+	if err != nil {
 		return err
 	}
-	return
+	if e := storeRefTips(store, taskOpts, optionsHash, tips, processedTips); e != nil {
+		return e
+	}
+	return nil
+}
+
+// refTips returns the commit sha each ref points to, keyed by the ref name
+func (r *GogitRepoCollector) refTips() (map[string]string, error) {
+	refIter, err := r.repo.References()
+	if err != nil {
+		return nil, err
+	}
+	tips := make(map[string]string)
+	err = refIter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		hash := ref.Hash()
+		if _, err := r.repo.CommitObject(hash); err != nil {
+			// annotated tags have to be peeled to the commits, refs to other objects are ignored
+			tag, err := r.repo.TagObject(hash)
+			if err != nil {
+				return nil
+			}
+			commit, err := tag.Commit()
+			if err != nil {
+				return nil
+			}
+			hash = commit.Hash
+		}
+		tips[ref.Name().String()] = hash.String()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tips, nil
 }
 
 func (r *GogitRepoCollector) storeParentCommits(commitSha string, commit *object.Commit) error {
@@ -409,7 +571,7 @@ func (r *GogitRepoCollector) getCurrentAndParentTree(ctx context.Context, commit
 	return commitTree, firstParentTree, nil
 }
 
-func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plugin.SubTaskContext, componentMap map[string]*regexp.Regexp, commit *object.Commit) (err error) {
+func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plugin.SubTaskContext, components componentMatchers, classifier *FileClassifier, commit *object.Commit) (err error) {
 	commitTree, firstParentTree, err := r.getCurrentAndParentTree(subtaskCtx.GetContext(), commit)
 	if err != nil {
 		return err
@@ -428,7 +590,8 @@ func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plu
 		commitFile.Id = genCommitFileId(commitFile.CommitSha, fileName)
 		commitFile.Deletions = p.Deletion
 		commitFile.Additions = p.Addition
-		if err := r.storeCommitFileComponents(subtaskCtx, componentMap, commitFile.Id, commitFile.FilePath); err != nil {
+		commitFile.Language, commitFile.Category = classifier.Classify(fileName, readFileHead(commitTree, fileName))
+		if err := r.storeCommitFileComponents(subtaskCtx, components, commitFile.Id, commitFile.FilePath); err != nil {
 			return err
 		}
 		err = r.store.CommitFiles(commitFile)
@@ -440,6 +603,24 @@ func (r *GogitRepoCollector) storeDiffCommitFilesComparedToParent(subtaskCtx plu
 	return nil
 }
 
+// readFileHead returns a function to read the beginning of the file in the tree, which is enough to detect the shebang
+func readFileHead(tree *object.Tree, filePath string) func() []byte {
+	return func() []byte {
+		file, err := tree.File(filePath)
+		if err != nil {
+			return nil
+		}
+		reader, err := file.Reader()
+		if err != nil {
+			return nil
+		}
+		defer reader.Close()
+		head := make([]byte, 256)
+		n, _ := io.ReadFull(reader, head)
+		return head[:n]
+	}
+}
+
 // With some long path,the varchar(255) was not enough both ID and file_path
 // So we use the hash to compress the path in ID and add length of file_path.
 // Use commitSha and the sha256 of FilePath to create id
@@ -449,19 +630,13 @@ func genCommitFileId(commitSha, filePath string) string {
 	return commitSha + ":" + hex.EncodeToString(shaFilePath.Sum(nil))
 }
 
-func (r *GogitRepoCollector) storeCommitFileComponents(subtaskCtx plugin.SubTaskContext, componentMap map[string]*regexp.Regexp, commitFileId string, commitFilePath string) error {
+func (r *GogitRepoCollector) storeCommitFileComponents(subtaskCtx plugin.SubTaskContext, components componentMatchers, commitFileId string, commitFilePath string) error {
 	if commitFileId == "" || commitFilePath == "" {
 		return errors.Default.New("commit id r commit file path is empty")
 	}
 	commitFileComponent := &code.CommitFileComponent{
 		CommitFileId:  commitFileId,
-		ComponentName: "Default",
-	}
-	for component, reg := range componentMap {
-		if reg.MatchString(commitFilePath) {
-			commitFileComponent.ComponentName = component
-			break
-		}
+		ComponentName: components.match(commitFilePath),
 	}
 	return r.store.CommitFileComponents(commitFileComponent)
 }
@@ -469,6 +644,7 @@ func (r *GogitRepoCollector) storeCommitFileComponents(subtaskCtx plugin.SubTask
 // storeRepoSnapshot depends on commit list's order.
 func (r *GogitRepoCollector) storeRepoSnapshot(subtaskCtx plugin.SubTaskContext, commitList []*object.Commit) error {
 	ctx := subtaskCtx.GetContext()
+	classifier := subtaskCtx.GetData().(*GitExtractorTaskData).FileClassifier
 	snapshot := make(map[string][]string) // {"filePathAndName": ["line1 commit sha", "line2 commit sha"]}
 	for _, commit := range commitList {
 		commitTree, firstParentTree, err := r.getCurrentAndParentTree(ctx, commit)
@@ -484,6 +660,8 @@ func (r *GogitRepoCollector) storeRepoSnapshot(subtaskCtx plugin.SubTaskContext,
 			if _, ok := snapshot[fileName]; !ok {
 				snapshot[fileName] = []string{}
 			}
+			// the classification is cached by the classifier for storing the snapshot later
+			classifier.Classify(fileName, readFileHead(commitTree, fileName))
 			blameResults, err := gogit.Blame(commit, fileName)
 			if err != nil {
 				return err
@@ -497,6 +675,7 @@ func (r *GogitRepoCollector) storeRepoSnapshot(subtaskCtx plugin.SubTaskContext,
 	}
 	// store snapshots
 	for fileName, lineBlames := range snapshot {
+		language, category := classifier.Classify(fileName, nil)
 		for idx, lineBlameHash := range lineBlames {
 			lineNo := idx + 1
 			repoSnapshot := &code.RepoSnapshot{
@@ -504,6 +683,8 @@ func (r *GogitRepoCollector) storeRepoSnapshot(subtaskCtx plugin.SubTaskContext,
 				CommitSha: lineBlameHash,
 				FilePath:  fileName,
 				LineNo:    lineNo,
+				Language:  language,
+				Category:  category,
 			}
 			if err := r.store.RepoSnapshot(repoSnapshot); err != nil {
 				r.logger.Error(err, "store RepoSnapshot error")
@@ -22,7 +22,6 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"regexp"
 	"sort"
 	"strconv"
 
@@ -245,40 +244,146 @@ func (r *Libgit2RepoCollector) CollectBranches(subtaskCtx plugin.SubTaskContext)
 	}))
 }
 
-// CollectCommits Collect data from each commit, we can also get the diff line
-func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) error {
-	taskOpts := subtaskCtx.GetData().(*GitExtractorTaskData).Options
-	opts, err := getDiffOpts()
-	if err != nil {
-		return err
-	}
-	db := subtaskCtx.GetDal()
-	components := make([]code.Component, 0)
-	err = db.All(&components, dal.From(components), dal.Where("repo_id= ?", r.id))
-	if err != nil {
+// CollectDependencies extracts the dependencies declared in the manifests at HEAD and each tag
+func (r *Libgit2RepoCollector) CollectDependencies(subtaskCtx plugin.SubTaskContext) error {
+	var refs []dependencyRef
+	head, err := r.repo.Head()
+	if err != nil && !git.IsErrorCode(err, git.ErrorCodeUnbornBranch) && !git.IsErrorCode(err, git.ErrorCodeNotFound) {
 		return err
 	}
-	componentMap := make(map[string]*regexp.Regexp)
-	for _, component := range components {
-		componentMap[component.Name] = regexp.MustCompile(component.PathRegex)
+	if head != nil {
+		refs = append(refs, dependencyRef{name: "HEAD", commitSha: head.Target().String()})
+		head.Free()
 	}
-	odb, err := errors.Convert01(r.repo.Odb())
+	err = r.repo.Tags.Foreach(func(name string, id *git.Oid) error {
+		commitSha := id.String()
+		tag, err1 := r.repo.LookupTag(id)
+		if err1 != nil && err1.Error() != TypeNotMatchError {
+			return err1
+		}
+		if tag != nil {
+			commitSha = tag.TargetId().String()
+		}
+		refs = append(refs, dependencyRef{name: name, commitSha: commitSha})
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	return errors.Convert(odb.ForEach(func(id *git.Oid) error {
+	subtaskCtx.SetProgress(0, len(refs))
+	for _, ref := range refs {
 		select {
 		case <-subtaskCtx.GetContext().Done():
 			return subtaskCtx.GetContext().Err()
 		default:
 		}
-		commit, err1 := r.repo.LookupCommit(id)
-		if err1 != nil && err1.Error() != TypeNotMatchError {
-			return errors.Convert(err1)
+		err = r.storeDependenciesOfRef(ref)
+		if err != nil {
+			return err
+		}
+		subtaskCtx.IncProgress(1)
+	}
+	return nil
+}
+
+func (r *Libgit2RepoCollector) storeDependenciesOfRef(ref dependencyRef) error {
+	if err := r.store.DeleteRepoDependencies(r.id, ref.name); err != nil {
+		return err
+	}
+	id, err := git.NewOid(ref.commitSha)
+	if err != nil {
+		return err
+	}
+	commit, err := r.repo.LookupCommit(id)
+	if err != nil {
+		r.logger.Warn(err, "skip %s which doesn't point to a commit", ref.name)
+		return nil
+	}
+	defer commit.Free()
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	defer tree.Free()
+	return tree.Walk(func(root string, entry *git.TreeEntry) error {
+		if entry.Type == git.ObjectTree {
+			if IsDependencySkippedDir(entry.Name) {
+				return git.TreeWalkSkip
+			}
+			return nil
 		}
-		if commit == nil {
+		filePath := root + entry.Name
+		if entry.Type != git.ObjectBlob || !IsDependencyManifest(filePath) {
 			return nil
 		}
+		blob, err := r.repo.LookupBlob(entry.Id)
+		if err != nil {
+			return err
+		}
+		defer blob.Free()
+		return storeDependencies(r.store, r.logger, r.id, ref, filePath, blob.Contents())
+	})
+}
+
+// ReadFileAtHead returns the content of the file at HEAD, nil if the file doesn't exist
+func (r *Libgit2RepoCollector) ReadFileAtHead(ctx context.Context, path string) ([]byte, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		if git.IsErrorCode(err, git.ErrorCodeUnbornBranch) || git.IsErrorCode(err, git.ErrorCodeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer head.Free()
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return nil, err
+	}
+	defer commit.Free()
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	defer tree.Free()
+	entry, err := tree.EntryByPath(path)
+	if err != nil {
+		if git.IsErrorCode(err, git.ErrorCodeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	blob, err := r.repo.LookupBlob(entry.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Free()
+	return append([]byte{}, blob.Contents()...), nil
+}
+
+// CollectCommits Collect data from each commit, we can also get the diff line
+func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext) error {
+	taskOpts := subtaskCtx.GetData().(*GitExtractorTaskData).Options
+	classifier := subtaskCtx.GetData().(*GitExtractorTaskData).FileClassifier
+	opts, err := getDiffOpts()
+	if err != nil {
+		return err
+	}
+	components, err := loadComponentMatchers(subtaskCtx.GetDal(), r.id)
+	if err != nil {
+		return err
+	}
+	odb, err := errors.Convert01(r.repo.Odb())
+	if err != nil {
+		return err
+	}
+	tips, err := r.refTips()
+	if err != nil {
+		return err
+	}
+	optionsHash := refTipOptionsHash(taskOpts, components)
+	processedTips := validProcessedRefTips(r.store, r.logger, subtaskCtx.GetData().(*GitExtractorTaskData).ProcessedRefTips, optionsHash)
+
+	collectCommit := func(commit *git.Commit) errors.Error {
 		commitSha := commit.Id().String()
 		r.logger.Debug("process commit: %s", commitSha)
 		c := &code.Commit{
@@ -310,7 +415,7 @@ func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext)
 
 		if !*taskOpts.SkipCommitStat {
 			var stats *git.DiffStats
-			if stats, err = r.getDiffComparedToParent(taskOpts, c.Sha, commit, parent, opts, componentMap); err != nil {
+			if stats, err = r.getDiffComparedToParent(taskOpts, c.Sha, commit, parent, opts, components, classifier); err != nil {
 				return err
 			}
 			r.logger.Debug("state: %#+v\n", stats.Deletions())
@@ -332,7 +437,104 @@ func (r *Libgit2RepoCollector) CollectCommits(subtaskCtx plugin.SubTaskContext)
 		}
 		subtaskCtx.IncProgress(1)
 		return nil
-	}))
+	}
+
+	if len(processedTips) > 0 {
+		// only the commits reachable from the current tips but not from the processed ones are new
+		err = r.walkNewCommits(subtaskCtx.GetContext(), tips, processedTips, collectCommit)
+	} else {
+		err = errors.Convert(odb.ForEach(func(id *git.Oid) error {
+			select {
+			case <-subtaskCtx.GetContext().Done():
+				return subtaskCtx.GetContext().Err()
+			default:
+			}
+			commit, err1 := r.repo.LookupCommit(id)
+			if err1 != nil && err1.Error() != TypeNotMatchError {
+				return errors.Convert(err1)
+			}
+			if commit == nil {
+				return nil
+			}
+			return collectCommit(commit)
+		}))
+	}
+	if err != nil {
+		return err
+	}
+	return storeRefTips(r.store, taskOpts, optionsHash, tips, processedTips)
+}
+
+// walkNewCommits calls fn with the commits reachable from the tips but not from the processed tips
+func (r *Libgit2RepoCollector) walkNewCommits(ctx context.Context, tips map[string]string, processedTips []*models.GitRefTip, fn func(commit *git.Commit) errors.Error) errors.Error {
+	walk, err := r.repo.Walk()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	defer walk.Free()
+	walk.Sorting(git.SortTime)
+	for _, tip := range tips {
+		oid, err := git.NewOid(tip)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		if err = walk.Push(oid); err != nil {
+			return errors.Convert(err)
+		}
+	}
+	for _, tip := range processedTips {
+		oid, err := git.NewOid(tip.CommitSha)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		// the processed tip might be gone with the force pushes and the gc
+		if err = walk.Hide(oid); err != nil && !git.IsErrorCode(err, git.ErrorCodeNotFound) {
+			return errors.Convert(err)
+		}
+	}
+	var walkErr errors.Error
+	err = walk.Iterate(func(commit *git.Commit) bool {
+		select {
+		case <-ctx.Done():
+			walkErr = errors.Convert(ctx.Err())
+			return false
+		default:
+		}
+		walkErr = fn(commit)
+		return walkErr == nil
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	return errors.Convert(err)
+}
+
+// refTips returns the commit sha each ref points to, keyed by the ref name
+func (r *Libgit2RepoCollector) refTips() (map[string]string, errors.Error) {
+	iter, err := r.repo.NewReferenceIterator()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	defer iter.Free()
+	tips := make(map[string]string)
+	for {
+		ref, err := iter.Next()
+		if git.IsErrorCode(err, git.ErrorCodeIterOver) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+		if ref.Type() != git.ReferenceOid {
+			continue
+		}
+		// annotated tags have to be peeled to the commits, refs to other objects are ignored
+		commit, err := ref.Peel(git.ObjectCommit)
+		if err == nil {
+			tips[ref.Name()] = commit.Id().String()
+		}
+	}
+	return tips, nil
 }
 
 func (r *Libgit2RepoCollector) storeParentCommits(commitSha string, commit *git.Commit) errors.Error {
@@ -351,7 +553,7 @@ func (r *Libgit2RepoCollector) storeParentCommits(commitSha string, commit *git.
 	return r.store.CommitParents(commitParents)
 }
 
-func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOptions, commitSha string, commit *git.Commit, parent *git.Commit, opts *git.DiffOptions, componentMap map[string]*regexp.Regexp) (*git.DiffStats, errors.Error) {
+func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOptions, commitSha string, commit *git.Commit, parent *git.Commit, opts *git.DiffOptions, components componentMatchers, classifier *FileClassifier) (*git.DiffStats, errors.Error) {
 	var err error
 	var parentTree, tree *git.Tree
 	if parent != nil {
@@ -370,7 +572,7 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 		return nil, errors.Convert(err)
 	}
 	if !*taskOpts.SkipCommitFiles {
-		err = r.storeCommitFilesFromDiff(commitSha, diff, componentMap)
+		err = r.storeCommitFilesFromDiff(commitSha, diff, components, classifier)
 		if err != nil {
 			return nil, errors.Convert(err)
 		}
@@ -383,7 +585,7 @@ func (r *Libgit2RepoCollector) getDiffComparedToParent(taskOpts *GitExtractorOpt
 	return stats, nil
 }
 
-func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, componentMap map[string]*regexp.Regexp) errors.Error {
+func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *git.Diff, components componentMatchers, classifier *FileClassifier) errors.Error {
 	var commitFile *code.CommitFile
 	var commitFileComponent *code.CommitFileComponent
 	var err error
@@ -407,17 +609,11 @@ func (r *Libgit2RepoCollector) storeCommitFilesFromDiff(commitSha string, diff *
 		shaFilePath := sha256.New()
 		shaFilePath.Write([]byte(file.NewFile.Path))
 		commitFile.Id = commitSha + ":" + hex.EncodeToString(shaFilePath.Sum(nil))
+		commitFile.Language, commitFile.Category = classifier.Classify(commitFile.FilePath, r.readBlobHead(file.NewFile.Oid))
 
-		commitFileComponent = new(code.CommitFileComponent)
-		for component, reg := range componentMap {
-			if reg.MatchString(commitFile.FilePath) {
-				commitFileComponent.ComponentName = component
-				break
-			}
-		}
-		commitFileComponent.CommitFileId = commitFile.Id
-		if commitFileComponent.ComponentName == "" {
-			commitFileComponent.ComponentName = "Default"
+		commitFileComponent = &code.CommitFileComponent{
+			CommitFileId:  commitFile.Id,
+			ComponentName: components.match(commitFile.FilePath),
 		}
 		return func(hunk git.DiffHunk) (git.DiffForEachLineCallback, error) {
 			return func(line git.DiffLine) error {
@@ -579,8 +775,13 @@ func (r *Libgit2RepoCollector) CollectDiffLine(subtaskCtx plugin.SubTaskContext)
 	if err != nil {
 		return errors.Convert(err)
 	}
+	classifier := subtaskCtx.GetData().(*GitExtractorTaskData).FileClassifier
 	for fp := range snapshot {
 		temp := snapshot[fp]
+		language, category := classifier.Classify(fp, func() []byte {
+			content, _ := r.ReadFileAtHead(subtaskCtx.GetContext(), fp)
+			return content
+		})
 		count := 0
 		for e := temp.Lines.Front(); e != nil; e = e.Next() {
 			count++
@@ -589,6 +790,8 @@ func (r *Libgit2RepoCollector) CollectDiffLine(subtaskCtx plugin.SubTaskContext)
 			snapshotLine.LineNo = count
 			snapshotLine.CommitSha = e.Value.(string)
 			snapshotLine.FilePath = fp
+			snapshotLine.Language = language
+			snapshotLine.Category = category
 			err := r.store.RepoSnapshot(snapshotLine)
 			if err != nil {
 				r.logger.Info("error")
@@ -612,6 +815,25 @@ func updateSnapshotFileBlame(currentCommit *git.Commit, deleted models.DiffLines
 	}
 }
 
+// readBlobHead returns a function to read the beginning of the blob, which is enough to detect the shebang
+func (r *Libgit2RepoCollector) readBlobHead(id *git.Oid) func() []byte {
+	return func() []byte {
+		if id == nil || id.IsZero() {
+			return nil
+		}
+		blob, err := r.repo.LookupBlob(id)
+		if err != nil {
+			return nil
+		}
+		defer blob.Free()
+		content := blob.Contents()
+		if len(content) > 256 {
+			content = content[:256]
+		}
+		return append([]byte{}, content...)
+	}
+}
+
 func getDiffOpts() (*git.DiffOptions, errors.Error) {
 	opts, err := git.DefaultDiffOptions()
 	if err != nil {
@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"container/heap"
+	"context"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	revWalkQueued uint8 = 1 << iota
+	revWalkHidden
+)
+
+// commitQueue is a heap of commits, the latest committed one comes first
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int           { return len(q) }
+func (q commitQueue) Less(i, j int) bool { return q[i].Committer.When.After(q[j].Committer.When) }
+func (q commitQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any)        { *q = append(*q, x.(*object.Commit)) }
+func (q *commitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	commit := old[n-1]
+	*q = old[:n-1]
+	return commit
+}
+
+// gogitRevWalk walks the commits reachable from the pushed commits but not from the hidden ones, which works like
+// `git rev-list <pushed> --not <hidden>`. Commits are visited from the latest to the oldest by the committer time,
+// so the hidden commits are able to mark their ancestors before the ancestors get visited.
+type gogitRevWalk struct {
+	repo  *gogit.Repository
+	flags map[plumbing.Hash]uint8
+	queue commitQueue
+	// the number of queued commits which are not hidden, the walk stops when it drops to 0
+	interesting int
+}
+
+func newGogitRevWalk(repo *gogit.Repository) *gogitRevWalk {
+	return &gogitRevWalk{
+		repo:  repo,
+		flags: make(map[plumbing.Hash]uint8),
+	}
+}
+
+func (w *gogitRevWalk) Push(hash plumbing.Hash) error {
+	return w.push(hash, false)
+}
+
+func (w *gogitRevWalk) Hide(hash plumbing.Hash) error {
+	return w.push(hash, true)
+}
+
+func (w *gogitRevWalk) push(hash plumbing.Hash, hidden bool) error {
+	flag, seen := w.flags[hash]
+	if seen {
+		if !hidden || flag&revWalkHidden != 0 {
+			return nil
+		}
+		if flag&revWalkQueued != 0 {
+			w.flags[hash] = flag | revWalkHidden
+			w.interesting--
+			return nil
+		}
+	}
+	commit, err := w.repo.CommitObject(hash)
+	if err == plumbing.ErrObjectNotFound {
+		// beyond the boundary of the shallow clone
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	flag = revWalkQueued
+	if hidden {
+		flag |= revWalkHidden
+	} else {
+		w.interesting++
+	}
+	w.flags[hash] = flag
+	heap.Push(&w.queue, commit)
+	return nil
+}
+
+// Iterate calls fn with the commits reachable from the pushed commits but not from the hidden ones
+func (w *gogitRevWalk) Iterate(ctx context.Context, fn func(commit *object.Commit) error) error {
+	for w.interesting > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		commit := heap.Pop(&w.queue).(*object.Commit)
+		flag := w.flags[commit.Hash] &^ revWalkQueued
+		w.flags[commit.Hash] = flag
+		hidden := flag&revWalkHidden != 0
+		if !hidden {
+			w.interesting--
+			if err := fn(commit); err != nil {
+				return err
+			}
+		}
+		for _, parent := range commit.ParentHashes {
+			if err := w.push(parent, hidden); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
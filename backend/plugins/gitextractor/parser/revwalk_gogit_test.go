@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGogitRevWalk(t *testing.T) {
+	storage := memory.NewStorage()
+	repo, err := gogit.Init(storage, nil)
+	assert.Nil(t, err)
+	emptyTree := &object.Tree{}
+	treeObj := storage.NewEncodedObject()
+	assert.Nil(t, emptyTree.Encode(treeObj))
+	treeHash, err := storage.SetEncodedObject(treeObj)
+	assert.Nil(t, err)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := make(map[string]plumbing.Hash)
+	names := make(map[plumbing.Hash]string)
+	newCommit := func(name string, minutes int, parents ...string) {
+		sig := object.Signature{Name: "dev", Email: "dev@example.com", When: base.Add(time.Duration(minutes) * time.Minute)}
+		commit := &object.Commit{Author: sig, Committer: sig, Message: name, TreeHash: treeHash}
+		for _, parent := range parents {
+			commit.ParentHashes = append(commit.ParentHashes, commits[parent])
+		}
+		obj := storage.NewEncodedObject()
+		assert.Nil(t, commit.Encode(obj))
+		hash, err := storage.SetEncodedObject(obj)
+		assert.Nil(t, err)
+		commits[name] = hash
+		names[hash] = name
+	}
+	// a - b - c - f (main)
+	//      \     /
+	//       d - e (feature, processed)
+	newCommit("a", 0)
+	newCommit("b", 1, "a")
+	newCommit("c", 2, "b")
+	newCommit("d", 3, "b")
+	newCommit("e", 4, "d")
+	newCommit("f", 5, "c", "e")
+
+	walk := func(tips []string, hidden []string) []string {
+		w := newGogitRevWalk(repo)
+		for _, tip := range tips {
+			assert.Nil(t, w.Push(commits[tip]))
+		}
+		for _, tip := range hidden {
+			assert.Nil(t, w.Hide(commits[tip]))
+		}
+		var visited []string
+		assert.Nil(t, w.Iterate(context.Background(), func(commit *object.Commit) error {
+			visited = append(visited, names[commit.Hash])
+			return nil
+		}))
+		return visited
+	}
+	assert.Equal(t, []string{"f", "e", "d", "c", "b", "a"}, walk([]string{"f"}, nil))
+	assert.Equal(t, []string{"f", "c"}, walk([]string{"f"}, []string{"e"}))
+	assert.Equal(t, []string{"f", "e"}, walk([]string{"f"}, []string{"c", "d"}))
+	assert.Empty(t, walk([]string{"e"}, []string{"f"}))
+	// the processed tip is unknown after a force push
+	assert.Equal(t, []string{"f", "e", "d", "c", "b", "a"}, walk([]string{"f"}, []string{"gone"}))
+}
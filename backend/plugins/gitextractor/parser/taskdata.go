@@ -19,13 +19,18 @@ package parser
 
 import (
 	"net/url"
+
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 )
 
 type GitExtractorTaskData struct {
-	Options         *GitExtractorOptions
-	ParsedURL       *url.URL
-	GitRepo         RepoCollector
-	SkipAllSubtasks bool // siliently skip all tasks without raising error
+	Options        *GitExtractorOptions
+	ParsedURL      *url.URL
+	GitRepo        RepoCollector
+	FileClassifier *FileClassifier
+	// ProcessedRefTips are the ref tips processed by the previous run, commits are collected from scratch if empty
+	ProcessedRefTips []*models.GitRefTip
+	SkipAllSubtasks  bool // siliently skip all tasks without raising error
 }
 
 type GitExtractorApiParams struct {
@@ -45,4 +50,6 @@ type GitExtractorOptions struct {
 	SkipCommitStat        *bool  `json:"skipCommitStat" mapstructure:"skipCommitStat" comment:"skip all commit stat including added/deleted lines and commit files as well"`
 	SkipCommitFiles       *bool  `json:"skipCommitFiles" mapstructure:"skipCommitFiles"`
 	NoShallowClone        bool   `json:"noShallowClone" mapstructure:"noShallowClone"`
+	// FileCategoryPatterns are regular expressions by file category to override the builtin classification
+	FileCategoryPatterns map[string]string `json:"fileCategoryPatterns" mapstructure:"fileCategoryPatterns"`
 }
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -86,6 +87,8 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	dependencyWriter          *csvWriter
+	refTipWriter              *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +132,14 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.dependencyWriter, err = newCsvWriter(filepath.Join(dir, "repo_dependencies.csv"), code.RepoDependency{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	s.refTipWriter, err = newCsvWriter(filepath.Join(dir, "ref_tips.csv"), models.GitRefTip{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -160,6 +171,27 @@ func (c *CsvStore) RepoSnapshot(ss *code.RepoSnapshot) errors.Error {
 	return c.snapshotWriter.Write(ss)
 }
 
+func (c *CsvStore) RepoDependencies(dependency *code.RepoDependency) errors.Error {
+	return c.dependencyWriter.Write(dependency)
+}
+
+// SetIncrementalMode is a no-op, the csv files are written from scratch on every run
+func (c *CsvStore) SetIncrementalMode(_ bool) {}
+
+// DeleteRepoDependencies is a no-op, the csv files are written from scratch on every run
+func (c *CsvStore) DeleteRepoDependencies(_ string, _ string) errors.Error {
+	return nil
+}
+
+// DeleteRefTip is a no-op, the csv files are written from scratch on every run
+func (c *CsvStore) DeleteRefTip(_ string, _ string) errors.Error {
+	return nil
+}
+
+func (c *CsvStore) RefTips(tip *models.GitRefTip) errors.Error {
+	return c.refTipWriter.Write(tip)
+}
+
 func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	var err error
 	for _, p := range pp {
@@ -196,5 +228,11 @@ func (c *CsvStore) Close() errors.Error {
 	if c.commitLineChangeWriter != nil {
 		c.commitLineChangeWriter.Close()
 	}
+	if c.dependencyWriter != nil {
+		c.dependencyWriter.Close()
+	}
+	if c.refTipWriter != nil {
+		c.refTipWriter.Close()
+	}
 	return nil
 }
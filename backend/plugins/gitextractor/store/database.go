@@ -19,25 +19,31 @@ package store
 
 import (
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	"reflect"
 )
 
 const BathSize = 100
 
 type Database struct {
+	db     dal.Dal
 	driver *helper.BatchSaveDivider
-	table  string
-	params string
+	// commitDriver saves the data collected by walking the commits, which are kept in the incremental mode
+	commitDriver *helper.BatchSaveDivider
+	table        string
+	params       string
 }
 
 func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
 	database := &Database{
+		db:     basicRes.GetDal(),
 		table:  "gitextractor",
 		params: repoId,
 	}
@@ -47,16 +53,27 @@ func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
 		database.table,
 		database.params,
 	)
+	database.commitDriver = helper.NewBatchSaveDivider(
+		basicRes,
+		BathSize,
+		database.table,
+		database.params,
+	)
 	return database
 }
 
+// SetIncrementalMode keeps the previously collected commits instead of deleting them before saving the new ones
+func (d *Database) SetIncrementalMode(incremental bool) {
+	d.commitDriver.SetIncrementalMode(incremental)
+}
+
 func (d *Database) updateRawDataFields(rawData *common.RawDataOrigin) {
 	rawData.RawDataTable = d.table
 	rawData.RawDataParams = d.params
 }
 
 func (d *Database) RepoCommits(repoCommit *code.RepoCommit) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(repoCommit))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(repoCommit))
 	if err != nil {
 		return err
 	}
@@ -71,7 +88,7 @@ func (d *Database) Commits(commit *code.Commit) errors.Error {
 		FullName:     commit.AuthorName,
 		UserName:     commit.AuthorName,
 	}
-	accountBatch, err := d.driver.ForType(reflect.TypeOf(account))
+	accountBatch, err := d.commitDriver.ForType(reflect.TypeOf(account))
 	if err != nil {
 		return err
 	}
@@ -83,7 +100,7 @@ func (d *Database) Commits(commit *code.Commit) errors.Error {
 			return err
 		}
 	}
-	commitBatch, err := d.driver.ForType(reflect.TypeOf(commit))
+	commitBatch, err := d.commitDriver.ForType(reflect.TypeOf(commit))
 	if err != nil {
 		return err
 	}
@@ -101,7 +118,7 @@ func (d *Database) Refs(ref *code.Ref) errors.Error {
 }
 
 func (d *Database) CommitFiles(file *code.CommitFile) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(file))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(file))
 	if err != nil {
 		return err
 	}
@@ -110,7 +127,7 @@ func (d *Database) CommitFiles(file *code.CommitFile) errors.Error {
 }
 
 func (d *Database) CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error {
-	batch, err := d.driver.ForType(reflect.TypeOf(commitFileComponent))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(commitFileComponent))
 	if err != nil {
 		return err
 	}
@@ -127,6 +144,32 @@ func (d *Database) RepoSnapshot(snapshotElement *code.RepoSnapshot) errors.Error
 	return batch.Add(snapshotElement)
 }
 
+func (d *Database) RepoDependencies(dependency *code.RepoDependency) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(dependency))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&dependency.RawDataOrigin)
+	return batch.Add(dependency)
+}
+
+func (d *Database) DeleteRepoDependencies(repoId string, refName string) errors.Error {
+	return d.db.Delete(&code.RepoDependency{}, dal.Where("repo_id = ? AND ref_name = ?", repoId, refName))
+}
+
+func (d *Database) RefTips(tip *models.GitRefTip) errors.Error {
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(tip))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&tip.RawDataOrigin)
+	return batch.Add(tip)
+}
+
+func (d *Database) DeleteRefTip(repoId string, refName string) errors.Error {
+	return d.db.Delete(&models.GitRefTip{}, dal.Where("repo_id = ? AND ref_name = ?", repoId, refName))
+}
+
 func (d *Database) CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error {
 	batch, err := d.driver.ForType(reflect.TypeOf(commitLineChange))
 	if err != nil {
@@ -140,7 +183,7 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 	if len(pp) == 0 {
 		return nil
 	}
-	batch, err := d.driver.ForType(reflect.TypeOf(pp[0]))
+	batch, err := d.commitDriver.ForType(reflect.TypeOf(pp[0]))
 	if err != nil {
 		return err
 	}
@@ -155,5 +198,8 @@ func (d *Database) CommitParents(pp []*code.CommitParent) errors.Error {
 }
 
 func (d *Database) Close() errors.Error {
+	if err := d.commitDriver.Close(); err != nil {
+		return err
+	}
 	return d.driver.Close()
 }
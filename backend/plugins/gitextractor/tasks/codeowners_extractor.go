@@ -0,0 +1,202 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
+)
+
+const (
+	// the length of components.path_regex
+	maxComponentPathRegexLength = 255
+	// the length of component_owners.owner
+	maxComponentOwnerLength = 100
+)
+
+// ExtractCodeOwners converts the CODEOWNERS file at HEAD to Components and maps their owners to Teams
+func ExtractCodeOwners(subTaskCtx plugin.SubTaskContext) errors.Error {
+	taskData := subTaskCtx.GetData().(*parser.GitExtractorTaskData)
+	if taskData.SkipAllSubtasks {
+		return nil
+	}
+	repo := getGitRepo(subTaskCtx)
+	logger := subTaskCtx.GetLogger()
+	db := subTaskCtx.GetDal()
+	repoId := taskData.Options.RepoId
+
+	var content []byte
+	for _, path := range parser.CodeOwnersPaths {
+		var err error
+		content, err = repo.ReadFileAtHead(subTaskCtx.GetContext(), path)
+		if err != nil {
+			return errors.Convert(err)
+		}
+		if content != nil {
+			logger.Info("extracting components from %s", path)
+			break
+		}
+	}
+
+	// components created manually take precedence over the ones from CODEOWNERS
+	err := db.Delete(&code.Component{}, dal.Where("repo_id = ? AND source = ?", repoId, code.COMPONENT_SOURCE_CODEOWNERS))
+	if err != nil {
+		return err
+	}
+	err = db.Delete(&crossdomain.ComponentOwner{}, dal.Where("repo_id = ?", repoId))
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		logger.Info("no CODEOWNERS file found")
+		return nil
+	}
+	var manualNames []string
+	err = db.Pluck("name", &manualNames,
+		dal.From(&code.Component{}),
+		dal.Where("repo_id = ? AND source = ?", repoId, code.COMPONENT_SOURCE_MANUAL),
+	)
+	if err != nil {
+		return err
+	}
+	manual := make(map[string]bool, len(manualNames))
+	for _, name := range manualNames {
+		manual[name] = true
+	}
+
+	// a pattern may appear more than once, the last one wins as it does in CODEOWNERS
+	rules := parser.ParseCodeOwners(content)
+	ruleIndexes := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndexes[rule.Pattern] = i
+	}
+	resolver := newCodeOwnerResolver(db)
+	subTaskCtx.SetProgress(0, len(rules))
+	for i, rule := range rules {
+		subTaskCtx.IncProgress(1)
+		if ruleIndexes[rule.Pattern] != i {
+			continue
+		}
+		if manual[rule.Pattern] {
+			logger.Warn(nil, "component %s was created manually, skip the CODEOWNERS rule", rule.Pattern)
+			continue
+		}
+		if len(rule.PathRegex) > maxComponentPathRegexLength {
+			logger.Warn(nil, "the path regex of CODEOWNERS pattern %s is too long, skip it", rule.Pattern)
+			continue
+		}
+		err = db.CreateOrUpdate(&code.Component{
+			RepoId:       repoId,
+			Name:         rule.Pattern,
+			PathRegex:    rule.PathRegex,
+			Source:       code.COMPONENT_SOURCE_CODEOWNERS,
+			SortingIndex: i,
+		})
+		if err != nil {
+			return err
+		}
+		for _, owner := range rule.Owners {
+			if len(owner) > maxComponentOwnerLength {
+				logger.Warn(nil, "the CODEOWNERS owner %s is too long, skip it", owner)
+				continue
+			}
+			teamIds, err := resolver.resolve(owner)
+			if err != nil {
+				return err
+			}
+			if len(teamIds) == 0 {
+				// keep the unresolved owner so it can be mapped to a Team later
+				teamIds = []string{""}
+			}
+			for _, teamId := range teamIds {
+				err = db.CreateOrUpdate(&crossdomain.ComponentOwner{
+					RepoId:        repoId,
+					ComponentName: rule.Pattern,
+					Owner:         owner,
+					TeamId:        teamId,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// codeOwnerResolver maps the owners in CODEOWNERS to Teams, the results are cached since an owner usually
+// owns many paths
+type codeOwnerResolver struct {
+	db    dal.Dal
+	cache map[string][]string
+}
+
+func newCodeOwnerResolver(db dal.Dal) *codeOwnerResolver {
+	return &codeOwnerResolver{db: db, cache: make(map[string][]string)}
+}
+
+// resolve returns the ids of Teams of the owner, which could be `@org/team-slug`, `@username` or an email
+func (r *codeOwnerResolver) resolve(owner string) ([]string, errors.Error) {
+	if teamIds, ok := r.cache[owner]; ok {
+		return teamIds, nil
+	}
+	var teamIds []string
+	var err errors.Error
+	switch {
+	case strings.HasPrefix(owner, "@") && strings.Contains(owner, "/"):
+		slug := strings.ToLower(owner[strings.LastIndex(owner, "/")+1:])
+		err = r.db.Pluck("id", &teamIds,
+			dal.From(&crossdomain.Team{}),
+			dal.Where("LOWER(name) = ? OR LOWER(alias) = ?", slug, slug),
+		)
+	case strings.HasPrefix(owner, "@"):
+		err = r.db.Pluck("DISTINCT tu.team_id", &teamIds,
+			dal.From("accounts a"),
+			dal.Join("JOIN user_accounts ua ON ua.account_id = a.id"),
+			dal.Join("JOIN team_users tu ON tu.user_id = ua.user_id AND (tu.end_date IS NULL OR tu.end_date >= CURRENT_DATE) AND tu.start_date <= CURRENT_DATE"),
+			dal.Where("LOWER(a.user_name) = ?", strings.ToLower(owner[1:])),
+		)
+	case strings.Contains(owner, "@"):
+		err = r.db.Pluck("DISTINCT tu.team_id", &teamIds,
+			dal.From("users u"),
+			dal.Join("JOIN team_users tu ON tu.user_id = u.id AND (tu.end_date IS NULL OR tu.end_date >= CURRENT_DATE) AND tu.start_date <= CURRENT_DATE"),
+			dal.Where("LOWER(u.email) = ?", strings.ToLower(owner)),
+		)
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to resolve the team of owner %s", owner))
+	}
+	r.cache[owner] = teamIds
+	return teamIds, nil
+}
+
+var ExtractCodeOwnersMeta = plugin.SubTaskMeta{
+	Name:             "Extract CODEOWNERS",
+	EntryPoint:       ExtractCodeOwners,
+	EnabledByDefault: false,
+	Description:      "extract components and their owning teams from the CODEOWNERS file at HEAD",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CROSS},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
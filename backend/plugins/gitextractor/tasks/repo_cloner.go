@@ -63,6 +63,18 @@ func CloneGitRepo(subTaskCtx plugin.SubTaskContext) errors.Error {
 		return err
 	}
 
+	// skip the commits processed by the previous runs unless full sync is requested
+	if syncPolicy := subTaskCtx.TaskContext().SyncPolicy(); syncPolicy == nil || !syncPolicy.FullSync {
+		taskData.ProcessedRefTips, err = parser.LoadProcessedRefTips(subTaskCtx.GetDal(), op)
+		if err != nil {
+			return err
+		}
+	}
+	storage.SetIncrementalMode(len(taskData.ProcessedRefTips) > 0)
+	if len(taskData.ProcessedRefTips) > 0 {
+		logger.Info("collect the commits since %d processed ref tips", len(taskData.ProcessedRefTips))
+	}
+
 	// We have done comparison experiments for git2go and go-git, and the results show that git2go has better performance.
 	var repoCollector parser.RepoCollector
 	if *taskData.Options.UseGoGit {
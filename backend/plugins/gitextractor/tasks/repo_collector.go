@@ -81,6 +81,14 @@ func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return nil
 }
 
+func CollectGitDependencies(subTaskCtx plugin.SubTaskContext) errors.Error {
+	if subTaskCtx.TaskContext().GetData().(*parser.GitExtractorTaskData).SkipAllSubtasks {
+		return nil
+	}
+	repo := getGitRepo(subTaskCtx)
+	return errors.Convert(repo.CollectDependencies(subTaskCtx))
+}
+
 func getGitRepo(subTaskCtx plugin.SubTaskContext) parser.RepoCollector {
 	taskData, ok := subTaskCtx.GetData().(*parser.GitExtractorTaskData)
 	if !ok {
@@ -127,3 +135,12 @@ var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
 }
+
+var CollectGitDependencyMeta = plugin.SubTaskMeta{
+	Name:             "Collect Dependencies",
+	EntryPoint:       CollectGitDependencies,
+	EnabledByDefault: false,
+	Description:      "collect the dependencies declared in manifests and SBOM files at HEAD and each tag into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
@@ -125,15 +125,19 @@ func makeDataSourcePipelinePlanV200(
 			}
 			token := strings.Split(connection.Token, ",")[0]
 			cloneUrl.User = url.UserPassword("git", token)
+			gitextractorOptions := map[string]interface{}{
+				"url":      cloneUrl.String(),
+				"name":     githubRepo.FullName,
+				"fullName": githubRepo.FullName,
+				"repoId":   didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
+				"proxy":    connection.Proxy,
+			}
+			if len(scopeConfig.FileCategoryPatterns) > 0 {
+				gitextractorOptions["fileCategoryPatterns"] = scopeConfig.FileCategoryPatterns
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":      cloneUrl.String(),
-					"name":     githubRepo.FullName,
-					"fullName": githubRepo.FullName,
-					"repoId":   didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(connection.ID, githubRepo.GithubId),
-					"proxy":    connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOptions,
 			})
 
 		}
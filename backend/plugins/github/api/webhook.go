@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// webhookEvents are the events which might change the data we collect, others like "star" are ignored
+var webhookEvents = map[string]bool{
+	"push":                        true,
+	"create":                      true,
+	"delete":                      true,
+	"pull_request":                true,
+	"pull_request_review":         true,
+	"pull_request_review_comment": true,
+	"issues":                      true,
+	"issue_comment":               true,
+	"deployment":                  true,
+	"deployment_status":           true,
+	"workflow_run":                true,
+	"release":                     true,
+}
+
+type githubWebhookPayload struct {
+	Repository *struct {
+		Id int `json:"id"`
+	} `json:"repository"`
+}
+
+// ParseSourceWebhook verifies the X-Hub-Signature-256 header and maps the event to the repo it was sent for
+func ParseSourceWebhook(secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	err := api.VerifyHmacSha256Signature(secret, body, header.Get("X-Hub-Signature-256"))
+	if err != nil {
+		return nil, err
+	}
+	event := &plugin.SourceWebhookEvent{Name: header.Get("X-GitHub-Event")}
+	if !webhookEvents[event.Name] {
+		return event, nil
+	}
+	payload := &githubWebhookPayload{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode the github webhook payload")
+	}
+	if payload.Repository != nil && payload.Repository.Id != 0 {
+		event.ScopeIds = []string{strconv.Itoa(payload.Repository.Id)}
+	}
+	return event, nil
+}
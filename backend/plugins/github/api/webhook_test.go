@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSourceWebhook(t *testing.T) {
+	body := []byte(`{"action":"opened","repository":{"id":384111310,"full_name":"apache/incubator-devlake"}}`)
+	header := http.Header{}
+	header.Set("X-GitHub-Event", "pull_request")
+	header.Set("X-Hub-Signature-256", "sha256=0c1a0b6b0e4b8bdfb12b3df8b1ff8e8b4ce1fd8c4d0ef4c6b4f1d8a56ad5f8f1")
+	_, err := ParseSourceWebhook("secret", header, body)
+	assert.NotNil(t, err)
+
+	header.Set("X-Hub-Signature-256", "sha256=a5a576e310040b1316f1ad8ec02439bd51901fe22d8c7beb069de5908ac0f4e7")
+	event, err := ParseSourceWebhook("secret", header, body)
+	assert.Nil(t, err)
+	assert.Equal(t, "pull_request", event.Name)
+	assert.Equal(t, []string{"384111310"}, event.ScopeIds)
+
+	header.Set("X-GitHub-Event", "star")
+	event, err = ParseSourceWebhook("secret", header, body)
+	assert.Nil(t, err)
+	assert.Empty(t, event.ScopeIds)
+}
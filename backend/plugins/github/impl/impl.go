@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/subtaskmeta/sorter"
 
@@ -43,6 +44,7 @@ var _ interface {
 	plugin.PluginModel
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourcePluginWebhook
 	plugin.CloseablePluginTask
 } = (*Github)(nil)
 
@@ -234,6 +236,10 @@ func (p Github) MakeDataSourcePipelinePlanV200(
 	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
 }
 
+func (p Github) ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	return api.ParseSourceWebhook(secret, header, body)
+}
+
 func (p Github) Close(taskCtx plugin.TaskContext) errors.Error {
 	data, ok := taskCtx.GetData().(*tasks.GithubTaskData)
 	if !ok {
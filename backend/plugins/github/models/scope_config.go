@@ -40,6 +40,8 @@ type GithubScopeConfig struct {
 	ProductionPattern    string            `mapstructure:"productionPattern,omitempty" json:"productionPattern" gorm:"type:varchar(255)"`
 	EnvNamePattern       string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff              datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+	// FileCategoryPatterns are regular expressions by file category to override the classification of gitextractor
+	FileCategoryPatterns map[string]string `mapstructure:"fileCategoryPatterns,omitempty" json:"fileCategoryPatterns" gorm:"type:json;serializer:json"`
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
@@ -142,15 +142,19 @@ func makePipelinePlanV200(
 				return nil, err
 			}
 			cloneUrl.User = url.UserPassword("git", connection.Token)
+			gitextractorOptions := map[string]interface{}{
+				"url":      cloneUrl.String(),
+				"name":     gitlabProject.Name,
+				"fullName": gitlabProject.PathWithNamespace,
+				"repoId":   didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
+				"proxy":    connection.Proxy,
+			}
+			if len(scopeConfig.FileCategoryPatterns) > 0 {
+				gitextractorOptions["fileCategoryPatterns"] = scopeConfig.FileCategoryPatterns
+			}
 			stage = append(stage, &coreModels.PipelineTask{
-				Plugin: "gitextractor",
-				Options: map[string]interface{}{
-					"url":      cloneUrl.String(),
-					"name":     gitlabProject.Name,
-					"fullName": gitlabProject.PathWithNamespace,
-					"repoId":   didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connection.ID, gitlabProject.GitlabId),
-					"proxy":    connection.Proxy,
-				},
+				Plugin:  "gitextractor",
+				Options: gitextractorOptions,
 			})
 		}
 
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/subtaskmeta/sorter"
 
@@ -43,6 +44,7 @@ var _ interface {
 	plugin.PluginMigration
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourcePluginWebhook
 	plugin.CloseablePluginTask
 } = (*Gitlab)(nil)
 
@@ -80,6 +82,10 @@ func (p Gitlab) MakeDataSourcePipelinePlanV200(
 	return api.MakePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
 }
 
+func (p Gitlab) ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	return api.ParseSourceWebhook(secret, header, body)
+}
+
 func (p Gitlab) GetTablesInfo() []dal.Tabler {
 	return []dal.Tabler{
 		&models.GitlabConnection{},
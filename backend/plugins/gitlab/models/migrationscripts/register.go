@@ -48,5 +48,6 @@ func All() []plugin.MigrationScript {
 		new(addTimeToGitlabPipelineProject),
 		new(modifyDeploymentCommitTitle),
 		new(addWebUrlToGitlabPipelineProject),
+		new(addFileCategoryPatterns),
 	}
 }
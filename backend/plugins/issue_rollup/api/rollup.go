@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+type Descendant struct {
+	ticket.Issue
+	// HierarchyParentId is the parent in the hierarchy, which could be an epic or an issue related from another tool
+	HierarchyParentId string `json:"hierarchyParentId"`
+	Depth             int    `json:"depth"`
+}
+
+// GetRollups lists the rolled up progress of the epics and initiatives of the project
+// @Summary list the rollups of the project
+// @Description the issues with descendants in the hierarchy, with the progress, the pull requests and the deployments rolled up
+// @Tags plugins/issue_rollup
+// @Param projectName path string true "project name"
+// @Param parentIssueId query string false "only the children of the issue, empty for the roots"
+// @Param level query int false "only the rollups of the level, 1 for the epics of work items"
+// @Success 200  {object} []ticket.IssueRollup
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_rollup/projects/{projectName}/rollups [GET]
+func GetRollups(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	clauses := []dal.Clause{dal.Where("project_name = ?", input.Params["projectName"])}
+	if parentIssueId, ok := input.Query["parentIssueId"]; ok {
+		clauses = append(clauses, dal.Where("parent_issue_id = ?", parentIssueId[0]))
+	}
+	if level := input.Query.Get("level"); level != "" {
+		l, err := strconv.Atoi(level)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "level should be an integer")
+		}
+		clauses = append(clauses, dal.Where("level = ?", l))
+	}
+	clauses = append(clauses, dal.Orderby("level DESC, issue_key"))
+	rollups := []*ticket.IssueRollup{}
+	err := basicRes.GetDal().All(&rollups, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: rollups, Status: http.StatusOK}, nil
+}
+
+// GetDescendants lists all the issues under the issue in the hierarchy of the project, whichever tool they come from
+// @Summary list the descendants of the issue
+// @Tags plugins/issue_rollup
+// @Param projectName path string true "project name"
+// @Param issueId path string true "issue id"
+// @Success 200  {object} []Descendant
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_rollup/projects/{projectName}/rollups/{issueId}/descendants [GET]
+func GetDescendants(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	projectName := input.Params["projectName"]
+	descendants := []*Descendant{}
+	err := basicRes.GetDal().All(&descendants,
+		dal.Select("issues.*, p.ancestor_issue_id AS hierarchy_parent_id, h.depth"),
+		dal.From("issue_hierarchies h"),
+		dal.Join("JOIN issues ON issues.id = h.descendant_issue_id"),
+		dal.Join("JOIN issue_hierarchies p ON p.project_name = h.project_name AND p.descendant_issue_id = h.descendant_issue_id AND p.depth = 1"),
+		dal.Where("h.project_name = ? AND h.ancestor_issue_id = ?", projectName, input.Params["issueId"]),
+		dal.Orderby("h.depth, issues.issue_key"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: descendants, Status: http.StatusOK}, nil
+}
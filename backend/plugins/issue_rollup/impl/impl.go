@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/issue_rollup/api"
+	"github.com/apache/incubator-devlake/plugins/issue_rollup/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/issue_rollup/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
+} = (*IssueRollup)(nil)
+
+type IssueRollup struct{}
+
+func (p IssueRollup) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p IssueRollup) Description() string {
+	return "build the issue hierarchy across the tools and roll up the progress of the epics and initiatives"
+}
+
+func (p IssueRollup) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{
+		{
+			"model": "issues",
+		},
+		{
+			"model": "issue_relationships",
+		},
+	}, nil
+}
+
+func (p IssueRollup) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p IssueRollup) Name() string {
+	return "issue_rollup"
+}
+
+func (p IssueRollup) IsProjectMetric() bool {
+	return true
+}
+
+// RunAfter the plugins providing the pull requests linked to the issues, the pull requests deployed and the forecasts
+// of the epics
+func (p IssueRollup) RunAfter() ([]string, errors.Error) {
+	return []string{"linker", "dora", "forecast"}, nil
+}
+
+func (p IssueRollup) Settings() interface{} {
+	return nil
+}
+
+func (p IssueRollup) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CalculateIssueRollupsMeta,
+	}
+}
+
+func (p IssueRollup) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.IssueRollupTaskData{
+		Options: op,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p IssueRollup) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/issue_rollup"
+}
+
+func (p IssueRollup) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p IssueRollup) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"projects/:projectName/rollups": {
+			"GET": api.GetRollups,
+		},
+		"projects/:projectName/rollups/:issueId/descendants": {
+			"GET": api.GetDescendants,
+		},
+	}
+}
+
+func (p IssueRollup) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.IssueRollupOptions{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "issue_rollup",
+				Options: map[string]interface{}{
+					"projectName":         projectName,
+					"childRelationTypes":  op.ChildRelationTypes,
+					"parentRelationTypes": op.ParentRelationTypes,
+					"forecastConfidence":  op.ForecastConfidence,
+				},
+				Subtasks: []string{
+					"calculateIssueRollups",
+				},
+			},
+		},
+	}
+	return plan, nil
+}
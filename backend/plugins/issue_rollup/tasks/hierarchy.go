@@ -0,0 +1,209 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// Hierarchy is the tree of the issues, an issue's parent is its parent issue, otherwise its epic,
+// otherwise the parent given by the issue relationships, which may link issues of different tools
+type Hierarchy struct {
+	issues   map[string]*ticket.Issue
+	parents  map[string]string
+	children map[string][]string
+}
+
+func NewHierarchy(issues []*ticket.Issue, relationships []*ticket.IssueRelationship, op *IssueRollupOptions) *Hierarchy {
+	h := &Hierarchy{
+		issues:   make(map[string]*ticket.Issue, len(issues)),
+		parents:  make(map[string]string),
+		children: make(map[string][]string),
+	}
+	// the issue keys are only unique within a tool, the ambiguous ones are not used
+	byKey := make(map[string]string)
+	for _, issue := range issues {
+		h.issues[issue.Id] = issue
+		if issue.IssueKey == "" {
+			continue
+		}
+		if _, ok := byKey[issue.IssueKey]; ok {
+			byKey[issue.IssueKey] = ""
+		} else {
+			byKey[issue.IssueKey] = issue.Id
+		}
+	}
+	related := make(map[string][]string)
+	for _, r := range relationships {
+		switch op.relationDirection(r.OriginalType) {
+		case 1:
+			related[r.TargetIssueId] = append(related[r.TargetIssueId], r.SourceIssueId)
+		case -1:
+			related[r.SourceIssueId] = append(related[r.SourceIssueId], r.TargetIssueId)
+		}
+	}
+	ids := make([]string, 0, len(issues))
+	for id := range h.issues {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	// the stronger links go first, the weaker ones making cycles are dropped
+	for _, candidatesOf := range []func(issue *ticket.Issue) []string{
+		func(issue *ticket.Issue) []string { return []string{issue.ParentIssueId} },
+		func(issue *ticket.Issue) []string { return []string{byKey[issue.EpicKey]} },
+		func(issue *ticket.Issue) []string { return related[issue.Id] },
+	} {
+		for _, id := range ids {
+			for _, parentId := range candidatesOf(h.issues[id]) {
+				if _, ok := h.issues[parentId]; !ok || h.parents[id] != "" || h.isAncestor(id, parentId) {
+					continue
+				}
+				h.parents[id] = parentId
+				h.children[parentId] = append(h.children[parentId], id)
+			}
+		}
+	}
+	return h
+}
+
+// isAncestor tells whether the issue is an ancestor of the other, which would make a cycle of it being the parent
+func (h *Hierarchy) isAncestor(issueId, otherId string) bool {
+	for id := h.parents[otherId]; id != ""; id = h.parents[id] {
+		if id == issueId {
+			return true
+		}
+	}
+	return otherId == issueId
+}
+
+// Parent returns the id of the parent of the issue, empty for the roots
+func (h *Hierarchy) Parent(issueId string) string {
+	return h.parents[issueId]
+}
+
+// Closure lists all the pairs of ancestors and descendants
+func (h *Hierarchy) Closure(projectName string) []*ticket.IssueHierarchy {
+	var closure []*ticket.IssueHierarchy
+	for id := range h.parents {
+		depth := 1
+		for ancestor := h.parents[id]; ancestor != ""; ancestor = h.parents[ancestor] {
+			closure = append(closure, &ticket.IssueHierarchy{
+				ProjectName:       projectName,
+				AncestorIssueId:   ancestor,
+				DescendantIssueId: id,
+				Depth:             depth,
+			})
+			depth++
+		}
+	}
+	sort.Slice(closure, func(i, j int) bool {
+		if closure[i].AncestorIssueId != closure[j].AncestorIssueId {
+			return closure[i].AncestorIssueId < closure[j].AncestorIssueId
+		}
+		return closure[i].DescendantIssueId < closure[j].DescendantIssueId
+	})
+	return closure
+}
+
+// Descendants lists the ids of all the issues under the issue
+func (h *Hierarchy) Descendants(issueId string) []string {
+	var descendants []string
+	queue := append([]string(nil), h.children[issueId]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		descendants = append(descendants, id)
+		queue = append(queue, h.children[id]...)
+	}
+	return descendants
+}
+
+// Rollups rolls up the progress of the work items, the descendants without children, into every issue with children.
+// The story points come from the highest descendants having them, so a story keeps its points when its sub-tasks
+// have none, and the points are not counted twice when both have them.
+func (h *Hierarchy) Rollups(projectName string) []*ticket.IssueRollup {
+	var rollups []*ticket.IssueRollup
+	for id := range h.children {
+		issue := h.issues[id]
+		rollup := &ticket.IssueRollup{
+			ProjectName:   projectName,
+			IssueId:       id,
+			IssueKey:      issue.IssueKey,
+			Title:         issue.Title,
+			Type:          issue.Type,
+			Status:        issue.Status,
+			ParentIssueId: h.parents[id],
+			Level:         h.level(id),
+		}
+		for _, descendantId := range h.Descendants(id) {
+			if len(h.children[descendantId]) > 0 {
+				continue
+			}
+			item := h.issues[descendantId]
+			rollup.TotalItems++
+			switch item.Status {
+			case ticket.DONE:
+				rollup.DoneItems++
+			case ticket.IN_PROGRESS:
+				rollup.InProgressItems++
+			default:
+				rollup.TodoItems++
+			}
+		}
+		h.rollupStoryPoints(id, rollup)
+		if rollup.TotalItems > 0 {
+			progress := float64(rollup.DoneItems) / float64(rollup.TotalItems)
+			rollup.ProgressByCount = &progress
+		}
+		if rollup.TotalStoryPoints > 0 {
+			progress := rollup.DoneStoryPoints / rollup.TotalStoryPoints
+			rollup.ProgressByStoryPoints = &progress
+		}
+		rollups = append(rollups, rollup)
+	}
+	sort.Slice(rollups, func(i, j int) bool { return rollups[i].IssueId < rollups[j].IssueId })
+	return rollups
+}
+
+// rollupStoryPoints adds the story points of the descendants, the children without story points are looked into
+func (h *Hierarchy) rollupStoryPoints(issueId string, rollup *ticket.IssueRollup) {
+	for _, childId := range h.children[issueId] {
+		child := h.issues[childId]
+		if child.StoryPoint == nil || *child.StoryPoint == 0 {
+			h.rollupStoryPoints(childId, rollup)
+			continue
+		}
+		rollup.TotalStoryPoints += *child.StoryPoint
+		if child.Status == ticket.DONE {
+			rollup.DoneStoryPoints += *child.StoryPoint
+		}
+	}
+}
+
+// level is the height of the issue in the hierarchy, 0 for the work items
+func (h *Hierarchy) level(issueId string) int {
+	level := 0
+	for _, childId := range h.children[issueId] {
+		if l := h.level(childId) + 1; l > level {
+			level = l
+		}
+	}
+	return level
+}
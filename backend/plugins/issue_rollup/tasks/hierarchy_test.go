@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func issue(id, key, status string, storyPoint float64) *ticket.Issue {
+	return &ticket.Issue{
+		DomainEntity: domainlayer.DomainEntity{Id: id},
+		IssueKey:     key,
+		Status:       status,
+		StoryPoint:   &storyPoint,
+	}
+}
+
+func TestHierarchyRollups(t *testing.T) {
+	initiative := issue("jira:1", "INIT-1", ticket.IN_PROGRESS, 0)
+	epic := issue("jira:2", "EPIC-1", ticket.IN_PROGRESS, 0)
+	story := issue("jira:3", "STORY-1", ticket.DONE, 5)
+	story.EpicKey = "EPIC-1"
+	subtask := issue("jira:4", "SUB-1", ticket.DONE, 0)
+	subtask.ParentIssueId = "jira:3"
+	// a gitlab issue of the epic, linked by a relationship
+	gitlabIssue := issue("gitlab:1", "1", ticket.TODO, 3)
+	// the epic keys not found are ignored
+	orphan := issue("jira:5", "STORY-2", ticket.TODO, 1)
+	orphan.EpicKey = "EPIC-404"
+	issues := []*ticket.Issue{initiative, epic, story, subtask, gitlabIssue, orphan}
+	relationships := []*ticket.IssueRelationship{
+		// cycles are broken, the epic link of the story is stronger
+		{SourceIssueId: "jira:3", TargetIssueId: "jira:2", OriginalType: "is parent of"},
+		{SourceIssueId: "jira:2", TargetIssueId: "jira:1", OriginalType: "is child of"},
+		{SourceIssueId: "jira:2", TargetIssueId: "gitlab:1", OriginalType: "Is Parent Of"},
+		// other relationships don't make hierarchies
+		{SourceIssueId: "jira:5", TargetIssueId: "jira:2", OriginalType: "blocks"},
+	}
+	op := &IssueRollupOptions{ChildRelationTypes: DefaultChildRelationTypes, ParentRelationTypes: DefaultParentRelationTypes}
+	h := NewHierarchy(issues, relationships, op)
+
+	assert.Equal(t, "jira:1", h.Parent("jira:2"))
+	assert.Equal(t, "jira:2", h.Parent("jira:3"))
+	assert.Equal(t, "jira:3", h.Parent("jira:4"))
+	assert.Equal(t, "jira:2", h.Parent("gitlab:1"))
+	assert.Equal(t, "", h.Parent("jira:1"))
+	assert.Equal(t, "", h.Parent("jira:5"))
+	assert.ElementsMatch(t, []string{"jira:2", "jira:3", "gitlab:1", "jira:4"}, h.Descendants("jira:1"))
+
+	closure := h.Closure("p1")
+	assert.Len(t, closure, 8)
+	depths := make(map[string]int)
+	for _, c := range closure {
+		assert.Equal(t, "p1", c.ProjectName)
+		depths[c.AncestorIssueId+">"+c.DescendantIssueId] = c.Depth
+	}
+	assert.Equal(t, 3, depths["jira:1>jira:4"])
+	assert.Equal(t, 1, depths["jira:2>gitlab:1"])
+
+	rollups := h.Rollups("p1")
+	assert.Len(t, rollups, 3)
+	byId := make(map[string]*ticket.IssueRollup)
+	for _, r := range rollups {
+		byId[r.IssueId] = r
+	}
+	// the work items of the initiative are the subtask and the gitlab issue, the story points come from the story
+	// whose subtask has none
+	init := byId["jira:1"]
+	assert.Equal(t, 3, init.Level)
+	assert.Equal(t, 2, init.TotalItems)
+	assert.Equal(t, 1, init.DoneItems)
+	assert.Equal(t, 1, init.TodoItems)
+	assert.Equal(t, 0.5, *init.ProgressByCount)
+	assert.Equal(t, float64(8), init.TotalStoryPoints)
+	assert.Equal(t, float64(5), init.DoneStoryPoints)
+	assert.Equal(t, 0.625, *init.ProgressByStoryPoints)
+	assert.Equal(t, float64(8), byId["jira:2"].TotalStoryPoints)
+	assert.Equal(t, "jira:1", byId["jira:2"].ParentIssueId)
+	assert.Equal(t, 2, byId["jira:2"].Level)
+	assert.Equal(t, 1, byId["jira:3"].Level)
+	assert.Equal(t, 1.0, *byId["jira:3"].ProgressByCount)
+	assert.Nil(t, byId["jira:3"].ProgressByStoryPoints)
+}
+
+func TestHierarchyStoryPointsNotCountedTwice(t *testing.T) {
+	epic := issue("jira:1", "EPIC-1", ticket.IN_PROGRESS, 0)
+	story := issue("jira:2", "STORY-1", ticket.IN_PROGRESS, 5)
+	story.EpicKey = "EPIC-1"
+	done := issue("jira:3", "SUB-1", ticket.DONE, 2)
+	done.ParentIssueId = "jira:2"
+	todo := issue("jira:4", "SUB-2", ticket.TODO, 3)
+	todo.ParentIssueId = "jira:2"
+	h := NewHierarchy([]*ticket.Issue{epic, story, done, todo}, nil, &IssueRollupOptions{})
+	byId := make(map[string]*ticket.IssueRollup)
+	for _, r := range h.Rollups("p1") {
+		byId[r.IssueId] = r
+	}
+	assert.Equal(t, float64(5), byId["jira:1"].TotalStoryPoints)
+	assert.Equal(t, float64(0), byId["jira:1"].DoneStoryPoints)
+	assert.Equal(t, float64(5), byId["jira:2"].TotalStoryPoints)
+	assert.Equal(t, float64(2), byId["jira:2"].DoneStoryPoints)
+}
+
+func TestHierarchyAmbiguousEpicKey(t *testing.T) {
+	story := issue("jira:3", "STORY-1", ticket.TODO, 0)
+	story.EpicKey = "1"
+	h := NewHierarchy([]*ticket.Issue{
+		issue("gitlab:1", "1", ticket.TODO, 0),
+		issue("github:1", "1", ticket.TODO, 0),
+		story,
+	}, nil, &IssueRollupOptions{})
+	assert.Equal(t, "", h.Parent("jira:3"))
+}
+
+func TestRollupPullRequests(t *testing.T) {
+	deployed := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	later := deployed.Add(time.Hour)
+	rollup := &ticket.IssueRollup{}
+	rollupPullRequests(rollup, []*issuePullRequest{
+		{IssueId: "1", PullRequestId: "pr1", Status: "MERGED", DeploymentCommitId: "d1", DeployedDate: &deployed},
+		{IssueId: "2", PullRequestId: "pr1", Status: "MERGED", DeploymentCommitId: "d1", DeployedDate: &deployed},
+		{IssueId: "2", PullRequestId: "pr2", Status: "MERGED", DeploymentCommitId: "d2", DeployedDate: &later},
+		{IssueId: "3", PullRequestId: "pr3", Status: "OPEN"},
+	})
+	assert.Equal(t, 3, rollup.PullRequests)
+	assert.Equal(t, 2, rollup.MergedPullRequests)
+	assert.Equal(t, 1, rollup.OpenPullRequests)
+	assert.Equal(t, 2, rollup.DeployedPullRequests)
+	assert.Equal(t, later, *rollup.LastDeployedDate)
+}
+
+func TestForecastCompletionDate(t *testing.T) {
+	early := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	late := early.AddDate(0, 1, 0)
+	other := early.AddDate(1, 0, 0)
+	forecastDates := map[boardScope]*time.Time{
+		{"b1", "EPIC-1"}: &early,
+		{"b2", "EPIC-1"}: &late,
+		// another epic of the same key on a board of another source
+		{"b3", "EPIC-1"}: &other,
+		// the board has no throughput
+		{"b4", "EPIC-1"}: nil,
+	}
+	assert.Equal(t, early, *forecastCompletionDate(forecastDates, []string{"b1", "b4"}, "EPIC-1"))
+	assert.Equal(t, late, *forecastCompletionDate(forecastDates, []string{"b1", "b2"}, "EPIC-1"))
+	assert.Nil(t, forecastCompletionDate(forecastDates, []string{"b4", "b5"}, "EPIC-1"))
+	assert.Nil(t, forecastCompletionDate(forecastDates, []string{"b1"}, "EPIC-2"))
+}
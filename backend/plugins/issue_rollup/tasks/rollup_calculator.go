@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateIssueRollupsMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueRollups",
+	EntryPoint:       CalculateIssueRollups,
+	EnabledByDefault: true,
+	Description:      "Build the issue hierarchy across the tools and roll up the progress, the pull requests and the deployments of the epics and initiatives",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		ticket.Issue{}.TableName(),
+		ticket.IssueRelationship{}.TableName(),
+		"pull_request_issues",
+		"project_pr_metrics",
+		ticket.BoardForecast{}.TableName(),
+		ticket.BoardIssue{}.TableName(),
+	},
+	ProductTables: []string{ticket.IssueHierarchy{}.TableName(), ticket.IssueRollup{}.TableName()},
+}
+
+// projectIssueIds selects the ids of the issues on the boards of the project
+const projectIssueIds = `SELECT bi.issue_id FROM board_issues bi
+	JOIN project_mapping pm ON pm.table = 'boards' AND pm.row_id = bi.board_id
+	WHERE pm.project_name = ?`
+
+type issuePullRequest struct {
+	IssueId            string
+	PullRequestId      string
+	Status             string
+	DeploymentCommitId string
+	DeployedDate       *time.Time
+}
+
+type epicForecast struct {
+	BoardId        string
+	Scope          string
+	CompletionDate *time.Time
+}
+
+// boardScope identifies the forecast of an epic on a board, the epic keys are only unique within a board
+type boardScope struct {
+	boardId string
+	scope   string
+}
+
+func CalculateIssueRollups(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	op := taskCtx.GetData().(*IssueRollupTaskData).Options
+
+	for _, table := range []dal.Tabler{&ticket.IssueHierarchy{}, &ticket.IssueRollup{}} {
+		err := db.Delete(table, dal.Where("project_name = ?", op.ProjectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to delete the previous "+table.TableName())
+		}
+	}
+
+	var issues []*ticket.Issue
+	err := db.All(&issues, dal.Where("id IN ("+projectIssueIds+")", op.ProjectName))
+	if err != nil {
+		return err
+	}
+	var relationships []*ticket.IssueRelationship
+	err = db.All(&relationships, dal.Where(
+		"source_issue_id IN ("+projectIssueIds+") AND target_issue_id IN ("+projectIssueIds+")",
+		op.ProjectName, op.ProjectName,
+	))
+	if err != nil {
+		return err
+	}
+	hierarchy := NewHierarchy(issues, relationships, op)
+
+	var pullRequests []*issuePullRequest
+	err = db.All(&pullRequests,
+		dal.Select("pri.issue_id, pr.id AS pull_request_id, pr.status, ppm.deployment_commit_id, dc.finished_date AS deployed_date"),
+		dal.From("pull_request_issues pri"),
+		dal.Join("JOIN pull_requests pr ON pr.id = pri.pull_request_id"),
+		dal.Join("LEFT JOIN project_pr_metrics ppm ON ppm.id = pr.id AND ppm.project_name = ?", op.ProjectName),
+		dal.Join("LEFT JOIN cicd_deployment_commits dc ON dc.id = ppm.deployment_commit_id AND ppm.deployment_commit_id != ''"),
+		dal.Where("pri.issue_id IN ("+projectIssueIds+")", op.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	issuePullRequests := make(map[string][]*issuePullRequest)
+	for _, pr := range pullRequests {
+		issuePullRequests[pr.IssueId] = append(issuePullRequests[pr.IssueId], pr)
+	}
+
+	var forecasts []*epicForecast
+	err = db.All(&forecasts,
+		dal.Select("board_id, scope, completion_date"),
+		dal.From(&ticket.BoardForecast{}),
+		dal.Where("question = ? AND confidence = ? AND board_id IN (SELECT pm.row_id FROM project_mapping pm WHERE pm.project_name = ? AND pm.table = 'boards')",
+			ticket.ForecastWhen, op.ForecastConfidence, op.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	forecastDates := make(map[boardScope]*time.Time, len(forecasts))
+	for _, forecast := range forecasts {
+		forecastDates[boardScope{forecast.BoardId, forecast.Scope}] = forecast.CompletionDate
+	}
+	var boardIssues []*ticket.BoardIssue
+	err = db.All(&boardIssues, dal.Where("issue_id IN ("+projectIssueIds+")", op.ProjectName))
+	if err != nil {
+		return err
+	}
+	issueBoards := make(map[string][]string)
+	for _, boardIssue := range boardIssues {
+		issueBoards[boardIssue.IssueId] = append(issueBoards[boardIssue.IssueId], boardIssue.BoardId)
+	}
+
+	hierarchySaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.IssueHierarchy{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, closure := range hierarchy.Closure(op.ProjectName) {
+		if err = hierarchySaver.Add(closure); err != nil {
+			return err
+		}
+	}
+	if err = hierarchySaver.Close(); err != nil {
+		return err
+	}
+
+	rollupSaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.IssueRollup{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, rollup := range hierarchy.Rollups(op.ProjectName) {
+		var linked []*issuePullRequest
+		var boardIds []string
+		for _, issueId := range append(hierarchy.Descendants(rollup.IssueId), rollup.IssueId) {
+			linked = append(linked, issuePullRequests[issueId]...)
+			boardIds = append(boardIds, issueBoards[issueId]...)
+		}
+		rollupPullRequests(rollup, linked)
+		if rollup.IssueKey != "" {
+			rollup.ForecastCompletionDate = forecastCompletionDate(forecastDates, boardIds, rollup.IssueKey)
+		}
+		if err = rollupSaver.Add(rollup); err != nil {
+			return err
+		}
+	}
+	return rollupSaver.Close()
+}
+
+// forecastCompletionDate returns the latest forecast of the epic key on the boards of the issue and its descendants
+func forecastCompletionDate(forecastDates map[boardScope]*time.Time, boardIds []string, epicKey string) *time.Time {
+	var latest *time.Time
+	for _, boardId := range boardIds {
+		date := forecastDates[boardScope{boardId, epicKey}]
+		if date != nil && (latest == nil || date.After(*latest)) {
+			latest = date
+		}
+	}
+	return latest
+}
+
+// rollupPullRequests counts the distinct pull requests linked to the issue or its descendants
+func rollupPullRequests(rollup *ticket.IssueRollup, pullRequests []*issuePullRequest) {
+	seen := make(map[string]bool)
+	for _, pr := range pullRequests {
+		if seen[pr.PullRequestId] {
+			continue
+		}
+		seen[pr.PullRequestId] = true
+		rollup.PullRequests++
+		switch pr.Status {
+		case code.OPEN:
+			rollup.OpenPullRequests++
+		case code.MERGED:
+			rollup.MergedPullRequests++
+		}
+		if pr.DeploymentCommitId != "" {
+			rollup.DeployedPullRequests++
+			if pr.DeployedDate != nil && (rollup.LastDeployedDate == nil || pr.DeployedDate.After(*rollup.LastDeployedDate)) {
+				rollup.LastDeployedDate = pr.DeployedDate
+			}
+		}
+	}
+}
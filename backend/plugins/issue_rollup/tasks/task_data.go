@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var (
+	// DefaultChildRelationTypes are the original types of the issue relationships whose target is a child of the source
+	DefaultChildRelationTypes = []string{"is parent of", "contains"}
+	// DefaultParentRelationTypes are the original types of the issue relationships whose target is the parent of the source
+	DefaultParentRelationTypes = []string{"is child of", "is contained by"}
+	DefaultForecastConfidence  = 85
+)
+
+type IssueRollupOptions struct {
+	ProjectName         string   `json:"projectName" mapstructure:"projectName"`
+	ChildRelationTypes  []string `json:"childRelationTypes" mapstructure:"childRelationTypes"`
+	ParentRelationTypes []string `json:"parentRelationTypes" mapstructure:"parentRelationTypes"`
+	// ForecastConfidence picks the board_forecasts of the epics to roll up
+	ForecastConfidence int `json:"forecastConfidence" mapstructure:"forecastConfidence"`
+}
+
+type IssueRollupTaskData struct {
+	Options *IssueRollupOptions
+}
+
+// relationDirection tells whether the relationship of the original type makes the target a child (1)
+// or the parent (-1) of the source, or neither (0)
+func (op *IssueRollupOptions) relationDirection(originalType string) int {
+	for _, t := range op.ChildRelationTypes {
+		if strings.EqualFold(t, originalType) {
+			return 1
+		}
+	}
+	for _, t := range op.ParentRelationTypes {
+		if strings.EqualFold(t, originalType) {
+			return -1
+		}
+	}
+	return 0
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*IssueRollupOptions, errors.Error) {
+	var op IssueRollupOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding issue_rollup task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required for issue_rollup")
+	}
+	if op.ChildRelationTypes == nil {
+		op.ChildRelationTypes = DefaultChildRelationTypes
+	}
+	if op.ParentRelationTypes == nil {
+		op.ParentRelationTypes = DefaultParentRelationTypes
+	}
+	if op.ForecastConfidence <= 0 || op.ForecastConfidence > 100 {
+		op.ForecastConfidence = DefaultForecastConfidence
+	}
+	return &op, nil
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+const defaultAgePercentile = 85
+
+type WipLimit struct {
+	OriginalStatus string `json:"originalStatus" mapstructure:"originalStatus"`
+	WipLimit       int    `json:"wipLimit" mapstructure:"wipLimit"`
+}
+
+type WipLimitsRequest struct {
+	Limits []WipLimit `json:"limits" mapstructure:"limits"`
+}
+
+type AgingWipResponse struct {
+	BoardId    string  `json:"boardId"`
+	Percentile float64 `json:"percentile"`
+	// ThresholdMinutes is the percentile of the cycle time of the issues done on the board
+	ThresholdMinutes int64                  `json:"thresholdMinutes"`
+	Items            []*ticket.BoardWipItem `json:"items"`
+}
+
+// GetWipLimits returns the WIP limits of the board
+// @Summary get the WIP limits of the board
+// @Tags plugins/issue_trace
+// @Param boardId path string true "board id"
+// @Success 200  {object} WipLimitsRequest
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/boards/{boardId}/wip-limits [GET]
+func GetWipLimits(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var limits []*ticket.BoardWipLimit
+	err := basicRes.GetDal().All(&limits, dal.Where("board_id = ?", input.Params["boardId"]), dal.Orderby("original_status"))
+	if err != nil {
+		return nil, err
+	}
+	resp := &WipLimitsRequest{Limits: make([]WipLimit, 0, len(limits))}
+	for _, limit := range limits {
+		resp.Limits = append(resp.Limits, WipLimit{OriginalStatus: limit.OriginalStatus, WipLimit: limit.WipLimit})
+	}
+	return &plugin.ApiResourceOutput{Body: resp, Status: http.StatusOK}, nil
+}
+
+// PutWipLimits replaces the WIP limits of the board
+// @Summary set the WIP limits of the board
+// @Description the limits replace the existing ones, the statuses not listed are unlimited.
+// @Description The TODO statuses with a limit are counted as work in progress as well, the DONE statuses never are.
+// @Tags plugins/issue_trace
+// @Accept application/json
+// @Param boardId path string true "board id"
+// @Param body body WipLimitsRequest true "json"
+// @Success 200  {object} WipLimitsRequest
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/boards/{boardId}/wip-limits [PUT]
+func PutWipLimits(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	boardId := input.Params["boardId"]
+	var req WipLimitsRequest
+	if err := helper.Decode(input.Body, &req, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid WIP limits")
+	}
+	limits := make([]*ticket.BoardWipLimit, 0, len(req.Limits))
+	seen := make(map[string]bool)
+	for _, limit := range req.Limits {
+		if limit.OriginalStatus == "" {
+			return nil, errors.BadInput.New("originalStatus is required")
+		}
+		if limit.WipLimit < 0 {
+			return nil, errors.BadInput.New(fmt.Sprintf("invalid WIP limit of %s", limit.OriginalStatus))
+		}
+		if seen[limit.OriginalStatus] {
+			return nil, errors.BadInput.New(fmt.Sprintf("duplicated WIP limit of %s", limit.OriginalStatus))
+		}
+		seen[limit.OriginalStatus] = true
+		limits = append(limits, &ticket.BoardWipLimit{
+			BoardId:        boardId,
+			OriginalStatus: limit.OriginalStatus,
+			WipLimit:       limit.WipLimit,
+		})
+	}
+	tx := basicRes.GetDal().Begin()
+	err := tx.Delete(&ticket.BoardWipLimit{}, dal.Where("board_id = ?", boardId))
+	if err == nil && len(limits) > 0 {
+		err = tx.Create(limits)
+	}
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			basicRes.GetLogger().Error(e, "transaction Rollback")
+		}
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return GetWipLimits(input)
+}
+
+// GetAgingWip lists the issues in progress on the board older than the percentile of the cycle time
+// @Summary list the aging work in progress of the board
+// @Description the issues in progress longer than the percentile of the cycle time of the issues done on the board, the oldest first
+// @Tags plugins/issue_trace
+// @Param boardId path string true "board id"
+// @Param percentile query number false "the percentile of the cycle time, 85 by default"
+// @Success 200  {object} AgingWipResponse
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/issue_trace/boards/{boardId}/aging-wip [GET]
+func GetAgingWip(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	boardId := input.Params["boardId"]
+	percentile := float64(defaultAgePercentile)
+	if p := input.Query.Get("percentile"); p != "" {
+		var err error
+		percentile, err = strconv.ParseFloat(p, 64)
+		if err != nil || percentile <= 0 || percentile > 100 {
+			return nil, errors.BadInput.New("percentile should be a number between 0 and 100")
+		}
+	}
+	db := basicRes.GetDal()
+	var cycleTimes []int64
+	err := db.Pluck("cycle_time_minutes", &cycleTimes,
+		dal.From(&ticket.IssueFlowMetric{}),
+		dal.Where("cycle_time_minutes IS NOT NULL AND issue_id IN (SELECT issue_id FROM board_issues WHERE board_id = ?)", boardId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AgingWipResponse{BoardId: boardId, Percentile: percentile, Items: []*ticket.BoardWipItem{}}
+	threshold, ok := tasks.Percentile(cycleTimes, percentile)
+	if !ok {
+		// nothing is done yet, every item in progress is aging
+		threshold = 0
+	}
+	resp.ThresholdMinutes = threshold
+	err = db.All(&resp.Items,
+		dal.Where("board_id = ? AND age_minutes > ?", boardId, threshold),
+		dal.Orderby("age_minutes DESC"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: resp, Status: http.StatusOK}, nil
+}
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/api"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMetric
+	plugin.PluginMigration
+	plugin.MetricPluginBlueprintV200
+} = (*IssueTrace)(nil)
+
+type IssueTrace struct{}
+
+func (p IssueTrace) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
+func (p IssueTrace) Description() string {
+	return "derive the status history, the time in status and the flow efficiency of the issues from the changelogs, and the work in progress of the boards"
+}
+
+func (p IssueTrace) RequiredDataEntities() (data []map[string]interface{}, err errors.Error) {
+	return []map[string]interface{}{
+		{
+			"model": "issue_changelogs",
+		},
+	}, nil
+}
+
+func (p IssueTrace) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p IssueTrace) Name() string {
+	return "issue_trace"
+}
+
+func (p IssueTrace) IsProjectMetric() bool {
+	return true
+}
+
+func (p IssueTrace) RunAfter() ([]string, errors.Error) {
+	return []string{}, nil
+}
+
+func (p IssueTrace) Settings() interface{} {
+	return nil
+}
+
+func (p IssueTrace) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CalculateIssueFlowMeta,
+		tasks.CalculateBoardWipMeta,
+	}
+}
+
+func (p IssueTrace) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.IssueTraceTaskData{
+		Options: op,
+	}, nil
+}
+
+// RootPkgPath information lost when compiled as plugin(.so)
+func (p IssueTrace) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/issue_trace"
+}
+
+func (p IssueTrace) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p IssueTrace) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"boards/:boardId/wip-limits": {
+			"GET": api.GetWipLimits,
+			"PUT": api.PutWipLimits,
+		},
+		"boards/:boardId/aging-wip": {
+			"GET": api.GetAgingWip,
+		},
+	}
+}
+
+func (p IssueTrace) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
+	op := &tasks.IssueTraceOptions{}
+	if options != nil && string(options) != "\"\"" {
+		err := json.Unmarshal(options, op)
+		if err != nil {
+			return nil, errors.Default.WrapRaw(err)
+		}
+	}
+	plan := coreModels.PipelinePlan{
+		{
+			{
+				Plugin: "issue_trace",
+				Options: map[string]interface{}{
+					"projectName":        projectName,
+					"todoStatuses":       op.TodoStatuses,
+					"inProgressStatuses": op.InProgressStatuses,
+					"doneStatuses":       op.DoneStatuses,
+					"blockedStatuses":    op.BlockedStatuses,
+					"blockedFields":      op.BlockedFields,
+					"wipLookbackDays":    op.WipLookbackDays,
+				},
+				Subtasks: []string{
+					"calculateIssueFlow",
+					"calculateBoardWip",
+				},
+			},
+		},
+	}
+	return plan, nil
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/plugins/issue_trace/impl"
+	"github.com/spf13/cobra"
+)
+
+// PluginEntry exports for Framework to search and load
+var PluginEntry impl.IssueTrace //nolint
+
+// standalone mode for debugging
+func main() {
+	cmd := &cobra.Command{Use: "issue_trace"}
+
+	projectName := cmd.Flags().StringP("projectName", "p", "", "project name")
+	blockedStatuses := cmd.Flags().StringP("blockedStatuses", "b", "", "original statuses in which the issues are blocked, separated by comma")
+	timeAfter := cmd.Flags().StringP("timeAfter", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
+
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		options := map[string]interface{}{
+			"projectName": *projectName,
+		}
+		if *blockedStatuses != "" {
+			options["blockedStatuses"] = strings.Split(*blockedStatuses, ",")
+		}
+		runner.DirectRun(cmd, args, PluginEntry, options, *timeAfter)
+	}
+	runner.RunCmd(cmd)
+}
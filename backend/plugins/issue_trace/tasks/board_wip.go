@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// CountDailyWip counts the issues of the board in each original status at the end of each day from `from` to `now`,
+// the IN_PROGRESS statuses are work in progress, the TODO statuses are only counted when they have a WIP limit, e.g. a
+// "Ready for Dev" column, and the DONE statuses are skipped
+func CountDailyWip(boardId string, histories []*ticket.IssueStatusHistory, limits map[string]int, from, now time.Time) []*ticket.BoardStatusWip {
+	var days []time.Time
+	for day := from.UTC().Truncate(24 * time.Hour); !day.After(now); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	type dayStatus struct {
+		day            time.Time
+		originalStatus string
+	}
+	wips := make(map[dayStatus]*ticket.BoardStatusWip)
+	for _, history := range histories {
+		if _, limited := limits[history.OriginalStatus]; history.Status == ticket.DONE || (history.Status != ticket.IN_PROGRESS && !limited) {
+			continue
+		}
+		for _, day := range days {
+			endOfDay := day.AddDate(0, 0, 1)
+			if endOfDay.After(now) {
+				endOfDay = now
+			}
+			if history.StartDate.After(endOfDay) || (history.EndDate != nil && !history.EndDate.After(endOfDay)) {
+				continue
+			}
+			key := dayStatus{day, history.OriginalStatus}
+			wip := wips[key]
+			if wip == nil {
+				wip = &ticket.BoardStatusWip{
+					BoardId:        boardId,
+					Date:           day,
+					OriginalStatus: history.OriginalStatus,
+					Status:         history.Status,
+				}
+				wips[key] = wip
+			}
+			wip.WipCount++
+		}
+	}
+	result := make([]*ticket.BoardStatusWip, 0, len(wips))
+	for _, wip := range wips {
+		if limit, ok := limits[wip.OriginalStatus]; ok {
+			limit := limit
+			wip.WipLimit = &limit
+			wip.OverLimit = wip.WipCount > limit
+		}
+		result = append(result, wip)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Date.Equal(result[j].Date) {
+			return result[i].Date.Before(result[j].Date)
+		}
+		return result[i].OriginalStatus < result[j].OriginalStatus
+	})
+	return result
+}
+
+// Percentile returns the nearest-rank percentile of the values, p is between 0 and 100
+func Percentile(values []int64, p float64) (int64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1], true
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateBoardWipMeta = plugin.SubTaskMeta{
+	Name:             "calculateBoardWip",
+	EntryPoint:       CalculateBoardWip,
+	EnabledByDefault: true,
+	Description:      "Count the issues in each status of the boards per day against the WIP limits, and age the issues in progress",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		ticket.IssueStatusHistory{}.TableName(),
+		ticket.IssueFlowMetric{}.TableName(),
+		ticket.BoardWipLimit{}.TableName(),
+	},
+	ProductTables: []string{
+		ticket.BoardStatusWip{}.TableName(),
+		ticket.BoardWipItem{}.TableName(),
+	},
+}
+
+// projectBoardIds selects the ids of the boards of the project
+const projectBoardIds = "SELECT pm.row_id FROM project_mapping pm WHERE pm.project_name = ? AND pm.table = 'boards'"
+
+// boardIssueIds selects the ids of the issues on the board
+const boardIssueIds = "SELECT issue_id FROM board_issues WHERE board_id = ?"
+
+type wipIssue struct {
+	IssueId             string
+	IssueKey            string
+	Title               string
+	OriginalStatus      string
+	Status              string
+	StartDate           time.Time
+	FirstInProgressDate *time.Time
+}
+
+func CalculateBoardWip(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+	projectName := data.Options.ProjectName
+
+	var boardIds []string
+	err := db.Pluck("row_id", &boardIds, dal.From("project_mapping pm"), dal.Where("pm.project_name = ? AND pm.table = 'boards'", projectName))
+	if err != nil {
+		return err
+	}
+	for _, table := range []dal.Tabler{&ticket.BoardStatusWip{}, &ticket.BoardWipItem{}} {
+		err = db.Delete(table, dal.Where("board_id IN ("+projectBoardIds+")", projectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to delete the previous "+table.TableName())
+		}
+	}
+	wipSaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.BoardStatusWip{}), issueFlowBatchSize)
+	if err != nil {
+		return err
+	}
+	itemSaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&ticket.BoardWipItem{}), issueFlowBatchSize)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -data.Options.WipLookbackDays)
+	taskCtx.SetProgress(0, len(boardIds))
+	for _, boardId := range boardIds {
+		var limits []*ticket.BoardWipLimit
+		err = db.All(&limits, dal.Where("board_id = ?", boardId))
+		if err != nil {
+			return err
+		}
+		limitMap := make(map[string]int, len(limits))
+		for _, limit := range limits {
+			limitMap[limit.OriginalStatus] = limit.WipLimit
+		}
+		// the statuses with a limit are counted even if they are not IN_PROGRESS
+		counted := dal.Where("status = ?", ticket.IN_PROGRESS)
+		if len(limitMap) > 0 {
+			limitedStatuses := make([]string, 0, len(limitMap))
+			for originalStatus := range limitMap {
+				limitedStatuses = append(limitedStatuses, originalStatus)
+			}
+			counted = dal.Where("status != ? AND (status = ? OR original_status IN ?)", ticket.DONE, ticket.IN_PROGRESS, limitedStatuses)
+		}
+		var histories []*ticket.IssueStatusHistory
+		err = db.All(&histories,
+			dal.Where("issue_id IN ("+boardIssueIds+") AND (end_date IS NULL OR end_date > ?)", boardId, from),
+			counted,
+		)
+		if err != nil {
+			return err
+		}
+		for _, wip := range CountDailyWip(boardId, histories, limitMap, from, now) {
+			if err = wipSaver.Add(wip); err != nil {
+				return err
+			}
+		}
+
+		var issues []*wipIssue
+		err = db.All(&issues,
+			dal.Select("h.issue_id, i.issue_key, i.title, h.original_status, h.status, h.start_date, m.first_in_progress_date"),
+			dal.From("issue_status_histories h"),
+			dal.Join("JOIN issues i ON i.id = h.issue_id"),
+			dal.Join("LEFT JOIN issue_flow_metrics m ON m.issue_id = h.issue_id"),
+			dal.Where("h.is_current_status = ? AND h.status = ? AND h.issue_id IN ("+boardIssueIds+")", true, ticket.IN_PROGRESS, boardId),
+		)
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			inProgressDate := issue.FirstInProgressDate
+			if inProgressDate == nil {
+				inProgressDate = &issue.StartDate
+			}
+			err = itemSaver.Add(&ticket.BoardWipItem{
+				BoardId:          boardId,
+				IssueId:          issue.IssueId,
+				IssueKey:         issue.IssueKey,
+				Title:            issue.Title,
+				OriginalStatus:   issue.OriginalStatus,
+				Status:           issue.Status,
+				InProgressDate:   inProgressDate,
+				StatusStartDate:  issue.StartDate,
+				AgeMinutes:       int64(now.Sub(*inProgressDate).Minutes()),
+				StatusAgeMinutes: int64(now.Sub(issue.StartDate).Minutes()),
+				CalculatedDate:   now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		taskCtx.IncProgress(1)
+	}
+	if err = wipSaver.Close(); err != nil {
+		return err
+	}
+	return itemSaver.Close()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func history(issueId, originalStatus, status, start, end string) *ticket.IssueStatusHistory {
+	h := &ticket.IssueStatusHistory{
+		IssueId:        issueId,
+		OriginalStatus: originalStatus,
+		Status:         status,
+		StartDate:      at(start),
+	}
+	if end != "" {
+		endDate := at(end)
+		h.EndDate = &endDate
+	}
+	return h
+}
+
+func TestCountDailyWip(t *testing.T) {
+	histories := []*ticket.IssueStatusHistory{
+		history("1", "In Dev", ticket.IN_PROGRESS, "2024-06-28 09:00", "2024-07-02 10:00"),
+		history("1", "Review", ticket.IN_PROGRESS, "2024-07-02 10:00", ""),
+		history("2", "In Dev", ticket.IN_PROGRESS, "2024-07-01 08:00", "2024-07-01 12:00"),
+		history("2", "Closed", ticket.DONE, "2024-07-01 12:00", ""),
+		history("3", "In Dev", ticket.IN_PROGRESS, "2024-07-02 23:00", ""),
+		// the backlog is not work in progress
+		history("4", "Backlog", ticket.TODO, "2024-06-30 09:00", ""),
+		// the TODO statuses with a limit are counted
+		history("5", "Ready", ticket.TODO, "2024-07-02 09:00", ""),
+		history("5", "Shipped", ticket.DONE, "2024-07-01 09:00", "2024-07-02 09:00"),
+	}
+	limits := map[string]int{"In Dev": 1, "Ready": 2, "Shipped": 1}
+	wips := CountDailyWip("b1", histories, limits, at("2024-07-01 06:00"), at("2024-07-03 08:00"))
+	type count struct {
+		date   string
+		status string
+		count  int
+		over   bool
+	}
+	var counts []count
+	for _, wip := range wips {
+		assert.Equal(t, "b1", wip.BoardId)
+		if limit, ok := limits[wip.OriginalStatus]; ok {
+			assert.Equal(t, limit, *wip.WipLimit)
+		} else {
+			assert.Nil(t, wip.WipLimit)
+		}
+		counts = append(counts, count{wip.Date.Format("01-02"), wip.OriginalStatus, wip.WipCount, wip.OverLimit})
+	}
+	assert.Equal(t, []count{
+		// issue 2 left In Dev before the end of the day
+		{"07-01", "In Dev", 1, false},
+		{"07-02", "In Dev", 1, false},
+		{"07-02", "Ready", 1, false},
+		{"07-02", "Review", 1, false},
+		// the current day is counted up to now
+		{"07-03", "In Dev", 1, false},
+		{"07-03", "Ready", 1, false},
+		{"07-03", "Review", 1, false},
+	}, counts)
+
+	wips = CountDailyWip("b1", histories[:3], map[string]int{"In Dev": 1}, at("2024-06-29 06:00"), at("2024-07-01 10:00"))
+	// issue 2 is still in In Dev at the moment
+	assert.Len(t, wips, 3)
+	assert.Equal(t, 2, wips[2].WipCount)
+	assert.True(t, wips[2].OverLimit)
+}
+
+func TestPercentile(t *testing.T) {
+	_, ok := Percentile(nil, 85)
+	assert.False(t, ok)
+	values := []int64{50, 10, 40, 20, 30}
+	p, ok := Percentile(values, 85)
+	assert.True(t, ok)
+	assert.Equal(t, int64(50), p)
+	p, _ = Percentile(values, 50)
+	assert.Equal(t, int64(30), p)
+	p, _ = Percentile(values, 1)
+	assert.Equal(t, int64(10), p)
+	assert.Equal(t, []int64{50, 10, 40, 20, 30}, values)
+}
@@ -0,0 +1,284 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// StatusMapper maps the original statuses to the standard ones
+type StatusMapper struct {
+	rule    *ticket.StatusRule
+	learned map[string]string
+}
+
+// NewStatusMapper creates a StatusMapper, the rule configured by the user goes first, then the standard status given by
+// the data source, then the standard status learned from the issues currently in the original status
+func NewStatusMapper(rule *ticket.StatusRule, learned map[string]string) *StatusMapper {
+	return &StatusMapper{rule: rule, learned: learned}
+}
+
+func (m *StatusMapper) Map(originalStatus, status string) string {
+	if m.rule != nil {
+		if s := ticket.GetStatus(m.rule, originalStatus); s != "" {
+			return s
+		}
+	}
+	switch status {
+	case ticket.TODO, ticket.IN_PROGRESS, ticket.DONE, ticket.OTHER:
+		return status
+	}
+	if s := m.learned[originalStatus]; s != "" {
+		return s
+	}
+	return ticket.OTHER
+}
+
+// IssueFlow is what FlowCalculator derives from the changelogs of an issue
+type IssueFlow struct {
+	Histories []*ticket.IssueStatusHistory
+	Durations []*ticket.IssueStatusDuration
+	Metric    *ticket.IssueFlowMetric
+}
+
+// FlowCalculator derives the status history and the flow metrics of the issues
+type FlowCalculator struct {
+	mapper          *StatusMapper
+	blockedStatuses map[string]bool
+}
+
+func NewFlowCalculator(mapper *StatusMapper, blockedStatuses []string) *FlowCalculator {
+	c := &FlowCalculator{mapper: mapper, blockedStatuses: make(map[string]bool)}
+	for _, s := range blockedStatuses {
+		c.blockedStatuses[s] = true
+	}
+	return c
+}
+
+type interval struct {
+	start, end time.Time
+}
+
+// Calculate derives the flow of the issue from its status changelogs and the changelogs of the fields marking it
+// as blocked, the periods still going on are measured up to now
+func (c *FlowCalculator) Calculate(issue *ticket.Issue, statusChanges, blockedChanges []*ticket.IssueChangelogs, now time.Time) *IssueFlow {
+	statusChanges = sortChangelogs(statusChanges)
+	start := now
+	if issue.CreatedDate != nil {
+		start = *issue.CreatedDate
+	}
+	originalStatus, status := issue.OriginalStatus, issue.Status
+	if len(statusChanges) > 0 {
+		if statusChanges[0].CreatedDate.Before(start) {
+			start = statusChanges[0].CreatedDate
+		}
+		originalStatus, status = statusChanges[0].OriginalFromValue, statusChanges[0].FromValue
+	}
+
+	flow := &IssueFlow{Metric: &ticket.IssueFlowMetric{IssueId: issue.Id}}
+	current := &ticket.IssueStatusHistory{
+		IssueId:        issue.Id,
+		StartDate:      start,
+		Status:         c.mapper.Map(originalStatus, status),
+		OriginalStatus: originalStatus,
+	}
+	// closes the current period, the ones without length are dropped
+	appendCurrent := func(end *time.Time) {
+		endDate := now
+		if end != nil {
+			endDate = *end
+			if !endDate.After(current.StartDate) {
+				return
+			}
+		}
+		current.EndDate = end
+		if endDate.After(current.StartDate) {
+			current.DurationMinutes = minutes(endDate.Sub(current.StartDate))
+		}
+		if len(flow.Histories) == 0 {
+			current.IsFirstStatus = true
+		} else if flow.Histories[len(flow.Histories)-1].Status == ticket.DONE && current.Status != ticket.DONE {
+			current.Reopened = true
+			flow.Metric.ReopenCount++
+		}
+		flow.Histories = append(flow.Histories, current)
+	}
+	for _, change := range statusChanges {
+		changeDate := change.CreatedDate
+		appendCurrent(&changeDate)
+		current = &ticket.IssueStatusHistory{
+			IssueId:        issue.Id,
+			StartDate:      changeDate,
+			Status:         c.mapper.Map(change.OriginalToValue, change.ToValue),
+			OriginalStatus: change.OriginalToValue,
+		}
+	}
+	appendCurrent(nil)
+	if len(flow.Histories) > 0 {
+		flow.Histories[len(flow.Histories)-1].IsCurrentStatus = true
+	}
+
+	c.aggregate(flow, blockedChanges, now)
+	return flow
+}
+
+func (c *FlowCalculator) aggregate(flow *IssueFlow, blockedChanges []*ticket.IssueChangelogs, now time.Time) {
+	metric := flow.Metric
+	durations := make(map[string]*ticket.IssueStatusDuration)
+	var inProgress, blocked []interval
+	for _, history := range flow.Histories {
+		end := now
+		if history.EndDate != nil {
+			end = *history.EndDate
+		}
+		switch history.Status {
+		case ticket.TODO:
+			metric.TodoMinutes += history.DurationMinutes
+		case ticket.IN_PROGRESS:
+			metric.InProgressMinutes += history.DurationMinutes
+			inProgress = append(inProgress, interval{history.StartDate, end})
+			if metric.FirstInProgressDate == nil {
+				startDate := history.StartDate
+				metric.FirstInProgressDate = &startDate
+			}
+		case ticket.DONE:
+			metric.DoneMinutes += history.DurationMinutes
+		default:
+			metric.OtherMinutes += history.DurationMinutes
+		}
+		if c.blockedStatuses[history.OriginalStatus] {
+			blocked = append(blocked, interval{history.StartDate, end})
+		}
+		duration := durations[history.OriginalStatus]
+		if duration == nil {
+			duration = &ticket.IssueStatusDuration{IssueId: history.IssueId, OriginalStatus: history.OriginalStatus}
+			durations[history.OriginalStatus] = duration
+			flow.Durations = append(flow.Durations, duration)
+		}
+		duration.Status = history.Status
+		duration.DurationMinutes += history.DurationMinutes
+		duration.Times++
+	}
+
+	// the fields like the jira flag mark the issue as blocked while having a value
+	var flaggedSince *time.Time
+	for _, change := range sortChangelogs(blockedChanges) {
+		flagged := change.OriginalToValue != "" || change.ToValue != ""
+		if flagged && flaggedSince == nil {
+			changeDate := change.CreatedDate
+			flaggedSince = &changeDate
+		} else if !flagged && flaggedSince != nil {
+			blocked = append(blocked, interval{*flaggedSince, change.CreatedDate})
+			flaggedSince = nil
+		}
+	}
+	if flaggedSince != nil {
+		blocked = append(blocked, interval{*flaggedSince, now})
+	}
+	blocked = unionIntervals(blocked)
+	metric.BlockedMinutes = minutes(totalDuration(blocked))
+	metric.ActiveMinutes = minutes(totalDuration(inProgress) - overlapDuration(inProgress, blocked))
+
+	last := flow.Histories[len(flow.Histories)-1]
+	if last.Status != ticket.DONE {
+		return
+	}
+	doneDate := last.StartDate
+	metric.DoneDate = &doneDate
+	if metric.FirstInProgressDate == nil || !doneDate.After(*metric.FirstInProgressDate) {
+		return
+	}
+	cycleTime := doneDate.Sub(*metric.FirstInProgressDate)
+	cycleTimeMinutes := minutes(cycleTime)
+	metric.CycleTimeMinutes = &cycleTimeMinutes
+	active := totalDuration(inProgress) - overlapDuration(inProgress, blocked)
+	efficiency := float64(active) / float64(cycleTime)
+	metric.FlowEfficiency = &efficiency
+}
+
+func sortChangelogs(changelogs []*ticket.IssueChangelogs) []*ticket.IssueChangelogs {
+	sorted := make([]*ticket.IssueChangelogs, len(changelogs))
+	copy(sorted, changelogs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedDate.Before(sorted[j].CreatedDate)
+	})
+	return sorted
+}
+
+func minutes(d time.Duration) int64 {
+	return int64(d / time.Minute)
+}
+
+// unionIntervals merges the overlapping intervals and sorts them
+func unionIntervals(intervals []interval) []interval {
+	sorted := make([]interval, 0, len(intervals))
+	for _, in := range intervals {
+		if in.end.After(in.start) {
+			sorted = append(sorted, in)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].start.Before(sorted[j].start)
+	})
+	var merged []interval
+	for _, in := range sorted {
+		if len(merged) > 0 && !in.start.After(merged[len(merged)-1].end) {
+			if in.end.After(merged[len(merged)-1].end) {
+				merged[len(merged)-1].end = in.end
+			}
+			continue
+		}
+		merged = append(merged, in)
+	}
+	return merged
+}
+
+func totalDuration(intervals []interval) time.Duration {
+	var total time.Duration
+	for _, in := range intervals {
+		total += in.end.Sub(in.start)
+	}
+	return total
+}
+
+// overlapDuration returns how long the two sorted and non-overlapping lists of intervals overlap
+func overlapDuration(a, b []interval) time.Duration {
+	var total time.Duration
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start, end := a[i].start, a[i].end
+		if b[j].start.After(start) {
+			start = b[j].start
+		}
+		if b[j].end.Before(end) {
+			end = b[j].end
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+		if a[i].end.Before(b[j].end) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return total
+}
@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateIssueFlowMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueFlow",
+	EntryPoint:       CalculateIssueFlow,
+	EnabledByDefault: true,
+	Description:      "Derive the time the issues spent in each status, the blocked time and the flow efficiency from the changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{ticket.Issue{}.TableName(), ticket.IssueChangelogs{}.TableName()},
+	ProductTables: []string{
+		ticket.IssueStatusHistory{}.TableName(),
+		ticket.IssueStatusDuration{}.TableName(),
+		ticket.IssueFlowMetric{}.TableName(),
+	},
+}
+
+const issueFlowBatchSize = 500
+
+// projectIssueIds selects the ids of the issues on the boards of the project
+const projectIssueIds = `SELECT bi.issue_id FROM board_issues bi
+	JOIN project_mapping pm ON pm.table = 'boards' AND pm.row_id = bi.board_id
+	WHERE pm.project_name = ?`
+
+type statusCount struct {
+	OriginalStatus string
+	Status         string
+	Count          int
+}
+
+func CalculateIssueFlow(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*IssueTraceTaskData)
+	projectName := data.Options.ProjectName
+
+	for _, table := range []dal.Tabler{&ticket.IssueStatusHistory{}, &ticket.IssueStatusDuration{}, &ticket.IssueFlowMetric{}} {
+		err := db.Delete(table, dal.Where("issue_id IN ("+projectIssueIds+")", projectName))
+		if err != nil {
+			return errors.Default.Wrap(err, "failed to delete the previous "+table.TableName())
+		}
+	}
+
+	// the standard status most issues in the original status are mapped to
+	var statusCounts []statusCount
+	err := db.All(&statusCounts,
+		dal.Select("original_status, status, COUNT(*) AS count"),
+		dal.From(&ticket.Issue{}),
+		dal.Where("original_status != '' AND status != ''"),
+		dal.Groupby("original_status, status"),
+	)
+	if err != nil {
+		return err
+	}
+	learned := make(map[string]string)
+	maxCounts := make(map[string]int)
+	for _, sc := range statusCounts {
+		if sc.Count > maxCounts[sc.OriginalStatus] {
+			maxCounts[sc.OriginalStatus] = sc.Count
+			learned[sc.OriginalStatus] = sc.Status
+		}
+	}
+	calculator := NewFlowCalculator(NewStatusMapper(data.Options.StatusRule(), learned), data.Options.BlockedStatuses)
+	blockedFields := make(map[string]bool)
+	for _, field := range data.Options.BlockedFields {
+		blockedFields[field] = true
+	}
+
+	issueClauses := []dal.Clause{
+		dal.From(&ticket.Issue{}),
+		dal.Where("id IN ("+projectIssueIds+")", projectName),
+	}
+	count, err := db.Count(issueClauses...)
+	if err != nil {
+		return err
+	}
+	taskCtx.SetProgress(0, int(count))
+	cursor, err := db.Cursor(append(issueClauses, dal.Orderby("id"))...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	savers := make(map[reflect.Type]*api.BatchSave)
+	for _, t := range []interface{}{&ticket.IssueStatusHistory{}, &ticket.IssueStatusDuration{}, &ticket.IssueFlowMetric{}} {
+		savers[reflect.TypeOf(t)], err = api.NewBatchSave(taskCtx, reflect.TypeOf(t), issueFlowBatchSize)
+		if err != nil {
+			return err
+		}
+	}
+	save := func(item interface{}) errors.Error {
+		return savers[reflect.TypeOf(item)].Add(item)
+	}
+
+	now := time.Now()
+	calculateBatch := func(issues []*ticket.Issue) errors.Error {
+		issueIds := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			issueIds = append(issueIds, issue.Id)
+		}
+		var changelogs []*ticket.IssueChangelogs
+		err := db.All(&changelogs,
+			dal.Where("issue_id IN ? AND (LOWER(field_name) = 'status' OR field_name IN ?)", issueIds, data.Options.BlockedFields),
+			dal.Orderby("created_date"),
+		)
+		if err != nil {
+			return err
+		}
+		statusChanges := make(map[string][]*ticket.IssueChangelogs)
+		blockedChanges := make(map[string][]*ticket.IssueChangelogs)
+		for _, changelog := range changelogs {
+			if strings.EqualFold(changelog.FieldName, "status") {
+				statusChanges[changelog.IssueId] = append(statusChanges[changelog.IssueId], changelog)
+			} else if blockedFields[changelog.FieldName] {
+				blockedChanges[changelog.IssueId] = append(blockedChanges[changelog.IssueId], changelog)
+			}
+		}
+		for _, issue := range issues {
+			flow := calculator.Calculate(issue, statusChanges[issue.Id], blockedChanges[issue.Id], now)
+			for _, history := range flow.Histories {
+				if err := save(history); err != nil {
+					return err
+				}
+			}
+			for _, duration := range flow.Durations {
+				if err := save(duration); err != nil {
+					return err
+				}
+			}
+			if err := save(flow.Metric); err != nil {
+				return err
+			}
+		}
+		taskCtx.IncProgress(len(issues))
+		return nil
+	}
+
+	var batch []*ticket.Issue
+	for cursor.Next() {
+		issue := &ticket.Issue{}
+		if err = db.Fetch(cursor, issue); err != nil {
+			return err
+		}
+		batch = append(batch, issue)
+		if len(batch) == issueFlowBatchSize {
+			if err = calculateBatch(batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		if err = calculateBatch(batch); err != nil {
+			return err
+		}
+	}
+	for _, saver := range savers {
+		if err = saver.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
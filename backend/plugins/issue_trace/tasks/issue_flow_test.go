@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func statusChange(date, from, fromStd, to, toStd string) *ticket.IssueChangelogs {
+	return &ticket.IssueChangelogs{
+		FieldName:         "status",
+		OriginalFromValue: from,
+		FromValue:         fromStd,
+		OriginalToValue:   to,
+		ToValue:           toStd,
+		CreatedDate:       at(date),
+	}
+}
+
+func TestStatusMapper(t *testing.T) {
+	mapper := NewStatusMapper(
+		&ticket.StatusRule{Done: []string{"Verified"}},
+		map[string]string{"active": ticket.IN_PROGRESS, "Verified": ticket.IN_PROGRESS},
+	)
+	assert.Equal(t, ticket.DONE, mapper.Map("Verified", ticket.IN_PROGRESS))
+	assert.Equal(t, ticket.TODO, mapper.Map("active", ticket.TODO))
+	assert.Equal(t, ticket.IN_PROGRESS, mapper.Map("active", "active"))
+	assert.Equal(t, ticket.OTHER, mapper.Map("unknown", ""))
+}
+
+func TestFlowCalculator(t *testing.T) {
+	mapper := NewStatusMapper(
+		&ticket.StatusRule{InProgress: []string{"Reopened"}},
+		map[string]string{"Blocked": ticket.IN_PROGRESS},
+	)
+	calculator := NewFlowCalculator(mapper, []string{"Blocked"})
+	created := at("2024-01-01 00:00")
+	issue := &ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "i1"},
+		CreatedDate:    &created,
+		OriginalStatus: "Closed",
+		Status:         ticket.DONE,
+	}
+	// out of order on purpose
+	statusChanges := []*ticket.IssueChangelogs{
+		statusChange("2024-01-04 00:00", "In Dev", ticket.IN_PROGRESS, "Closed", ticket.DONE),
+		statusChange("2024-01-02 00:00", "Open", ticket.TODO, "In Dev", ticket.IN_PROGRESS),
+		statusChange("2024-01-03 00:00", "In Dev", ticket.IN_PROGRESS, "Blocked", ""),
+		statusChange("2024-01-03 12:00", "Blocked", "", "In Dev", ticket.IN_PROGRESS),
+		statusChange("2024-01-05 00:00", "Closed", ticket.DONE, "Reopened", ""),
+		statusChange("2024-01-06 00:00", "Reopened", "", "Closed", ticket.DONE),
+	}
+	blockedChanges := []*ticket.IssueChangelogs{
+		{FieldName: "Flagged", OriginalToValue: "Impediment", CreatedDate: at("2024-01-05 06:00")},
+		{FieldName: "Flagged", OriginalFromValue: "Impediment", CreatedDate: at("2024-01-05 12:00")},
+	}
+	flow := calculator.Calculate(issue, statusChanges, blockedChanges, at("2024-01-10 00:00"))
+
+	type period struct {
+		start, status, originalStatus string
+		minutes                       int64
+		reopened                      bool
+	}
+	var periods []period
+	for _, h := range flow.Histories {
+		periods = append(periods, period{h.StartDate.Format("01-02 15:04"), h.Status, h.OriginalStatus, h.DurationMinutes, h.Reopened})
+	}
+	assert.Equal(t, []period{
+		{"01-01 00:00", ticket.TODO, "Open", 1440, false},
+		{"01-02 00:00", ticket.IN_PROGRESS, "In Dev", 1440, false},
+		{"01-03 00:00", ticket.IN_PROGRESS, "Blocked", 720, false},
+		{"01-03 12:00", ticket.IN_PROGRESS, "In Dev", 720, false},
+		{"01-04 00:00", ticket.DONE, "Closed", 1440, false},
+		{"01-05 00:00", ticket.IN_PROGRESS, "Reopened", 1440, true},
+		{"01-06 00:00", ticket.DONE, "Closed", 5760, false},
+	}, periods)
+	assert.True(t, flow.Histories[0].IsFirstStatus)
+	assert.True(t, flow.Histories[6].IsCurrentStatus)
+	assert.Nil(t, flow.Histories[6].EndDate)
+	assert.False(t, flow.Histories[5].IsCurrentStatus)
+
+	durations := make(map[string][2]int64)
+	for _, d := range flow.Durations {
+		durations[d.OriginalStatus] = [2]int64{d.DurationMinutes, int64(d.Times)}
+	}
+	assert.Equal(t, map[string][2]int64{
+		"Open":     {1440, 1},
+		"In Dev":   {2160, 2},
+		"Blocked":  {720, 1},
+		"Closed":   {7200, 2},
+		"Reopened": {1440, 1},
+	}, durations)
+
+	metric := flow.Metric
+	assert.Equal(t, int64(1440), metric.TodoMinutes)
+	assert.Equal(t, int64(4320), metric.InProgressMinutes)
+	assert.Equal(t, int64(7200), metric.DoneMinutes)
+	assert.Equal(t, int64(0), metric.OtherMinutes)
+	assert.Equal(t, int64(1080), metric.BlockedMinutes)
+	assert.Equal(t, int64(3240), metric.ActiveMinutes)
+	assert.Equal(t, 1, metric.ReopenCount)
+	assert.Equal(t, at("2024-01-02 00:00"), *metric.FirstInProgressDate)
+	assert.Equal(t, at("2024-01-06 00:00"), *metric.DoneDate)
+	assert.Equal(t, int64(5760), *metric.CycleTimeMinutes)
+	assert.InDelta(t, 0.5625, *metric.FlowEfficiency, 1e-9)
+}
+
+func TestFlowCalculatorWithoutChangelogs(t *testing.T) {
+	calculator := NewFlowCalculator(NewStatusMapper(nil, nil), nil)
+	created := at("2024-01-01 00:00")
+	flow := calculator.Calculate(&ticket.Issue{
+		DomainEntity:   domainlayer.DomainEntity{Id: "i2"},
+		CreatedDate:    &created,
+		OriginalStatus: "Doing",
+		Status:         ticket.IN_PROGRESS,
+	}, nil, nil, at("2024-01-02 00:00"))
+
+	assert.Len(t, flow.Histories, 1)
+	assert.True(t, flow.Histories[0].IsFirstStatus)
+	assert.True(t, flow.Histories[0].IsCurrentStatus)
+	assert.Equal(t, int64(1440), flow.Histories[0].DurationMinutes)
+	assert.Equal(t, int64(1440), flow.Metric.ActiveMinutes)
+	assert.Nil(t, flow.Metric.DoneDate)
+	assert.Nil(t, flow.Metric.CycleTimeMinutes)
+	assert.Nil(t, flow.Metric.FlowEfficiency)
+}
+
+func TestUnionIntervals(t *testing.T) {
+	merged := unionIntervals([]interval{
+		{at("2024-01-03 00:00"), at("2024-01-04 00:00")},
+		{at("2024-01-01 00:00"), at("2024-01-02 00:00")},
+		{at("2024-01-01 12:00"), at("2024-01-02 12:00")},
+		{at("2024-01-05 00:00"), at("2024-01-05 00:00")},
+	})
+	assert.Equal(t, []interval{
+		{at("2024-01-01 00:00"), at("2024-01-02 12:00")},
+		{at("2024-01-03 00:00"), at("2024-01-04 00:00")},
+	}, merged)
+	assert.Equal(t, 24*time.Hour, overlapDuration(merged, []interval{
+		{at("2024-01-02 00:00"), at("2024-01-03 12:00")},
+	}))
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// DefaultBlockedFields are the changelog fields marking the issues as blocked while having a value
+var DefaultBlockedFields = []string{"Flagged"}
+
+// DefaultWipLookbackDays is how many days back the daily WIP of the boards is counted
+const DefaultWipLookbackDays = 90
+
+type IssueTraceOptions struct {
+	ProjectName string `json:"projectName" mapstructure:"projectName"`
+	// the original statuses mapped to the standard ones, they take precedence over the mapping of the data sources
+	TodoStatuses       []string `json:"todoStatuses" mapstructure:"todoStatuses"`
+	InProgressStatuses []string `json:"inProgressStatuses" mapstructure:"inProgressStatuses"`
+	DoneStatuses       []string `json:"doneStatuses" mapstructure:"doneStatuses"`
+	// BlockedStatuses are the original statuses in which the issues are blocked
+	BlockedStatuses []string `json:"blockedStatuses" mapstructure:"blockedStatuses"`
+	// BlockedFields are the changelog fields marking the issues as blocked while having a value
+	BlockedFields []string `json:"blockedFields" mapstructure:"blockedFields"`
+	// WipLookbackDays is how many days back the daily WIP of the boards is counted
+	WipLookbackDays int `json:"wipLookbackDays" mapstructure:"wipLookbackDays"`
+}
+
+func (op *IssueTraceOptions) StatusRule() *ticket.StatusRule {
+	return &ticket.StatusRule{
+		Todo:       op.TodoStatuses,
+		InProgress: op.InProgressStatuses,
+		Done:       op.DoneStatuses,
+	}
+}
+
+type IssueTraceTaskData struct {
+	Options *IssueTraceOptions
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*IssueTraceOptions, errors.Error) {
+	var op IssueTraceOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error decoding issue_trace task options")
+	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required for issue_trace")
+	}
+	if op.BlockedFields == nil {
+		op.BlockedFields = DefaultBlockedFields
+	}
+	if op.WipLookbackDays <= 0 {
+		op.WipLookbackDays = DefaultWipLookbackDays
+	}
+	return &op, nil
+}
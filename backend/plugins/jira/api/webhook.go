@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/spf13/cast"
+)
+
+type jiraWebhookPayload struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Issue        *struct {
+		Id     string `json:"id"`
+		Fields struct {
+			Project struct {
+				Id string `json:"id"`
+			} `json:"project"`
+		} `json:"fields"`
+	} `json:"issue"`
+	Sprint *struct {
+		OriginBoardId uint64 `json:"originBoardId"`
+	} `json:"sprint"`
+}
+
+// ParseSourceWebhook verifies the X-Hub-Signature header and maps the event to the boards containing the issue or sprint
+func ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	err := api.VerifyHmacSha256Signature(secret, body, header.Get("X-Hub-Signature"))
+	if err != nil {
+		return nil, err
+	}
+	payload := &jiraWebhookPayload{}
+	if e := json.Unmarshal(body, payload); e != nil {
+		return nil, errors.BadInput.Wrap(e, "failed to decode the jira webhook payload")
+	}
+	event := &plugin.SourceWebhookEvent{Name: payload.WebhookEvent}
+	var boardIds []uint64
+	switch {
+	case payload.Sprint != nil && strings.HasPrefix(event.Name, "sprint_"):
+		boardIds = append(boardIds, payload.Sprint.OriginBoardId)
+	case payload.Issue != nil:
+		// boards already containing the issue, and boards of the project for new issues
+		db := basicRes.GetDal()
+		err = db.Pluck("board_id", &boardIds,
+			dal.From(&models.JiraBoardIssue{}),
+			dal.Where("connection_id = ? AND issue_id = ?", connectionId, cast.ToUint64(payload.Issue.Id)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		var projectBoardIds []uint64
+		err = db.Pluck("board_id", &projectBoardIds,
+			dal.From(&models.JiraBoard{}),
+			dal.Where("connection_id = ? AND project_id = ?", connectionId, cast.ToUint64(payload.Issue.Fields.Project.Id)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		boardIds = append(boardIds, projectBoardIds...)
+	}
+	seen := make(map[uint64]bool)
+	for _, boardId := range boardIds {
+		if boardId == 0 || seen[boardId] {
+			continue
+		}
+		seen[boardId] = true
+		event.ScopeIds = append(event.ScopeIds, fmt.Sprintf("%d", boardId))
+	}
+	return event, nil
+}
@@ -41,6 +41,7 @@ var _ interface {
 	plugin.PluginModel
 	plugin.PluginMigration
 	plugin.DataSourcePluginBlueprintV200
+	plugin.DataSourcePluginWebhook
 	plugin.CloseablePluginTask
 	plugin.PluginSource
 } = (*Jira)(nil)
@@ -90,6 +91,16 @@ func (p Jira) GetTablesInfo() []dal.Tabler {
 		&models.JiraIssueComment{},
 		&models.JiraIssueRelationship{},
 		&models.JiraScopeConfig{},
+		&models.JiraVersion{},
+		&models.JiraIssueVersion{},
+		&models.JiraComponent{},
+		&models.JiraIssueComponent{},
+		&models.JiraServiceDesk{},
+		&models.JiraOrganization{},
+		&models.JiraServiceDeskOrganization{},
+		&models.JiraRequestType{},
+		&models.JiraServiceRequest{},
+		&models.JiraIssueSlaCycle{},
 	}
 }
 
@@ -137,6 +148,19 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectEpicsMeta,
 		tasks.ExtractEpicsMeta,
 
+		tasks.CollectVersionsMeta,
+		tasks.ExtractVersionsMeta,
+
+		tasks.CollectComponentsMeta,
+		tasks.ExtractComponentsMeta,
+
+		tasks.CollectServiceDesksMeta,
+		tasks.ExtractServiceDesksMeta,
+		tasks.CollectOrganizationsMeta,
+		tasks.ExtractOrganizationsMeta,
+		tasks.CollectServiceRequestsMeta,
+		tasks.ExtractServiceRequestsMeta,
+
 		tasks.CollectAccountsMeta,
 
 		tasks.ConvertBoardMeta,
@@ -146,9 +170,15 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertWorklogsMeta,
 		tasks.ConvertIssueChangelogsMeta,
 		tasks.ConvertIssueRelationshipsMeta,
+		tasks.ConvertVersionsMeta,
+		tasks.ConvertIssueVersionsMeta,
+		tasks.ConvertComponentsMeta,
+		tasks.ConvertIssueComponentsMeta,
+		tasks.ConvertServiceRequestsMeta,
 
 		tasks.ConvertSprintsMeta,
 		tasks.ConvertSprintIssuesMeta,
+		tasks.TrackSprintScopeMeta,
 
 		tasks.CollectDevelopmentPanelMeta,
 		tasks.ExtractDevelopmentPanelMeta,
@@ -257,6 +287,10 @@ func (p Jira) MakeDataSourcePipelinePlanV200(
 	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
 }
 
+func (p Jira) ParseSourceWebhook(connectionId uint64, secret string, header http.Header, body []byte) (*plugin.SourceWebhookEvent, errors.Error) {
+	return api.ParseSourceWebhook(connectionId, secret, header, body)
+}
+
 func (p Jira) RootPkgPath() string {
 	return "github.com/apache/incubator-devlake/plugins/jira"
 }
@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/jira/models/migrationscripts/archived"
+)
+
+type addVersionsAndComponents struct{}
+
+func (script *addVersionsAndComponents) Up(basicRes context.BasicRes) errors.Error {
+	err := migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.JiraVersion{},
+		&archived.JiraIssueVersion{},
+		&archived.JiraComponent{},
+		&archived.JiraIssueComponent{},
+	)
+	if err != nil {
+		return err
+	}
+	// force full issue extraction so the issue versions and components can be extracted
+	return basicRes.GetDal().Exec("DELETE FROM _devlake_subtask_states WHERE plugin = ? AND subtask = ?", "jira", "extractIssues")
+}
+
+func (*addVersionsAndComponents) Version() uint64 {
+	return 20240710153021
+}
+
+func (*addVersionsAndComponents) Name() string {
+	return "add _tool_jira_versions, _tool_jira_issue_versions, _tool_jira_components and _tool_jira_issue_components tables"
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/jira/models/migrationscripts/archived"
+)
+
+type scopeConfig20240715 struct {
+	RequestTypeMappings map[string]string `gorm:"type:json;serializer:json"`
+}
+
+func (scopeConfig20240715) TableName() string {
+	return "_tool_jira_scope_configs"
+}
+
+type addServiceDeskTables struct{}
+
+func (script *addServiceDeskTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&scopeConfig20240715{},
+		&archived.JiraServiceDesk{},
+		&archived.JiraOrganization{},
+		&archived.JiraServiceDeskOrganization{},
+		&archived.JiraRequestType{},
+		&archived.JiraServiceRequest{},
+		&archived.JiraIssueSlaCycle{},
+	)
+}
+
+func (*addServiceDeskTables) Version() uint64 {
+	return 20240715104512
+}
+
+func (*addServiceDeskTables) Name() string {
+	return "add service management tables and request_type_mappings to _tool_jira_scope_configs"
+}
@@ -48,5 +48,7 @@ func All() []plugin.MigrationScript {
 		new(addComponents20230412),
 		new(addFilterJQL),
 		new(addWorklogToIssue),
+		new(addVersionsAndComponents),
+		new(addServiceDeskTables),
 	}
 }
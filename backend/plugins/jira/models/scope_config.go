@@ -18,6 +18,7 @@ limitations under the License.
 package models
 
 import (
+	"fmt"
 	"regexp"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -48,6 +49,7 @@ type JiraScopeConfig struct {
 	RemotelinkRepoPattern      []CommitUrlPattern     `mapstructure:"remotelinkRepoPattern,omitempty" json:"remotelinkRepoPattern" gorm:"type:json;serializer:json"`
 	TypeMappings               map[string]TypeMapping `mapstructure:"typeMappings,omitempty" json:"typeMappings" gorm:"type:json;serializer:json"`
 	ApplicationType            string                 `mapstructure:"applicationType,omitempty" json:"applicationType" gorm:"type:varchar(255)"`
+	RequestTypeMappings        map[string]string      `mapstructure:"requestTypeMappings,omitempty" json:"requestTypeMappings" gorm:"type:json;serializer:json"`
 }
 
 func (r *JiraScopeConfig) SetConnectionId(c *JiraScopeConfig, connectionId uint64) {
@@ -72,6 +74,11 @@ func (r *JiraScopeConfig) Validate() errors.Error {
 			return errors.Convert(err)
 		}
 	}
+	for requestType, stdType := range r.RequestTypeMappings {
+		if stdType == "" {
+			return errors.BadInput.New(fmt.Sprintf("empty standard type for request type %s in requestTypeMappings", requestType))
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type JiraServiceDesk struct {
+	common.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	ServiceDeskId uint64 `gorm:"primaryKey"`
+	ProjectId     uint64
+	ProjectKey    string `gorm:"type:varchar(255)"`
+	ProjectName   string `gorm:"type:varchar(255)"`
+}
+
+func (JiraServiceDesk) TableName() string {
+	return "_tool_jira_service_desks"
+}
+
+type JiraOrganization struct {
+	common.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	OrganizationId uint64 `gorm:"primaryKey"`
+	Name           string `gorm:"type:varchar(255)"`
+}
+
+func (JiraOrganization) TableName() string {
+	return "_tool_jira_organizations"
+}
+
+type JiraServiceDeskOrganization struct {
+	common.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ServiceDeskId  uint64 `gorm:"primaryKey"`
+	OrganizationId uint64 `gorm:"primaryKey"`
+}
+
+func (JiraServiceDeskOrganization) TableName() string {
+	return "_tool_jira_service_desk_organizations"
+}
+
+type JiraRequestType struct {
+	common.NoPKModel
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	RequestTypeId uint64 `gorm:"primaryKey"`
+	ServiceDeskId uint64
+	IssueTypeId   string `gorm:"type:varchar(255)"`
+	Name          string `gorm:"type:varchar(255)"`
+	Description   string
+}
+
+func (JiraRequestType) TableName() string {
+	return "_tool_jira_request_types"
+}
+
+// JiraServiceRequest is the customer request side of an issue in a service desk project
+type JiraServiceRequest struct {
+	common.NoPKModel
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	IssueId        uint64 `gorm:"primaryKey"`
+	IssueKey       string `gorm:"type:varchar(255)"`
+	ServiceDeskId  uint64
+	RequestTypeId  uint64
+	CurrentStatus  string `gorm:"type:varchar(255)"`
+	StatusCategory string `gorm:"type:varchar(100)"`
+	CreatedDate    *time.Time
+}
+
+func (JiraServiceRequest) TableName() string {
+	return "_tool_jira_service_requests"
+}
+
+// JiraIssueSlaCycle is a completed or the ongoing cycle of an SLA metric of an issue, like time to first response
+type JiraIssueSlaCycle struct {
+	common.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	IssueId      uint64 `gorm:"primaryKey"`
+	SlaId        uint64 `gorm:"primaryKey"`
+	// completed cycles are numbered from 1, the ongoing cycle comes last
+	CycleNo          int    `gorm:"primaryKey"`
+	SlaName          string `gorm:"type:varchar(255)"`
+	Ongoing          bool
+	Breached         bool
+	Paused           bool
+	StartTime        *time.Time
+	StopTime         *time.Time
+	BreachTime       *time.Time
+	GoalMinutes      *int64
+	ElapsedMinutes   int64
+	RemainingMinutes int64
+}
+
+func (JiraIssueSlaCycle) TableName() string {
+	return "_tool_jira_issue_sla_cycles"
+}
@@ -23,3 +23,11 @@ type Input struct {
 	IssueId    uint64    `json:"issue_id"`
 	UpdateTime time.Time `json:"update_time"`
 }
+
+type ProjectInput struct {
+	ProjectId uint64 `json:"project_id"`
+}
+
+type ServiceDeskInput struct {
+	ServiceDeskId uint64 `json:"service_desk_id"`
+}
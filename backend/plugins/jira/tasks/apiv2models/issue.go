@@ -62,7 +62,7 @@ type Issue struct {
 				Three2X32 string `json:"32x32"`
 			} `json:"avatarUrls"`
 		} `json:"project"`
-		FixVersions        []interface{}       `json:"fixVersions"`
+		FixVersions        []Version           `json:"fixVersions"`
 		Aggregatetimespent interface{}         `json:"aggregatetimespent"`
 		Resolution         interface{}         `json:"resolution"`
 		Resolutiondate     *common.Iso8601Time `json:"resolutiondate"`
@@ -94,7 +94,7 @@ type Issue struct {
 		Labels                        []string           `json:"labels"`
 		Timeestimate                  interface{}        `json:"timeestimate"`
 		Aggregatetimeoriginalestimate interface{}        `json:"aggregatetimeoriginalestimate"`
-		Versions                      []interface{}      `json:"versions"`
+		Versions                      []Version          `json:"versions"`
 		Issuelinks                    []IssueLink        `json:"issuelinks"`
 		Assignee                      *Account           `json:"assignee"`
 		Updated                       common.Iso8601Time `json:"updated"`
@@ -112,13 +112,9 @@ type Issue struct {
 				Name      string `json:"name"`
 			} `json:"statusCategory"`
 		} `json:"status"`
-		Components []struct {
-			Self string `json:"self"`
-			ID   string `json:"id"`
-			Name string `json:"name"`
-		} `json:"components"`
-		Timeoriginalestimate *int64 `json:"timeoriginalestimate"`
-		Description          string `json:"description"`
+		Components           []Component `json:"components"`
+		Timeoriginalestimate *int64      `json:"timeoriginalestimate"`
+		Description          string      `json:"description"`
 		Timetracking         *struct {
 			RemainingEstimate        string `json:"remainingEstimate"`
 			TimeSpent                string `json:"timeSpent"`
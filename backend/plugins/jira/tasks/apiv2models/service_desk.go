@@ -0,0 +1,189 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+type ServiceDesk struct {
+	ID          uint64 `json:"id,string"`
+	ProjectId   uint64 `json:"projectId,string"`
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+}
+
+func (s ServiceDesk) ToToolLayer(connectionId uint64) *models.JiraServiceDesk {
+	return &models.JiraServiceDesk{
+		ConnectionId:  connectionId,
+		ServiceDeskId: s.ID,
+		ProjectId:     s.ProjectId,
+		ProjectKey:    s.ProjectKey,
+		ProjectName:   s.ProjectName,
+	}
+}
+
+type Organization struct {
+	ID   uint64 `json:"id,string"`
+	Name string `json:"name"`
+}
+
+func (o Organization) ToToolLayer(connectionId uint64) *models.JiraOrganization {
+	return &models.JiraOrganization{
+		ConnectionId:   connectionId,
+		OrganizationId: o.ID,
+		Name:           o.Name,
+	}
+}
+
+type RequestType struct {
+	ID            uint64 `json:"id,string"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	IssueTypeId   string `json:"issueTypeId"`
+	ServiceDeskId uint64 `json:"serviceDeskId,string"`
+}
+
+func (r RequestType) ToToolLayer(connectionId uint64) *models.JiraRequestType {
+	return &models.JiraRequestType{
+		ConnectionId:  connectionId,
+		RequestTypeId: r.ID,
+		ServiceDeskId: r.ServiceDeskId,
+		IssueTypeId:   r.IssueTypeId,
+		Name:          r.Name,
+		Description:   r.Description,
+	}
+}
+
+// ServiceDeskDate is how the servicedesk api presents dates
+type ServiceDeskDate struct {
+	Iso8601     string `json:"iso8601"`
+	EpochMillis int64  `json:"epochMillis"`
+}
+
+func (d *ServiceDeskDate) ToNullableTime() *time.Time {
+	if d == nil || d.EpochMillis == 0 {
+		return nil
+	}
+	t := time.UnixMilli(d.EpochMillis).UTC()
+	return &t
+}
+
+type ServiceDeskDuration struct {
+	Millis int64 `json:"millis"`
+}
+
+func (d *ServiceDeskDuration) minutes() int64 {
+	if d == nil {
+		return 0
+	}
+	return d.Millis / 60000
+}
+
+type SlaCycle struct {
+	StartTime     *ServiceDeskDate     `json:"startTime"`
+	StopTime      *ServiceDeskDate     `json:"stopTime"`
+	BreachTime    *ServiceDeskDate     `json:"breachTime"`
+	Breached      bool                 `json:"breached"`
+	Paused        bool                 `json:"paused"`
+	GoalDuration  *ServiceDeskDuration `json:"goalDuration"`
+	ElapsedTime   *ServiceDeskDuration `json:"elapsedTime"`
+	RemainingTime *ServiceDeskDuration `json:"remainingTime"`
+}
+
+func (c SlaCycle) toToolLayer(connectionId uint64, issueId uint64, sla *Sla, cycleNo int) *models.JiraIssueSlaCycle {
+	cycle := &models.JiraIssueSlaCycle{
+		ConnectionId:     connectionId,
+		IssueId:          issueId,
+		SlaId:            sla.ID,
+		CycleNo:          cycleNo,
+		SlaName:          sla.Name,
+		Breached:         c.Breached,
+		Paused:           c.Paused,
+		StartTime:        c.StartTime.ToNullableTime(),
+		StopTime:         c.StopTime.ToNullableTime(),
+		BreachTime:       c.BreachTime.ToNullableTime(),
+		ElapsedMinutes:   c.ElapsedTime.minutes(),
+		RemainingMinutes: c.RemainingTime.minutes(),
+	}
+	if c.GoalDuration != nil {
+		goal := c.GoalDuration.minutes()
+		cycle.GoalMinutes = &goal
+	}
+	return cycle
+}
+
+type Sla struct {
+	ID              uint64     `json:"id,string"`
+	Name            string     `json:"name"`
+	CompletedCycles []SlaCycle `json:"completedCycles"`
+	OngoingCycle    *SlaCycle  `json:"ongoingCycle"`
+}
+
+// ServiceRequest is the customer request of an issue, expanded with its request type and SLA
+type ServiceRequest struct {
+	IssueId       uint64           `json:"issueId,string"`
+	IssueKey      string           `json:"issueKey"`
+	RequestTypeId uint64           `json:"requestTypeId,string"`
+	ServiceDeskId uint64           `json:"serviceDeskId,string"`
+	CreatedDate   *ServiceDeskDate `json:"createdDate"`
+	CurrentStatus *struct {
+		Status         string `json:"status"`
+		StatusCategory string `json:"statusCategory"`
+	} `json:"currentStatus"`
+	RequestType *RequestType `json:"requestType"`
+	Sla         *struct {
+		Values []Sla `json:"values"`
+	} `json:"sla"`
+}
+
+func (r ServiceRequest) ExtractEntities(connectionId uint64) (*models.JiraServiceRequest, *models.JiraRequestType, []*models.JiraIssueSlaCycle) {
+	request := &models.JiraServiceRequest{
+		ConnectionId:  connectionId,
+		IssueId:       r.IssueId,
+		IssueKey:      r.IssueKey,
+		ServiceDeskId: r.ServiceDeskId,
+		RequestTypeId: r.RequestTypeId,
+		CreatedDate:   r.CreatedDate.ToNullableTime(),
+	}
+	if r.CurrentStatus != nil {
+		request.CurrentStatus = r.CurrentStatus.Status
+		request.StatusCategory = r.CurrentStatus.StatusCategory
+	}
+	var requestType *models.JiraRequestType
+	if r.RequestType != nil {
+		requestType = r.RequestType.ToToolLayer(connectionId)
+	}
+	var cycles []*models.JiraIssueSlaCycle
+	if r.Sla != nil {
+		for i := range r.Sla.Values {
+			sla := &r.Sla.Values[i]
+			for j, c := range sla.CompletedCycles {
+				cycles = append(cycles, c.toToolLayer(connectionId, r.IssueId, sla, j+1))
+			}
+			if sla.OngoingCycle != nil {
+				cycle := sla.OngoingCycle.toToolLayer(connectionId, r.IssueId, sla, len(sla.CompletedCycles)+1)
+				cycle.Ongoing = true
+				cycles = append(cycles, cycle)
+			}
+		}
+	}
+	return request, requestType, cycles
+}
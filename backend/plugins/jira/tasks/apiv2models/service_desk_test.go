@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceRequest_ExtractEntities(t *testing.T) {
+	var serviceRequest ServiceRequest
+	err := json.Unmarshal([]byte(`{
+		"issueId": "107001",
+		"issueKey": "HELPDESK-1",
+		"requestTypeId": "25",
+		"serviceDeskId": "10",
+		"createdDate": {"iso8601": "2024-03-01T10:00:00+0000", "epochMillis": 1709287200000},
+		"currentStatus": {"status": "Waiting for support", "statusCategory": "NEW"},
+		"requestType": {"id": "25", "name": "Report an incident", "issueTypeId": "10004", "serviceDeskId": "10"},
+		"sla": {"values": [{
+			"id": "1",
+			"name": "Time to first response",
+			"completedCycles": [{
+				"startTime": {"epochMillis": 1709287200000},
+				"stopTime": {"epochMillis": 1709301600000},
+				"breachTime": {"epochMillis": 1709294400000},
+				"breached": true,
+				"goalDuration": {"millis": 7200000},
+				"elapsedTime": {"millis": 14400000},
+				"remainingTime": {"millis": -7200000}
+			}],
+			"ongoingCycle": {
+				"startTime": {"epochMillis": 1709373600000},
+				"breached": false,
+				"paused": true,
+				"goalDuration": {"millis": 7200000},
+				"elapsedTime": {"millis": 600000},
+				"remainingTime": {"millis": 6600000}
+			}
+		}]}
+	}`), &serviceRequest)
+	assert.Nil(t, err)
+
+	request, requestType, cycles := serviceRequest.ExtractEntities(1)
+	assert.Equal(t, uint64(107001), request.IssueId)
+	assert.Equal(t, uint64(25), request.RequestTypeId)
+	assert.Equal(t, uint64(10), request.ServiceDeskId)
+	assert.Equal(t, "NEW", request.StatusCategory)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), *request.CreatedDate)
+	assert.Equal(t, "Report an incident", requestType.Name)
+
+	assert.Len(t, cycles, 2)
+	assert.Equal(t, 1, cycles[0].CycleNo)
+	assert.Equal(t, "Time to first response", cycles[0].SlaName)
+	assert.True(t, cycles[0].Breached)
+	assert.False(t, cycles[0].Ongoing)
+	assert.Equal(t, int64(120), *cycles[0].GoalMinutes)
+	assert.Equal(t, int64(240), cycles[0].ElapsedMinutes)
+	assert.Equal(t, int64(-120), cycles[0].RemainingMinutes)
+	assert.Equal(t, 2, cycles[1].CycleNo)
+	assert.True(t, cycles[1].Ongoing)
+	assert.True(t, cycles[1].Paused)
+	assert.Nil(t, cycles[1].StopTime)
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion_ToToolLayer(t *testing.T) {
+	var version Version
+	err := json.Unmarshal([]byte(`{
+		"self": "https://example.atlassian.net/rest/api/2/version/10001",
+		"id": "10001",
+		"name": "v1.2.0",
+		"archived": false,
+		"released": true,
+		"releaseDate": "2024-03-15",
+		"projectId": 10000
+	}`), &version)
+	assert.Nil(t, err)
+
+	toolVersion := version.ToToolLayer(1)
+	assert.Equal(t, uint64(1), toolVersion.ConnectionId)
+	assert.Equal(t, uint64(10001), toolVersion.VersionId)
+	assert.Equal(t, uint64(10000), toolVersion.ProjectId)
+	assert.Equal(t, "v1.2.0", toolVersion.Name)
+	assert.True(t, toolVersion.Released)
+	assert.Nil(t, toolVersion.StartDate)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), *toolVersion.ReleaseDate)
+}
+
+func TestComponent_ToToolLayer(t *testing.T) {
+	var issue Issue
+	err := json.Unmarshal([]byte(`{
+		"id": "10100",
+		"fields": {
+			"components": [{"id": "10200", "name": "backend", "lead": {"accountId": "abc"}}],
+			"fixVersions": [{"id": "10001", "name": "v1.2.0"}],
+			"versions": [{"id": "10000", "name": "v1.1.0", "released": true}]
+		}
+	}`), &issue)
+	assert.Nil(t, err)
+
+	assert.Len(t, issue.Fields.FixVersions, 1)
+	assert.Equal(t, uint64(10001), issue.Fields.FixVersions[0].ID)
+	assert.Len(t, issue.Fields.Versions, 1)
+	assert.Equal(t, uint64(10000), issue.Fields.Versions[0].ID)
+	assert.Len(t, issue.Fields.Components, 1)
+	component := issue.Fields.Components[0].ToToolLayer(1)
+	assert.Equal(t, uint64(10200), component.ComponentId)
+	assert.Equal(t, "backend", component.Name)
+	assert.Equal(t, "abc", component.LeadAccountId)
+}
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertComponentsMeta = plugin.SubTaskMeta{
+	Name:             "convertComponents",
+	EntryPoint:       ConvertComponents,
+	EnabledByDefault: true,
+	Description:      "convert Jira components",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+var ConvertIssueComponentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueComponents",
+	EntryPoint:       ConvertIssueComponents,
+	EnabledByDefault: true,
+	Description:      "convert Jira components of issues into issue_components",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertComponents(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	db := taskCtx.GetDal()
+	projectKeys, err := getProjectKeys(db, connectionId)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.JiraComponent{}),
+		dal.Where("connection_id = ? AND project_id IN ("+boardProjectIds+")", connectionId, connectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	componentIdGen := didgen.NewDomainIdGenerator(&models.JiraComponent{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.JiraAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_COMPONENT_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraComponent{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraComponent := inputRow.(*models.JiraComponent)
+			component := &ticket.Component{
+				DomainEntity:    domainlayer.DomainEntity{Id: componentIdGen.Generate(connectionId, jiraComponent.ComponentId)},
+				Name:            jiraComponent.Name,
+				Description:     jiraComponent.Description,
+				Url:             jiraComponent.Self,
+				OriginalProject: projectKeys[strconv.FormatUint(jiraComponent.ProjectId, 10)],
+			}
+			if jiraComponent.LeadAccountId != "" {
+				component.LeadId = accountIdGen.Generate(connectionId, jiraComponent.LeadAccountId)
+			}
+			return []interface{}{component}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func ConvertIssueComponents(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("jic.*"),
+		dal.From("_tool_jira_issue_components jic"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON jic.connection_id = jbi.connection_id AND jic.issue_id = jbi.issue_id`),
+		dal.Where("jic.connection_id = ? AND jbi.board_id = ?", connectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	componentIdGen := didgen.NewDomainIdGenerator(&models.JiraComponent{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueComponent{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraIssueComponent := inputRow.(*models.JiraIssueComponent)
+			return []interface{}{
+				&ticket.IssueComponent{
+					IssueId:     issueIdGen.Generate(connectionId, jiraIssueComponent.IssueId),
+					ComponentId: componentIdGen.Generate(connectionId, jiraIssueComponent.ComponentId),
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -185,8 +185,30 @@ func extractIssues(data *JiraTaskData, mappings *typeMappings, row *api.RawData)
 	var componentNames []string
 	for _, v := range components {
 		componentNames = append(componentNames, v.Name)
+		results = append(results, &models.JiraIssueComponent{
+			ConnectionId: data.Options.ConnectionId,
+			IssueId:      issue.IssueId,
+			ComponentId:  v.ID,
+		})
 	}
 	issue.Components = strings.Join(componentNames, ",")
+	// fix versions and affects versions
+	for _, v := range apiIssue.Fields.FixVersions {
+		results = append(results, &models.JiraIssueVersion{
+			ConnectionId: data.Options.ConnectionId,
+			IssueId:      issue.IssueId,
+			VersionId:    v.ID,
+			Field:        "fixVersions",
+		})
+	}
+	for _, v := range apiIssue.Fields.Versions {
+		results = append(results, &models.JiraIssueVersion{
+			ConnectionId: data.Options.ConnectionId,
+			IssueId:      issue.IssueId,
+			VersionId:    v.ID,
+			Field:        "versions",
+		})
+	}
 	// issuelinks
 	issuelinks := apiIssue.Fields.Issuelinks
 	for _, v := range issuelinks {
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks/apiv2models"
+)
+
+const RAW_ORGANIZATION_TABLE = "jira_api_organizations"
+
+var _ plugin.SubTaskEntryPoint = CollectOrganizations
+
+var CollectOrganizationsMeta = plugin.SubTaskMeta{
+	Name:             "collectOrganizations",
+	EntryPoint:       CollectOrganizations,
+	EnabledByDefault: true,
+	Description:      "collect Jira Service Management customer organizations of the service desks, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectOrganizations(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	logger.Info("collect organizations")
+
+	cursor, err := db.Cursor(
+		dal.Select("service_desk_id"),
+		dal.From("_tool_jira_service_desks"),
+		dal.Where("connection_id = ? AND project_id IN ("+boardProjectIds+")", data.Options.ConnectionId, data.Options.ConnectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(apiv2models.ServiceDeskInput{}))
+	if err != nil {
+		return err
+	}
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ORGANIZATION_TABLE,
+		},
+		ApiClient:      data.ApiClient,
+		Input:          iterator,
+		PageSize:       50,
+		UrlTemplate:    "servicedeskapi/servicedesk/{{ .Input.ServiceDeskId }}/organization",
+		Query:          serviceDeskPageQuery,
+		ResponseParser: parseServiceDeskPage,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		logger.Error(err, "collect organization error")
+		return err
+	}
+	return collector.Execute()
+}
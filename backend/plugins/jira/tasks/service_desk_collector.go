@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_SERVICE_DESK_TABLE = "jira_api_service_desks"
+
+var _ plugin.SubTaskEntryPoint = CollectServiceDesks
+
+var CollectServiceDesksMeta = plugin.SubTaskMeta{
+	Name:             "collectServiceDesks",
+	EntryPoint:       CollectServiceDesks,
+	EnabledByDefault: true,
+	Description:      "collect Jira Service Management service desks, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectServiceDesks(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	logger := taskCtx.GetLogger()
+	logger.Info("collect service desks")
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_SERVICE_DESK_TABLE,
+		},
+		ApiClient:      data.ApiClient,
+		PageSize:       50,
+		UrlTemplate:    "servicedeskapi/servicedesk",
+		Query:          serviceDeskPageQuery,
+		ResponseParser: parseServiceDeskPage,
+		// the servicedesk api is only available when Jira Service Management is installed
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		logger.Error(err, "collect service desk error")
+		return err
+	}
+	return collector.Execute()
+}
+
+// serviceDeskPageQuery builds the paging parameters of the servicedesk api
+func serviceDeskPageQuery(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("start", fmt.Sprintf("%v", reqData.Pager.Skip))
+	query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	return query, nil
+}
+
+// parseServiceDeskPage returns the values of a page of the servicedesk api
+func parseServiceDeskPage(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var page struct {
+		Values []json.RawMessage `json:"values"`
+	}
+	err := api.UnmarshalResponse(res, &page)
+	if err != nil {
+		return nil, err
+	}
+	return page.Values, nil
+}
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertServiceRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertServiceRequests",
+	EntryPoint:       ConvertServiceRequests,
+	EnabledByDefault: true,
+	Description:      "convert Jira Service Management SLA cycles into issue_slas, and map request types onto standard issue types",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertServiceRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	logger.Info("convert service requests")
+
+	var requestTypes []models.JiraRequestType
+	err := db.All(&requestTypes, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return err
+	}
+	requestTypeNames := make(map[uint64]string, len(requestTypes))
+	for _, requestType := range requestTypes {
+		requestTypeNames[requestType.RequestTypeId] = requestType.Name
+	}
+	var cycles []*models.JiraIssueSlaCycle
+	err = db.All(
+		&cycles,
+		dal.Select("c.*"),
+		dal.From("_tool_jira_issue_sla_cycles c"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON c.connection_id = jbi.connection_id AND c.issue_id = jbi.issue_id`),
+		dal.Where("c.connection_id = ? AND jbi.board_id = ?", connectionId, data.Options.BoardId),
+		dal.Orderby("c.issue_id, c.sla_id, c.cycle_no"),
+	)
+	if err != nil {
+		return err
+	}
+	issueCycles := make(map[uint64][]*models.JiraIssueSlaCycle)
+	for _, cycle := range cycles {
+		issueCycles[cycle.IssueId] = append(issueCycles[cycle.IssueId], cycle)
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("r.*"),
+		dal.From("_tool_jira_service_requests r"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON r.connection_id = jbi.connection_id AND r.issue_id = jbi.issue_id`),
+		dal.Where("r.connection_id = ? AND jbi.board_id = ?", connectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	var requestTypeMappings map[string]string
+	if data.Options.ScopeConfig != nil {
+		requestTypeMappings = data.Options.ScopeConfig.RequestTypeMappings
+	}
+	// standard type => domain issue ids
+	mappedIssueIds := make(map[string][]string)
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_SERVICE_REQUEST_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraServiceRequest{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			request := inputRow.(*models.JiraServiceRequest)
+			issueId := issueIdGen.Generate(connectionId, request.IssueId)
+			if stdType, ok := requestTypeMappings[requestTypeNames[request.RequestTypeId]]; ok {
+				mappedIssueIds[stdType] = append(mappedIssueIds[stdType], issueId)
+			}
+			var results []interface{}
+			for _, slaCycles := range groupSlaCycles(issueCycles[request.IssueId]) {
+				issueSla := summarizeSlaCycles(slaCycles)
+				issueSla.IssueId = issueId
+				results = append(results, issueSla)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	err = converter.Execute()
+	if err != nil {
+		return err
+	}
+
+	for stdType, issueIds := range mappedIssueIds {
+		err = db.UpdateColumn(&ticket.Issue{}, "type", stdType, dal.Where("id IN ?", issueIds))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupSlaCycles splits the cycles of an issue, ordered by SLA and cycle number, by SLA
+func groupSlaCycles(cycles []*models.JiraIssueSlaCycle) [][]*models.JiraIssueSlaCycle {
+	var groups [][]*models.JiraIssueSlaCycle
+	for i, cycle := range cycles {
+		if i == 0 || cycle.SlaId != cycles[i-1].SlaId {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], cycle)
+	}
+	return groups
+}
+
+// summarizeSlaCycles sums up the cycles of an SLA of an issue, ordered by cycle number
+func summarizeSlaCycles(cycles []*models.JiraIssueSlaCycle) *ticket.IssueSla {
+	first, last := cycles[0], cycles[len(cycles)-1]
+	issueSla := &ticket.IssueSla{
+		Name:        last.SlaName,
+		GoalMinutes: last.GoalMinutes,
+		Cycles:      len(cycles),
+		Ongoing:     last.Ongoing,
+		Paused:      last.Ongoing && last.Paused,
+		StartDate:   first.StartTime,
+	}
+	if !last.Ongoing {
+		issueSla.StopDate = last.StopTime
+	}
+	for _, cycle := range cycles {
+		issueSla.ElapsedMinutes += cycle.ElapsedMinutes
+		if cycle.Breached {
+			issueSla.BreachedCycles++
+			if issueSla.BreachDate == nil {
+				issueSla.BreachDate = cycle.BreachTime
+			}
+		}
+	}
+	issueSla.Breached = issueSla.BreachedCycles > 0
+	if !issueSla.Breached && last.Ongoing {
+		issueSla.BreachDate = last.BreachTime
+	}
+	return issueSla
+}
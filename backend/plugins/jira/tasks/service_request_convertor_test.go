@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeSlaCycles(t *testing.T) {
+	hour := func(h int) *time.Time {
+		t := time.Date(2024, 3, 1, h, 0, 0, 0, time.UTC)
+		return &t
+	}
+	goal := int64(120)
+	cycles := []*models.JiraIssueSlaCycle{
+		{IssueId: 1, SlaId: 1, CycleNo: 1, SlaName: "Time to first response", StartTime: hour(1), StopTime: hour(2), ElapsedMinutes: 60, GoalMinutes: &goal},
+		{IssueId: 1, SlaId: 2, CycleNo: 1, SlaName: "Time to resolution", StartTime: hour(1), StopTime: hour(5), BreachTime: hour(3), Breached: true, ElapsedMinutes: 240, GoalMinutes: &goal},
+		{IssueId: 1, SlaId: 2, CycleNo: 2, SlaName: "Time to resolution", StartTime: hour(8), BreachTime: hour(10), Ongoing: true, Paused: true, ElapsedMinutes: 30, GoalMinutes: &goal},
+	}
+	groups := groupSlaCycles(cycles)
+	assert.Len(t, groups, 2)
+
+	firstResponse := summarizeSlaCycles(groups[0])
+	assert.Equal(t, "Time to first response", firstResponse.Name)
+	assert.Equal(t, 1, firstResponse.Cycles)
+	assert.False(t, firstResponse.Breached)
+	assert.False(t, firstResponse.Ongoing)
+	assert.Equal(t, hour(2), firstResponse.StopDate)
+	assert.Nil(t, firstResponse.BreachDate)
+
+	resolution := summarizeSlaCycles(groups[1])
+	assert.Equal(t, "Time to resolution", resolution.Name)
+	assert.Equal(t, 2, resolution.Cycles)
+	assert.Equal(t, 1, resolution.BreachedCycles)
+	assert.True(t, resolution.Breached)
+	assert.True(t, resolution.Ongoing)
+	assert.True(t, resolution.Paused)
+	assert.Equal(t, int64(270), resolution.ElapsedMinutes)
+	assert.Equal(t, int64(120), *resolution.GoalMinutes)
+	assert.Equal(t, hour(1), resolution.StartDate)
+	assert.Nil(t, resolution.StopDate)
+	assert.Equal(t, hour(3), resolution.BreachDate)
+}
@@ -0,0 +1,170 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertVersions",
+	EntryPoint:       ConvertVersions,
+	EnabledByDefault: true,
+	Description:      "convert Jira versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+var ConvertIssueVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueVersions",
+	EntryPoint:       ConvertIssueVersions,
+	EnabledByDefault: true,
+	Description:      "convert Jira fix versions and affects versions of issues into issue_versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// boardProjectIds selects the ids of the projects the board issues belong to
+const boardProjectIds = `SELECT DISTINCT i.project_id FROM _tool_jira_board_issues bi
+	LEFT JOIN _tool_jira_issues i ON (bi.connection_id = i.connection_id AND bi.issue_id = i.issue_id)
+	WHERE bi.connection_id = ? AND bi.board_id = ?`
+
+// getProjectKeys maps the project ids of the connection to their keys
+func getProjectKeys(db dal.Dal, connectionId uint64) (map[string]string, errors.Error) {
+	var projects []models.JiraProject
+	err := db.All(&projects, dal.Where("connection_id = ?", connectionId))
+	if err != nil {
+		return nil, err
+	}
+	projectKeys := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectKeys[project.Id] = project.ProjectKey
+	}
+	return projectKeys, nil
+}
+
+func ConvertVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	db := taskCtx.GetDal()
+	projectKeys, err := getProjectKeys(db, connectionId)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(&models.JiraVersion{}),
+		dal.Where("connection_id = ? AND project_id IN ("+boardProjectIds+")", connectionId, connectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_VERSION_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraVersion{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraVersion := inputRow.(*models.JiraVersion)
+			version := &ticket.Version{
+				DomainEntity:    domainlayer.DomainEntity{Id: versionIdGen.Generate(connectionId, jiraVersion.VersionId)},
+				Name:            jiraVersion.Name,
+				Description:     jiraVersion.Description,
+				Url:             jiraVersion.Self,
+				Released:        jiraVersion.Released,
+				Archived:        jiraVersion.Archived,
+				StartDate:       jiraVersion.StartDate,
+				ReleaseDate:     jiraVersion.ReleaseDate,
+				OriginalProject: projectKeys[strconv.FormatUint(jiraVersion.ProjectId, 10)],
+			}
+			return []interface{}{version}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func ConvertIssueVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("jiv.*"),
+		dal.From("_tool_jira_issue_versions jiv"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues jbi
+              ON jiv.connection_id = jbi.connection_id AND jiv.issue_id = jbi.issue_id`),
+		dal.Where("jiv.connection_id = ? AND jbi.board_id = ?", connectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraIssueVersion{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraIssueVersion := inputRow.(*models.JiraIssueVersion)
+			versionType := ticket.FixVersion
+			if jiraIssueVersion.Field == "versions" {
+				versionType = ticket.AffectsVersion
+			}
+			return []interface{}{
+				&ticket.IssueVersion{
+					IssueId:   issueIdGen.Generate(connectionId, jiraIssueVersion.IssueId),
+					VersionId: versionIdGen.Generate(connectionId, jiraIssueVersion.VersionId),
+					Type:      versionType,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,185 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/linker/tasks"
+)
+
+const defaultDryRunLimit = 100
+
+type DryRunRequest struct {
+	tasks.LinkerOptions `mapstructure:",squash"`
+	// Limit is the max number of matches to return
+	Limit int `json:"limit"`
+}
+
+type DryRunMatch struct {
+	PullRequestId  string `json:"pullRequestId"`
+	PullRequestKey int    `json:"pullRequestKey"`
+	CommitSha      string `json:"commitSha,omitempty"`
+	tasks.IssueKeyMatch
+	// IssueId is empty when no issue with the key exists in the projects
+	IssueId string `json:"issueId,omitempty"`
+}
+
+type DryRunResponse struct {
+	Matches   []*DryRunMatch `json:"matches"`
+	Truncated bool           `json:"truncated"`
+}
+
+// DryRun previews the links the rules would produce for the project without saving them
+// @Summary preview the links between pull requests, commits and issues
+// @Description the request body is the same as the linker options, plus an optional limit of the matches
+// @Tags plugins/linker
+// @Accept application/json
+// @Param body body DryRunRequest true "json"
+// @Success 200  {object} DryRunResponse
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/linker/dry-run [POST]
+func DryRun(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var req DryRunRequest
+	if err := helper.Decode(input.Body, &req, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid dry-run request")
+	}
+	if req.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required")
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultDryRunLimit
+	}
+	data, err := tasks.NewLinkerTaskData(&req.LinkerOptions)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := dryRun(basicRes.GetDal(), data, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: resp, Status: http.StatusOK}, nil
+}
+
+func dryRun(db dal.Dal, data *tasks.LinkerTaskData, limit int) (*DryRunResponse, errors.Error) {
+	// every match results in one link at least, so collecting one more match than the limit tells the truncation
+	var candidates []*DryRunMatch
+	// appends the matches and reports whether more matches than the limit were collected
+	collect := func(pullRequestId string, pullRequestKey int, commitSha string, matches []*tasks.IssueKeyMatch) bool {
+		for _, match := range matches {
+			if len(candidates) > limit {
+				return true
+			}
+			candidates = append(candidates, &DryRunMatch{
+				PullRequestId:  pullRequestId,
+				PullRequestKey: pullRequestKey,
+				CommitSha:      commitSha,
+				IssueKeyMatch:  *match,
+			})
+		}
+		return len(candidates) > limit
+	}
+	if err := collectDryRunMatches(db, data, collect); err != nil {
+		return nil, err
+	}
+
+	// the issues of all the matched keys are loaded by a single query
+	issueKeys := make([]string, 0, len(candidates))
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if !seen[candidate.IssueKey] {
+			seen[candidate.IssueKey] = true
+			issueKeys = append(issueKeys, candidate.IssueKey)
+		}
+	}
+	issues, err := tasks.FindIssues(db, data.Options.GetIssueProjectNames(), issueKeys)
+	if err != nil {
+		return nil, err
+	}
+	issueIdsByKey := make(map[string][]string)
+	for _, issue := range issues {
+		issueIdsByKey[issue.IssueKey] = append(issueIdsByKey[issue.IssueKey], issue.Id)
+	}
+
+	resp := &DryRunResponse{Matches: []*DryRunMatch{}}
+	for _, candidate := range candidates {
+		issueIds := issueIdsByKey[candidate.IssueKey]
+		if len(issueIds) == 0 {
+			issueIds = []string{""}
+		}
+		for _, issueId := range issueIds {
+			if len(resp.Matches) >= limit {
+				resp.Truncated = true
+				return resp, nil
+			}
+			match := *candidate
+			match.IssueId = issueId
+			resp.Matches = append(resp.Matches, &match)
+		}
+	}
+	return resp, nil
+}
+
+// collectDryRunMatches calls collect with the issue keys matched in the pull requests and their commit messages
+// until it reports that enough matches were collected
+func collectDryRunMatches(db dal.Dal, data *tasks.LinkerTaskData, collect func(pullRequestId string, pullRequestKey int, commitSha string, matches []*tasks.IssueKeyMatch) bool) errors.Error {
+	cursor, err := db.Cursor(
+		dal.Select("pull_requests.*"),
+		dal.From(&code.PullRequest{}),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pull_requests.base_repo_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		pullRequest := &code.PullRequest{}
+		if err := db.Fetch(cursor, pullRequest); err != nil {
+			return err
+		}
+		if collect(pullRequest.Id, pullRequest.PullRequestKey, "", data.MatchPullRequest(pullRequest)) {
+			return nil
+		}
+	}
+
+	if !data.HasCommitMessageRules() {
+		return nil
+	}
+	commitCursor, err := db.Cursor(tasks.PullRequestCommitMessageClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	defer commitCursor.Close()
+	for commitCursor.Next() {
+		commit := &tasks.PullRequestCommitMessage{}
+		if err := db.Fetch(commitCursor, commit); err != nil {
+			return err
+		}
+		if collect(commit.PullRequestId, commit.PullRequestKey, commit.CommitSha, data.MatchCommitMessage(commit.Message)) {
+			return nil
+		}
+	}
+	return nil
+}
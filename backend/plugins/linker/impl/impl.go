@@ -19,12 +19,13 @@ package impl
 
 import (
 	"encoding/json"
-	"regexp"
 
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/linker/api"
 	"github.com/apache/incubator-devlake/plugins/linker/models/migrationscripts"
 	"github.com/apache/incubator-devlake/plugins/linker/tasks"
 )
@@ -32,7 +33,9 @@ import (
 // make sure interface is implemented
 var _ interface {
 	plugin.PluginMeta
+	plugin.PluginInit
 	plugin.PluginTask
+	plugin.PluginApi
 	plugin.PluginModel
 	plugin.PluginMetric
 	plugin.PluginMigration
@@ -41,6 +44,11 @@ var _ interface {
 
 type Linker struct{}
 
+func (p Linker) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
 func (p Linker) Description() string {
 	return "link some cross table datas together"
 }
@@ -73,6 +81,8 @@ func (p Linker) Settings() interface{} {
 func (p Linker) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.LinkPrToIssueMeta,
+		tasks.LinkCommitToIssueMeta,
+		tasks.LinkVersionToReleaseMeta,
 	}
 }
 
@@ -81,17 +91,7 @@ func (p Linker) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 	if err != nil {
 		return nil, err
 	}
-	taskData := &tasks.LinkerTaskData{
-		Options: op,
-	}
-	if op.PrToIssueRegexp != "" {
-		re, err := regexp.Compile(op.PrToIssueRegexp)
-		if err != nil {
-			return taskData, errors.Convert(err)
-		}
-		taskData.PrToIssueRegexp = re
-	}
-	return taskData, nil
+	return tasks.NewLinkerTaskData(op)
 }
 
 // RootPkgPath information lost when compiled as plugin(.so)
@@ -103,6 +103,14 @@ func (p Linker) MigrationScripts() []plugin.MigrationScript {
 	return migrationscripts.All()
 }
 
+func (p Linker) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"dry-run": {
+			"POST": api.DryRun,
+		},
+	}
+}
+
 func (p Linker) MakeMetricPluginPipelinePlanV200(projectName string, options json.RawMessage) (coreModels.PipelinePlan, errors.Error) {
 	op := &tasks.LinkerOptions{}
 	err := json.Unmarshal(options, op)
@@ -114,11 +122,15 @@ func (p Linker) MakeMetricPluginPipelinePlanV200(projectName string, options jso
 			{
 				Plugin: "linker",
 				Options: map[string]interface{}{
-					"projectName":     projectName,
-					"prToIssueRegexp": op.PrToIssueRegexp,
+					"projectName":       projectName,
+					"prToIssueRegexp":   op.PrToIssueRegexp,
+					"rules":             op.Rules,
+					"issueProjectNames": op.IssueProjectNames,
 				},
 				Subtasks: []string{
 					"LinkPrToIssue",
+					"LinkCommitToIssue",
+					"LinkVersionToRelease",
 				},
 			},
 		},
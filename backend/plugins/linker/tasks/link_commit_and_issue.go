@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var LinkCommitToIssueMeta = plugin.SubTaskMeta{
+	Name:             "LinkCommitToIssue",
+	EntryPoint:       LinkCommitToIssue,
+	EnabledByDefault: true,
+	Description:      "Try to link commits of pull requests to issues, according to commits' message",
+	DependencyTables: []string{code.PullRequestCommit{}.TableName(), code.Commit{}.TableName(), ticket.Issue{}.TableName()},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+	ProductTables:    []string{crossdomain.IssueCommit{}.TableName(), crossdomain.PullRequestIssue{}.TableName()},
+}
+
+const linkCommitToIssueEnricherName = "linker_commit_messages"
+
+// PullRequestCommitMessage is a commit of a pull request in the project
+type PullRequestCommitMessage struct {
+	PullRequestId  string
+	PullRequestKey int
+	CommitSha      string
+	Message        string
+}
+
+// PullRequestCommitMessageClauses selects the commits of the pull requests in the project
+func PullRequestCommitMessageClauses(projectName string) []dal.Clause {
+	return []dal.Clause{
+		dal.Select("pr.id AS pull_request_id, pr.pull_request_key, c.sha AS commit_sha, c.message"),
+		dal.From("pull_request_commits prc"),
+		dal.Join("JOIN pull_requests pr ON pr.id = prc.pull_request_id"),
+		dal.Join("JOIN commits c ON c.sha = prc.commit_sha"),
+		dal.Join("JOIN project_mapping pm ON pm.table = 'repos' AND pm.row_id = pr.base_repo_id"),
+		dal.Where("pm.project_name = ?", projectName),
+	}
+}
+
+func clearLinkedCommits(db dal.Dal, data *LinkerTaskData) errors.Error {
+	sql := `
+	DELETE FROM issue_commits
+		WHERE _raw_data_remark LIKE ?
+			AND commit_sha IN (
+				SELECT prc.commit_sha
+					FROM pull_request_commits prc
+						JOIN pull_requests pr ON pr.id = prc.pull_request_id
+						JOIN project_mapping pm
+						ON pm.table = 'repos'
+							AND pm.row_id = pr.base_repo_id
+					WHERE pm.project_name = ?
+	)
+`
+	return db.Exec(sql, "%"+linkCommitToIssueEnricherName+",%", data.Options.ProjectName)
+}
+
+func LinkCommitToIssue(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*LinkerTaskData)
+
+	if err := clearLinkedCommits(db, data); err != nil {
+		return err
+	}
+	if !data.HasCommitMessageRules() {
+		return nil
+	}
+
+	cursor, err := db.Cursor(PullRequestCommitMessageClauses(data.Options.ProjectName)...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[PullRequestCommitMessage]{
+		Ctx:   taskCtx,
+		Name:  linkCommitToIssueEnricherName,
+		Input: cursor,
+		Enrich: func(commit *PullRequestCommitMessage) ([]interface{}, errors.Error) {
+			issues, err := FindIssues(db, data.Options.GetIssueProjectNames(), distinctIssueKeys(data.MatchCommitMessage(commit.Message)))
+			if err != nil {
+				return nil, err
+			}
+			var result []interface{}
+			for _, issue := range issues {
+				result = append(result,
+					&crossdomain.IssueCommit{
+						IssueId:   issue.Id,
+						CommitSha: commit.CommitSha,
+					},
+					&crossdomain.PullRequestIssue{
+						PullRequestId:  commit.PullRequestId,
+						IssueId:        issue.Id,
+						PullRequestKey: commit.PullRequestKey,
+						IssueKey:       issue.IssueKey,
+					},
+				)
+			}
+			return result, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return enricher.Execute()
+}
@@ -33,7 +33,7 @@ var LinkPrToIssueMeta = plugin.SubTaskMeta{
 	Name:             "LinkPrToIssue",
 	EntryPoint:       LinkPrToIssue,
 	EnabledByDefault: true,
-	Description:      "Try to link pull requests to issues, according to pull requests' title and description",
+	Description:      "Try to link pull requests to issues, according to pull requests' title, description and head branch",
 	DependencyTables: []string{code.PullRequest{}.TableName(), ticket.Issue{}.TableName()},
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
 	ProductTables:    []string{crossdomain.PullRequestIssue{}.TableName()},
@@ -51,10 +51,10 @@ func clearHistoryData(db dal.Dal, data *LinkerTaskData) errors.Error {
 		WHERE pull_request_id IN (
 			SELECT pr.id
 				FROM pull_requests pr
-					LEFT JOIN project_mapping pm
+					JOIN project_mapping pm
 					ON pm.table = 'repos'
 						AND pm.row_id = pr.base_repo_id
-						AND pm.project_name = ?
+				WHERE pm.project_name = ?
 	)
 `
 	return db.Exec(sql, data.Options.ProjectName)
@@ -80,41 +80,13 @@ func LinkPrToIssue(taskCtx plugin.SubTaskContext) errors.Error {
 
 	defer cursor.Close()
 
-	var projectIssueIds []string
-	if err := db.All(&projectIssueIds,
-		dal.From(ticket.BoardIssue{}),
-		dal.Select("board_issues.issue_id"),
-		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = board_issues.board_id)"),
-		dal.Where("pm.project_name = ?", data.Options.ProjectName),
-	); err != nil {
-		return err
-	}
-
 	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[code.PullRequest]{
 		Ctx:   taskCtx,
 		Name:  code.PullRequest{}.TableName(),
 		Input: cursor,
 		Enrich: func(pullRequest *code.PullRequest) ([]interface{}, errors.Error) {
-
-			var issueKeys []string
-			for _, text := range []string{pullRequest.Title, pullRequest.Description} {
-				foundIssueKeys := data.PrToIssueRegexp.FindAllString(text, -1)
-				if len(foundIssueKeys) > 0 {
-					for _, issueKey := range foundIssueKeys {
-						issueKey = normalizeIssueKey(issueKey)
-						issueKeys = append(issueKeys, issueKey)
-					}
-					break
-				}
-			}
-			var issues []*ticket.Issue
-
-			var clauses = []dal.Clause{
-				dal.From(&ticket.Issue{}),
-				dal.Where("issues.id in ? AND issues.issue_key in ?", projectIssueIds, issueKeys),
-			}
-
-			if err := db.All(&issues, clauses...); err != nil {
+			issues, err := FindIssues(db, data.Options.GetIssueProjectNames(), distinctIssueKeys(data.MatchPullRequest(pullRequest)))
+			if err != nil {
 				return nil, err
 			}
 			if len(issues) == 0 {
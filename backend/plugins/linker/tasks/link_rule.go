@@ -0,0 +1,202 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+const (
+	LINK_SOURCE_PR_TITLE       = "prTitle"
+	LINK_SOURCE_PR_BODY        = "prBody"
+	LINK_SOURCE_PR_BRANCH      = "prBranch"
+	LINK_SOURCE_COMMIT_MESSAGE = "commitMessage"
+)
+
+var linkSources = map[string]bool{
+	LINK_SOURCE_PR_TITLE:       true,
+	LINK_SOURCE_PR_BODY:        true,
+	LINK_SOURCE_PR_BRANCH:      true,
+	LINK_SOURCE_COMMIT_MESSAGE: true,
+}
+
+// LinkRule finds the issue keys in the source text by the regexp, the first capture group is taken as the issue
+// key if there is any, otherwise the whole match
+type LinkRule struct {
+	Source string `json:"source" mapstructure:"source"`
+	Regexp string `json:"regexp" mapstructure:"regexp"`
+}
+
+type compiledLinkRule struct {
+	LinkRule
+	re *regexp.Regexp
+}
+
+// IssueKeyMatch is an issue key found by a rule
+type IssueKeyMatch struct {
+	Source   string `json:"source"`
+	Regexp   string `json:"regexp"`
+	IssueKey string `json:"issueKey"`
+}
+
+func compileLinkRules(rules []LinkRule) ([]*compiledLinkRule, errors.Error) {
+	compiled := make([]*compiledLinkRule, 0, len(rules))
+	for i, rule := range rules {
+		if !linkSources[rule.Source] {
+			return nil, errors.BadInput.New(fmt.Sprintf("rule #%d: unknown source %s", i, rule.Source))
+		}
+		re, err := regexp.Compile(rule.Regexp)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("rule #%d: invalid regexp", i))
+		}
+		compiled = append(compiled, &compiledLinkRule{LinkRule: rule, re: re})
+	}
+	return compiled, nil
+}
+
+// findIssueKeys finds the issue keys in the text, which are the first capture groups of the matches when
+// `captureGroup` is true, or the whole matches as the legacy prToIssueRegexp does
+func findIssueKeys(re *regexp.Regexp, text string, captureGroup bool) []string {
+	var issueKeys []string
+	for _, match := range re.FindAllStringSubmatch(text, -1) {
+		issueKey := match[0]
+		if captureGroup && len(match) > 1 && match[1] != "" {
+			issueKey = match[1]
+		}
+		if issueKey = normalizeIssueKey(issueKey); issueKey != "" {
+			issueKeys = append(issueKeys, issueKey)
+		}
+	}
+	return issueKeys
+}
+
+// NewLinkerTaskData compiles the regexps of the options
+func NewLinkerTaskData(op *LinkerOptions) (*LinkerTaskData, errors.Error) {
+	taskData := &LinkerTaskData{
+		Options: op,
+	}
+	if op.PrToIssueRegexp != "" {
+		re, err := regexp.Compile(op.PrToIssueRegexp)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid prToIssueRegexp")
+		}
+		taskData.PrToIssueRegexp = re
+	}
+	rules, err := compileLinkRules(op.Rules)
+	if err != nil {
+		return nil, err
+	}
+	taskData.rules = rules
+	return taskData, nil
+}
+
+// MatchPullRequest finds the issue keys in the title, the description and the head branch of the pull request
+func (d *LinkerTaskData) MatchPullRequest(pullRequest *code.PullRequest) []*IssueKeyMatch {
+	var matches []*IssueKeyMatch
+	// the description is looked into only if nothing was found in the title
+	if d.PrToIssueRegexp != nil {
+		for _, source := range []string{LINK_SOURCE_PR_TITLE, LINK_SOURCE_PR_BODY} {
+			issueKeys := findIssueKeys(d.PrToIssueRegexp, pullRequestText(pullRequest, source), false)
+			for _, issueKey := range issueKeys {
+				matches = append(matches, &IssueKeyMatch{Source: source, Regexp: d.PrToIssueRegexp.String(), IssueKey: issueKey})
+			}
+			if len(issueKeys) > 0 {
+				break
+			}
+		}
+	}
+	for _, rule := range d.rules {
+		if rule.Source == LINK_SOURCE_COMMIT_MESSAGE {
+			continue
+		}
+		for _, issueKey := range findIssueKeys(rule.re, pullRequestText(pullRequest, rule.Source), true) {
+			matches = append(matches, &IssueKeyMatch{Source: rule.Source, Regexp: rule.Regexp, IssueKey: issueKey})
+		}
+	}
+	return matches
+}
+
+// MatchCommitMessage finds the issue keys in the commit message
+func (d *LinkerTaskData) MatchCommitMessage(message string) []*IssueKeyMatch {
+	var matches []*IssueKeyMatch
+	for _, rule := range d.rules {
+		if rule.Source != LINK_SOURCE_COMMIT_MESSAGE {
+			continue
+		}
+		for _, issueKey := range findIssueKeys(rule.re, message, true) {
+			matches = append(matches, &IssueKeyMatch{Source: rule.Source, Regexp: rule.Regexp, IssueKey: issueKey})
+		}
+	}
+	return matches
+}
+
+// HasCommitMessageRules reports whether any rule looks into the commit messages
+func (d *LinkerTaskData) HasCommitMessageRules() bool {
+	for _, rule := range d.rules {
+		if rule.Source == LINK_SOURCE_COMMIT_MESSAGE {
+			return true
+		}
+	}
+	return false
+}
+
+func pullRequestText(pullRequest *code.PullRequest, source string) string {
+	switch source {
+	case LINK_SOURCE_PR_TITLE:
+		return pullRequest.Title
+	case LINK_SOURCE_PR_BODY:
+		return pullRequest.Description
+	case LINK_SOURCE_PR_BRANCH:
+		return pullRequest.HeadRef
+	}
+	return ""
+}
+
+func distinctIssueKeys(matches []*IssueKeyMatch) []string {
+	seen := make(map[string]bool)
+	var issueKeys []string
+	for _, match := range matches {
+		if !seen[match.IssueKey] {
+			seen[match.IssueKey] = true
+			issueKeys = append(issueKeys, match.IssueKey)
+		}
+	}
+	return issueKeys
+}
+
+// FindIssues returns the issues with the keys on the boards of the projects
+func FindIssues(db dal.Dal, projectNames []string, issueKeys []string) ([]*ticket.Issue, errors.Error) {
+	var issues []*ticket.Issue
+	if len(issueKeys) == 0 {
+		return issues, nil
+	}
+	err := db.All(&issues,
+		dal.Select("DISTINCT issues.*"),
+		dal.From(&ticket.Issue{}),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = issues.id"),
+		dal.Join("JOIN project_mapping pm ON pm.table = 'boards' AND pm.row_id = bi.board_id"),
+		dal.Where("pm.project_name IN ? AND issues.issue_key IN ?", projectNames, issueKeys),
+	)
+	return issues, err
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPullRequest(t *testing.T) {
+	data, err := NewLinkerTaskData(&LinkerOptions{
+		ProjectName: "p",
+		Rules: []LinkRule{
+			{Source: LINK_SOURCE_PR_TITLE, Regexp: `[A-Z]+-\d+`},
+			{Source: LINK_SOURCE_PR_BRANCH, Regexp: `^feature/([A-Z]+-\d+)`},
+			{Source: LINK_SOURCE_COMMIT_MESSAGE, Regexp: `fixes #(\d+)`},
+		},
+	})
+	assert.Nil(t, err)
+
+	matches := data.MatchPullRequest(&code.PullRequest{
+		Title:       "DEV-1 DEV-2: some work",
+		Description: "DEV-3 is not looked into",
+		HeadRef:     "feature/OPS-4-login",
+	})
+	assert.Equal(t, []*IssueKeyMatch{
+		{Source: LINK_SOURCE_PR_TITLE, Regexp: `[A-Z]+-\d+`, IssueKey: "DEV-1"},
+		{Source: LINK_SOURCE_PR_TITLE, Regexp: `[A-Z]+-\d+`, IssueKey: "DEV-2"},
+		{Source: LINK_SOURCE_PR_BRANCH, Regexp: `^feature/([A-Z]+-\d+)`, IssueKey: "OPS-4"},
+	}, matches)
+	assert.Equal(t, []string{"DEV-1", "DEV-2", "OPS-4"}, distinctIssueKeys(matches))
+
+	assert.True(t, data.HasCommitMessageRules())
+	assert.Equal(t, []*IssueKeyMatch{
+		{Source: LINK_SOURCE_COMMIT_MESSAGE, Regexp: `fixes #(\d+)`, IssueKey: "12"},
+	}, data.MatchCommitMessage("fixes #12, refs #13"))
+}
+
+func TestMatchPullRequestLegacyRegexp(t *testing.T) {
+	data, err := NewLinkerTaskData(&LinkerOptions{
+		ProjectName:     "p",
+		PrToIssueRegexp: `#(\d+)`,
+	})
+	assert.Nil(t, err)
+	assert.False(t, data.HasCommitMessageRules())
+
+	// the description is used only when nothing is found in the title
+	assert.Equal(t, []string{"1"}, distinctIssueKeys(data.MatchPullRequest(&code.PullRequest{
+		Title:       "fix #1",
+		Description: "also #2",
+	})))
+	assert.Equal(t, []string{"2", "3"}, distinctIssueKeys(data.MatchPullRequest(&code.PullRequest{
+		Title:       "fix",
+		Description: "#2 and #3 and #2 again",
+	})))
+	assert.Empty(t, data.MatchCommitMessage("#4"))
+
+	// the whole match is the issue key even if the legacy regexp has groups
+	data, err = NewLinkerTaskData(&LinkerOptions{
+		ProjectName:     "p",
+		PrToIssueRegexp: `(DEV|OPS)-\d+`,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DEV-12"}, distinctIssueKeys(data.MatchPullRequest(&code.PullRequest{Title: "fix DEV-12"})))
+}
+
+func TestNewLinkerTaskDataInvalidRules(t *testing.T) {
+	_, err := NewLinkerTaskData(&LinkerOptions{
+		ProjectName: "p",
+		Rules:       []LinkRule{{Source: "prAuthor", Regexp: ".*"}},
+	})
+	assert.NotNil(t, err)
+
+	_, err = NewLinkerTaskData(&LinkerOptions{
+		ProjectName: "p",
+		Rules:       []LinkRule{{Source: LINK_SOURCE_PR_BODY, Regexp: "("}},
+	})
+	assert.NotNil(t, err)
+}
+
+func TestGetIssueProjectNames(t *testing.T) {
+	op := &LinkerOptions{ProjectName: "p", IssueProjectNames: []string{"q", "p", ""}}
+	assert.Equal(t, []string{"p", "q"}, op.GetIssueProjectNames())
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var LinkVersionToReleaseMeta = plugin.SubTaskMeta{
+	Name:             "LinkVersionToRelease",
+	EntryPoint:       LinkVersionToRelease,
+	EnabledByDefault: true,
+	Description:      "Link the versions of the issue trackers to the cicd releases of the project tagged with their names",
+	DependencyTables: []string{ticket.Version{}.TableName(), ticket.IssueVersion{}.TableName(), devops.CicdRelease{}.TableName()},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CROSS},
+	ProductTables:    []string{crossdomain.VersionRelease{}.TableName()},
+}
+
+// projectReleaseIds selects the ids of the cicd releases of the repos and cicd scopes in the project
+const projectReleaseIds = `
+	SELECT cr.id FROM cicd_releases cr
+		JOIN project_mapping pm ON (pm.row_id = cr.cicd_scope_id AND pm.table = 'cicd_scopes')
+			OR (pm.row_id = cr.repo_id AND pm.table = 'repos')
+		WHERE pm.project_name = ?`
+
+// projectVersionIds selects the ids of the versions of the issues on the boards of the projects
+const projectVersionIds = `
+	SELECT iv.version_id FROM issue_versions iv
+		JOIN board_issues bi ON bi.issue_id = iv.issue_id
+		JOIN project_mapping pm ON pm.row_id = bi.board_id AND pm.table = 'boards'
+		WHERE pm.project_name IN ?`
+
+func LinkVersionToRelease(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*LinkerTaskData)
+	projectName := data.Options.ProjectName
+
+	err := db.Delete(&crossdomain.VersionRelease{}, dal.Where("release_id IN ("+projectReleaseIds+")", projectName))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to delete the previous version_releases")
+	}
+	var releases []*devops.CicdRelease
+	err = db.All(&releases, dal.Where("id IN ("+projectReleaseIds+")", projectName))
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+	var versions []*ticket.Version
+	err = db.All(&versions, dal.Where("id IN ("+projectVersionIds+")", data.Options.GetIssueProjectNames()))
+	if err != nil {
+		return err
+	}
+
+	saver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.VersionRelease{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, versionRelease := range MatchVersionReleases(versions, releases) {
+		if err = saver.Add(versionRelease); err != nil {
+			return err
+		}
+	}
+	return saver.Close()
+}
+
+// MatchVersionReleases links each version to the releases whose tag or name is the name of the version,
+// ignoring the case and the "v" prefix, so that the version 1.2.0 matches the tag v1.2.0
+func MatchVersionReleases(versions []*ticket.Version, releases []*devops.CicdRelease) []*crossdomain.VersionRelease {
+	releasesByName := make(map[string][]string)
+	for _, release := range releases {
+		names := map[string]bool{}
+		for _, name := range []string{release.TagName, release.Name} {
+			if name = normalizeReleaseName(name); name != "" && !names[name] {
+				names[name] = true
+				releasesByName[name] = append(releasesByName[name], release.Id)
+			}
+		}
+	}
+	var result []*crossdomain.VersionRelease
+	for _, version := range versions {
+		for _, releaseId := range releasesByName[normalizeReleaseName(version.Name)] {
+			result = append(result, &crossdomain.VersionRelease{VersionId: version.Id, ReleaseId: releaseId})
+		}
+	}
+	return result
+}
+
+func normalizeReleaseName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) > 1 && name[0] == 'v' && name[1] >= '0' && name[1] <= '9' {
+		return name[1:]
+	}
+	return name
+}
@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchVersionReleases(t *testing.T) {
+	version := func(id, name string) *ticket.Version {
+		return &ticket.Version{DomainEntity: domainlayer.DomainEntity{Id: id}, Name: name}
+	}
+	release := func(id, tagName, name string) *devops.CicdRelease {
+		return &devops.CicdRelease{DomainEntity: domainlayer.DomainEntity{Id: id}, TagName: tagName, Name: name}
+	}
+	versionReleases := MatchVersionReleases(
+		[]*ticket.Version{version("v1", "1.2.0"), version("v2", "Mobile 2.0"), version("v3", "vnext"), version("v4", "")},
+		[]*devops.CicdRelease{
+			release("r1", "v1.2.0", "Release 1.2.0"),
+			// the same version released in another repo
+			release("r2", "1.2.0", "1.2.0"),
+			release("r3", "mobile-2.0", "Mobile 2.0"),
+			release("r4", "next", ""),
+			release("r5", "", ""),
+		},
+	)
+	assert.Equal(t, []*crossdomain.VersionRelease{
+		{VersionId: "v1", ReleaseId: "r1"},
+		{VersionId: "v1", ReleaseId: "r2"},
+		{VersionId: "v2", ReleaseId: "r3"},
+	}, versionReleases)
+}
@@ -24,13 +24,28 @@ import (
 )
 
 type LinkerOptions struct {
-	PrToIssueRegexp string `json:"prToIssueRegexp"`
-	ProjectName     string `json:"projectName"`
+	PrToIssueRegexp string     `json:"prToIssueRegexp"`
+	ProjectName     string     `json:"projectName"`
+	Rules           []LinkRule `json:"rules"`
+	// IssueProjectNames are the other projects whose issues could be linked as well
+	IssueProjectNames []string `json:"issueProjectNames"`
+}
+
+// GetIssueProjectNames returns the projects whose issues could be linked, the project itself included
+func (op *LinkerOptions) GetIssueProjectNames() []string {
+	projectNames := []string{op.ProjectName}
+	for _, projectName := range op.IssueProjectNames {
+		if projectName != "" && projectName != op.ProjectName {
+			projectNames = append(projectNames, projectName)
+		}
+	}
+	return projectNames
 }
 
 type LinkerTaskData struct {
 	Options         *LinkerOptions
 	PrToIssueRegexp *regexp.Regexp
+	rules           []*compiledLinkRule
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*LinkerOptions, errors.Error) {
@@ -39,5 +54,8 @@ func DecodeAndValidateTaskOptions(options map[string]interface{}) (*LinkerOption
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error decoding linker task options")
 	}
+	if op.ProjectName == "" {
+		return nil, errors.BadInput.New("projectName is required for linker")
+	}
 	return &op, nil
 }
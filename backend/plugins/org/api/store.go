@@ -18,12 +18,14 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"reflect"
+	"time"
 )
 
 type store interface {
@@ -32,6 +34,14 @@ type store interface {
 	findAllAccounts() ([]account, errors.Error)
 	findAllUserAccounts() ([]userAccount, errors.Error)
 	findAllProjectMapping() ([]projectMapping, errors.Error)
+	findUserAccountCandidates(status string) ([]userAccountCandidate, errors.Error)
+	reviewUserAccountCandidate(decision userAccountDecision) errors.Error
+	findTeams() ([]crossdomain.Team, errors.Error)
+	saveTeams(teams []*crossdomain.Team) errors.Error
+	deleteTeam(team *crossdomain.Team) errors.Error
+	findTeamMembers(teamIds []string) ([]teamMember, errors.Error)
+	saveTeamUsers(teamUsers []*crossdomain.TeamUser) errors.Error
+	deleteTeamUser(teamId, userId string, startDate time.Time) errors.Error
 	deleteAll(i interface{}) errors.Error
 	save(items []interface{}) errors.Error
 }
@@ -122,3 +132,144 @@ func (d *dbStore) save(items []interface{}) errors.Error {
 	d.driver.Close()
 	return nil
 }
+
+func (d *dbStore) findUserAccountCandidates(status string) ([]userAccountCandidate, errors.Error) {
+	clauses := []dal.Clause{
+		dal.Select("uac.account_id, a.email AS account_email, a.full_name AS account_full_name, a.user_name AS account_user_name, " +
+			"uac.user_id, u.name AS user_name, u.email AS user_email, uac.score, uac.reasons, uac.status"),
+		dal.From("user_account_candidates uac"),
+		dal.Join("LEFT JOIN accounts a ON a.id = uac.account_id"),
+		dal.Join("LEFT JOIN users u ON u.id = uac.user_id"),
+		dal.Orderby("uac.score DESC, uac.account_id, uac.user_id"),
+	}
+	if status != "" {
+		clauses = append(clauses, dal.Where("uac.status = ?", status))
+	}
+	candidates := []userAccountCandidate{}
+	err := d.db.All(&candidates, clauses...)
+	return candidates, err
+}
+
+func (d *dbStore) reviewUserAccountCandidate(decision userAccountDecision) errors.Error {
+	candidate := &crossdomain.UserAccountCandidate{}
+	err := d.db.First(candidate, dal.Where("account_id = ? AND user_id = ?", decision.AccountId, decision.UserId))
+	if err != nil {
+		if d.db.IsErrorNotFound(err) {
+			return errors.NotFound.New(fmt.Sprintf("no candidate of user %s for account %s", decision.UserId, decision.AccountId))
+		}
+		return err
+	}
+	tx := d.db.Begin()
+	if decision.Accept {
+		err = acceptUserAccountCandidate(tx, candidate)
+	} else {
+		err = rejectUserAccountCandidate(tx, candidate)
+	}
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to rollback: %s", e))
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func rejectUserAccountCandidate(tx dal.Transaction, candidate *crossdomain.UserAccountCandidate) errors.Error {
+	candidate.Status = crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED
+	err := tx.Delete(&crossdomain.UserAccount{}, dal.Where("account_id = ? AND user_id = ?", candidate.AccountId, candidate.UserId))
+	if err != nil {
+		return err
+	}
+	return tx.Update(candidate)
+}
+
+// acceptUserAccountCandidate links the account to the user only, the other users proposed for the account are settled
+func acceptUserAccountCandidate(tx dal.Transaction, candidate *crossdomain.UserAccountCandidate) errors.Error {
+	candidate.Status = crossdomain.USER_ACCOUNT_CANDIDATE_ACCEPTED
+	err := tx.Delete(&crossdomain.UserAccount{}, dal.Where("account_id = ? AND user_id != ?", candidate.AccountId, candidate.UserId))
+	if err != nil {
+		return err
+	}
+	err = tx.Delete(&crossdomain.UserAccountCandidate{}, dal.Where(
+		"account_id = ? AND user_id != ? AND status IN ?",
+		candidate.AccountId, candidate.UserId,
+		[]string{crossdomain.USER_ACCOUNT_CANDIDATE_PENDING, crossdomain.USER_ACCOUNT_CANDIDATE_AUTO},
+	))
+	if err != nil {
+		return err
+	}
+	// the users accepted before are rejected, otherwise they would be linked again by the next run
+	err = tx.UpdateColumn(&crossdomain.UserAccountCandidate{}, "status", crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED, dal.Where(
+		"account_id = ? AND user_id != ? AND status = ?",
+		candidate.AccountId, candidate.UserId, crossdomain.USER_ACCOUNT_CANDIDATE_ACCEPTED,
+	))
+	if err != nil {
+		return err
+	}
+	err = tx.CreateOrUpdate(&crossdomain.UserAccount{UserId: candidate.UserId, AccountId: candidate.AccountId})
+	if err != nil {
+		return err
+	}
+	return tx.Update(candidate)
+}
+
+func (d *dbStore) findTeams() ([]crossdomain.Team, errors.Error) {
+	var teams []crossdomain.Team
+	err := d.db.All(&teams, dal.Orderby("sorting_index, id"))
+	return teams, err
+}
+
+func (d *dbStore) saveTeams(teams []*crossdomain.Team) errors.Error {
+	for _, team := range teams {
+		if err := d.db.CreateOrUpdate(team); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *dbStore) deleteTeam(team *crossdomain.Team) errors.Error {
+	// the sub-teams are moved up to the parent of the team
+	err := d.db.UpdateColumn(&crossdomain.Team{}, "parent_id", team.ParentId, dal.Where("parent_id = ?", team.Id))
+	if err != nil {
+		return err
+	}
+	err = d.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ?", team.Id))
+	if err != nil {
+		return err
+	}
+	return d.db.Delete(&crossdomain.Team{}, dal.Where("id = ?", team.Id))
+}
+
+func (d *dbStore) findTeamMembers(teamIds []string) ([]teamMember, errors.Error) {
+	var members []teamMember
+	err := d.db.All(&members,
+		dal.Select("tu.team_id, tu.user_id, u.name, u.email, tu.start_date, tu.end_date"),
+		dal.From("team_users tu"),
+		dal.Join("LEFT JOIN users u ON u.id = tu.user_id"),
+		dal.Where("tu.team_id IN ?", teamIds),
+		dal.Orderby("tu.team_id, tu.user_id, tu.start_date"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if members[i].StartDate != nil && !members[i].StartDate.After(crossdomain.OpenStartDate) {
+			members[i].StartDate = nil
+		}
+	}
+	return members, nil
+}
+
+func (d *dbStore) saveTeamUsers(teamUsers []*crossdomain.TeamUser) errors.Error {
+	for _, teamUser := range teamUsers {
+		if err := d.db.CreateOrUpdate(teamUser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *dbStore) deleteTeamUser(teamId, userId string, startDate time.Time) errors.Error {
+	return d.db.Delete(&crossdomain.TeamUser{}, dal.Where("team_id = ? AND user_id = ? AND start_date = ?", teamId, userId, startDate))
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockCandidateStore(t *testing.T) (*dbStore, *mockdal.Transaction) {
+	db := mockdal.NewDal(t)
+	db.On("First", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		candidate := args.Get(0).(*crossdomain.UserAccountCandidate)
+		candidate.AccountId = "github:1"
+		candidate.UserId = "u1"
+		candidate.Status = crossdomain.USER_ACCOUNT_CANDIDATE_PENDING
+	}).Return(nil)
+	tx := mockdal.NewTransaction(t)
+	db.On("Begin").Return(tx)
+	return &dbStore{db: db}, tx
+}
+
+func TestAcceptUserAccountCandidate(t *testing.T) {
+	d, tx := mockCandidateStore(t)
+	var deletes []string
+	tx.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		where := args.Get(1).([]dal.Clause)[0]
+		deletes = append(deletes, where.Data.(dal.DalClause).Expr)
+	}).Return(nil)
+	tx.On("UpdateColumn", &crossdomain.UserAccountCandidate{}, "status", crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED, mock.Anything).Return(nil)
+	tx.On("CreateOrUpdate", &crossdomain.UserAccount{UserId: "u1", AccountId: "github:1"}, mock.Anything).Return(nil)
+	tx.On("Update", mock.MatchedBy(func(c *crossdomain.UserAccountCandidate) bool {
+		return c.Status == crossdomain.USER_ACCOUNT_CANDIDATE_ACCEPTED
+	}), mock.Anything).Return(nil)
+	tx.On("Commit").Return(nil)
+
+	err := d.reviewUserAccountCandidate(userAccountDecision{AccountId: "github:1", UserId: "u1", Accept: true})
+	assert.Nil(t, err)
+	// the links of the other users and their unsettled candidates are removed
+	assert.Equal(t, []string{
+		"account_id = ? AND user_id != ?",
+		"account_id = ? AND user_id != ? AND status IN ?",
+	}, deletes)
+}
+
+func TestReviewUserAccountCandidateRollback(t *testing.T) {
+	d, tx := mockCandidateStore(t)
+	tx.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	tx.On("Update", mock.Anything, mock.Anything).Return(errors.Default.New("failed"))
+	tx.On("Rollback").Return(nil)
+
+	err := d.reviewUserAccountCandidate(userAccountDecision{AccountId: "github:1", UserId: "u1", Accept: false})
+	assert.NotNil(t, err)
+	tx.AssertNotCalled(t, "Commit")
+}
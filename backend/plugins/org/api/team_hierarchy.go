@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+)
+
+// teamTree answers the questions about the nesting of the teams
+type teamTree struct {
+	teams    map[string]*crossdomain.Team
+	children map[string][]string
+}
+
+func newTeamTree(teams []crossdomain.Team) *teamTree {
+	tree := &teamTree{
+		teams:    make(map[string]*crossdomain.Team),
+		children: make(map[string][]string),
+	}
+	for i := range teams {
+		team := &teams[i]
+		tree.teams[team.Id] = team
+		if team.ParentId != "" {
+			tree.children[team.ParentId] = append(tree.children[team.ParentId], team.Id)
+		}
+	}
+	return tree
+}
+
+// ancestors returns the parent of the team, the parent of the parent and so on up to the root
+func (tree *teamTree) ancestors(teamId string) []*crossdomain.Team {
+	var ancestors []*crossdomain.Team
+	visited := map[string]bool{teamId: true}
+	team := tree.teams[teamId]
+	for team != nil && team.ParentId != "" && !visited[team.ParentId] {
+		visited[team.ParentId] = true
+		team = tree.teams[team.ParentId]
+		if team != nil {
+			ancestors = append(ancestors, team)
+		}
+	}
+	return ancestors
+}
+
+// descendants returns the sub-teams of the team at all levels, the nearer ones first
+func (tree *teamTree) descendants(teamId string) []*crossdomain.Team {
+	var descendants []*crossdomain.Team
+	visited := map[string]bool{teamId: true}
+	queue := []string{teamId}
+	for len(queue) > 0 {
+		for _, childId := range tree.children[queue[0]] {
+			if !visited[childId] {
+				visited[childId] = true
+				descendants = append(descendants, tree.teams[childId])
+				queue = append(queue, childId)
+			}
+		}
+		queue = queue[1:]
+	}
+	return descendants
+}
+
+// validate makes sure the parents exist and there is no cycle
+func (tree *teamTree) validate() errors.Error {
+	for id, team := range tree.teams {
+		if team.ParentId == "" {
+			continue
+		}
+		if tree.teams[team.ParentId] == nil {
+			return errors.BadInput.New(fmt.Sprintf("parent %s of team %s does not exist", team.ParentId, id))
+		}
+		if team.ParentId == id {
+			return errors.BadInput.New(fmt.Sprintf("team %s is a sub-team of itself", id))
+		}
+		for _, ancestor := range tree.ancestors(id) {
+			if ancestor.ParentId == id {
+				return errors.BadInput.New(fmt.Sprintf("team %s is a sub-team of itself", id))
+			}
+		}
+	}
+	return nil
+}
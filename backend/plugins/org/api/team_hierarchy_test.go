@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTeam(id, parentId string) crossdomain.Team {
+	return crossdomain.Team{DomainEntity: domainlayer.DomainEntity{Id: id}, ParentId: parentId}
+}
+
+func teamIds(teams []*crossdomain.Team) []string {
+	var ids []string
+	for _, t := range teams {
+		ids = append(ids, t.Id)
+	}
+	return ids
+}
+
+func TestTeamTree(t *testing.T) {
+	tree := newTeamTree([]crossdomain.Team{
+		newTeam("org", ""),
+		newTeam("eng", "org"),
+		newTeam("web", "eng"),
+		newTeam("api", "eng"),
+		newTeam("web-perf", "web"),
+		newTeam("sales", "org"),
+	})
+	assert.Nil(t, tree.validate())
+	assert.Equal(t, []string{"web", "eng", "org"}, teamIds(tree.ancestors("web-perf")))
+	assert.Empty(t, tree.ancestors("org"))
+	assert.Equal(t, []string{"web", "api", "web-perf"}, teamIds(tree.descendants("eng")))
+	assert.Empty(t, tree.descendants("sales"))
+}
+
+func TestTeamTreeValidate(t *testing.T) {
+	assert.NotNil(t, newTeamTree([]crossdomain.Team{newTeam("a", "missing")}).validate())
+	assert.NotNil(t, newTeamTree([]crossdomain.Team{newTeam("a", "a")}).validate())
+	assert.NotNil(t, newTeamTree([]crossdomain.Team{
+		newTeam("a", "c"),
+		newTeam("b", "a"),
+		newTeam("c", "b"),
+	}).validate())
+}
+
+func TestParseTeamMembership(t *testing.T) {
+	teamUser, err := parseTeamMembership("1")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", teamUser.TeamId)
+	assert.Equal(t, crossdomain.OpenStartDate, teamUser.StartDate)
+	assert.Equal(t, "1", formatTeamMembership(*teamUser))
+	assert.Nil(t, teamUser.EndDate)
+
+	teamUser, err = parseTeamMembership("team@a@2023-01-01/2023-06-30")
+	assert.Nil(t, err)
+	assert.Equal(t, "team@a", teamUser.TeamId)
+	assert.Equal(t, "2023-01-01", teamUser.StartDate.Format(TimeFormat))
+	assert.Equal(t, "2023-06-30", teamUser.EndDate.Format(TimeFormat))
+	assert.Equal(t, "team@a@2023-01-01/2023-06-30", formatTeamMembership(*teamUser))
+
+	teamUser, err = parseTeamMembership("2@2023-07-01/")
+	assert.Nil(t, err)
+	assert.Nil(t, teamUser.EndDate)
+	assert.Equal(t, "2@2023-07-01/", formatTeamMembership(*teamUser))
+
+	teamUser, err = parseTeamMembership("3@/2023-06-30")
+	assert.Nil(t, err)
+	assert.False(t, teamUser.HasStartDate())
+	assert.Equal(t, "3@/2023-06-30", formatTeamMembership(*teamUser))
+
+	for _, invalid := range []string{"1@2023-07-01", "1@2023-13-01/", "1@2023-07-01/2023-06-30", "@2023-01-01/"} {
+		_, err = parseTeamMembership(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestUserTeamMembershipPeriods(t *testing.T) {
+	u := &user{}
+	_, teamUsers, err := u.toDomainLayer([]user{{Id: "u1", TeamIds: "1@2023-01-01/2023-03-31;1@2023-09-01/"}})
+	assert.Nil(t, err)
+	assert.Len(t, teamUsers, 2)
+	assert.NotEqual(t, teamUsers[0].StartDate, teamUsers[1].StartDate)
+	users := u.fromDomainLayer([]crossdomain.User{{DomainEntity: domainlayer.DomainEntity{Id: "u1"}}}, []crossdomain.TeamUser{*teamUsers[0], *teamUsers[1]})
+	assert.Equal(t, "1@2023-01-01/2023-03-31;1@2023-09-01/", users[0].TeamIds)
+}
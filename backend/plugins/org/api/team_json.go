@@ -0,0 +1,318 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+type teamJson struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Alias        string `json:"alias"`
+	ParentId     string `json:"parentId"`
+	SortingIndex int    `json:"sortingIndex"`
+}
+
+func newTeamJson(t *crossdomain.Team) teamJson {
+	return teamJson{
+		Id:           t.Id,
+		Name:         t.Name,
+		Alias:        t.Alias,
+		ParentId:     t.ParentId,
+		SortingIndex: t.SortingIndex,
+	}
+}
+
+type teamDetail struct {
+	teamJson
+	// Ancestors are the parent teams up to the root, the nearest first
+	Ancestors []teamJson `json:"ancestors"`
+	// Descendants are the sub-teams at all levels
+	Descendants []teamJson `json:"descendants"`
+}
+
+type teamMember struct {
+	TeamId    string     `json:"teamId"`
+	UserId    string     `json:"userId"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	StartDate *time.Time `json:"startDate"`
+	EndDate   *time.Time `json:"endDate"`
+}
+
+type teamMembership struct {
+	UserId    string `json:"userId"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+func toTeamJsons(teams []*crossdomain.Team) []teamJson {
+	result := make([]teamJson, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, newTeamJson(t))
+	}
+	return result
+}
+
+func (h *Handlers) findTeam(teamId string) (*teamTree, *crossdomain.Team, errors.Error) {
+	teams, err := h.store.findTeams()
+	if err != nil {
+		return nil, nil, err
+	}
+	tree := newTeamTree(teams)
+	team := tree.teams[teamId]
+	if team == nil {
+		return nil, nil, errors.NotFound.New(fmt.Sprintf("team %s not found", teamId))
+	}
+	return tree, team, nil
+}
+
+// ListTeams returns all teams
+// @Summary      Get teams
+// @Description  get teams in json format
+// @Tags 		 plugins/org
+// @Produce      json
+// @Success      200  {object} []teamJson
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [get]
+func (h *Handlers) ListTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	teams, err := h.store.findTeams()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]teamJson, 0, len(teams))
+	for i := range teams {
+		result = append(result, newTeamJson(&teams[i]))
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
+
+// SaveTeams creates or updates the teams
+// @Summary      Create or update teams
+// @Description  create or update teams, the parents must exist either in the request or in the database
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body object true "{\"teams\": [teamJson]}"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams [post]
+func (h *Handlers) SaveTeams(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var body struct {
+		Teams []teamJson `json:"teams"`
+	}
+	if err := helper.Decode(input.Body, &body, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid teams")
+	}
+	existing, err := h.store.findTeams()
+	if err != nil {
+		return nil, err
+	}
+	var teams []*crossdomain.Team
+	for _, t := range body.Teams {
+		if t.Id == "" {
+			return nil, errors.BadInput.New("id of team is required")
+		}
+		team := &crossdomain.Team{
+			DomainEntity: domainlayer.DomainEntity{Id: t.Id},
+			Name:         t.Name,
+			Alias:        t.Alias,
+			ParentId:     t.ParentId,
+			SortingIndex: t.SortingIndex,
+		}
+		teams = append(teams, team)
+		existing = append(existing, *team)
+	}
+	// the later ones in the list win, so the teams in the request take the place of the existing ones
+	merged := make(map[string]crossdomain.Team)
+	for _, t := range existing {
+		merged[t.Id] = t
+	}
+	all := make([]crossdomain.Team, 0, len(merged))
+	for _, t := range merged {
+		all = append(all, t)
+	}
+	if err = newTeamTree(all).validate(); err != nil {
+		return nil, err
+	}
+	if err = h.store.saveTeams(teams); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// GetTeamDetail returns the team with its parent teams and sub-teams
+// @Summary      Get a team
+// @Description  get a team with its parent teams up to the root and its sub-teams at all levels
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Produce      json
+// @Success      200  {object} teamDetail
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [get]
+func (h *Handlers) GetTeamDetail(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	tree, team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	detail := &teamDetail{
+		teamJson:    newTeamJson(team),
+		Ancestors:   toTeamJsons(tree.ancestors(team.Id)),
+		Descendants: toTeamJsons(tree.descendants(team.Id)),
+	}
+	return &plugin.ApiResourceOutput{Body: detail, Status: http.StatusOK}, nil
+}
+
+// DeleteTeam deletes the team and its memberships, its sub-teams are moved up to its parent
+// @Summary      Delete a team
+// @Description  delete a team and its memberships, its sub-teams are moved up to its parent
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId} [delete]
+func (h *Handlers) DeleteTeam(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	_, team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	if err = h.store.deleteTeam(team); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// ListTeamMembers returns the memberships of the team
+// @Summary      Get the members of a team
+// @Description  get the memberships of a team, optionally only the ones active at the date and with the sub-teams
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Param        date query string false "only the memberships active at the date, like 2024-01-31"
+// @Param        includeSubTeams query bool false "include the members of the sub-teams at all levels"
+// @Produce      json
+// @Success      200  {object} []teamMember
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/members [get]
+func (h *Handlers) ListTeamMembers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	tree, team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDate(input.Query.Get("date"))
+	if err != nil {
+		return nil, err
+	}
+	teamIds := []string{team.Id}
+	if input.Query.Get("includeSubTeams") == "true" {
+		for _, t := range tree.descendants(team.Id) {
+			teamIds = append(teamIds, t.Id)
+		}
+	}
+	members, err := h.store.findTeamMembers(teamIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]teamMember, 0, len(members))
+	for _, m := range members {
+		membership := crossdomain.TeamUser{StartDate: crossdomain.OpenStartDate, EndDate: m.EndDate}
+		if m.StartDate != nil {
+			membership.StartDate = *m.StartDate
+		}
+		if date == nil || membership.ActiveAt(*date) {
+			result = append(result, m)
+		}
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
+
+// SaveTeamMembers creates or updates the memberships of the team
+// @Summary      Create or update the members of a team
+// @Description  create or update the memberships of a team, the dates are like 2024-01-31 and can be left empty for open-ended memberships
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        teamId path string true "team id"
+// @Param        body body object true "{\"members\": [teamMembership]}"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/members [post]
+func (h *Handlers) SaveTeamMembers(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	_, team, err := h.findTeam(input.Params["teamId"])
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		Members []teamMembership `json:"members"`
+	}
+	if err = helper.Decode(input.Body, &body, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid members")
+	}
+	var teamUsers []*crossdomain.TeamUser
+	for _, m := range body.Members {
+		if m.UserId == "" {
+			return nil, errors.BadInput.New("userId of member is required")
+		}
+		teamUser, err := parseTeamMembership(team.Id + "@" + m.StartDate + "/" + m.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		teamUser.UserId = m.UserId
+		teamUsers = append(teamUsers, teamUser)
+	}
+	if err = h.store.saveTeamUsers(teamUsers); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
+
+// DeleteTeamMember deletes a membership period of the user in the team
+// @Summary      Delete a member of a team
+// @Description  delete the membership of the user in the team starting at the startDate, set the endDate instead to keep the history
+// @Tags 		 plugins/org
+// @Param        teamId path string true "team id"
+// @Param        userId path string true "user id"
+// @Param        startDate query string false "start date of the membership like 2024-01-31, empty for the membership without a start date"
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/teams/{teamId}/members/{userId} [delete]
+func (h *Handlers) DeleteTeamMember(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	startDate, err := parseStartDate(input.Query.Get("startDate"))
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.deleteTeamUser(input.Params["teamId"], input.Params["userId"], startDate)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
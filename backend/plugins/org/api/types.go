@@ -18,8 +18,11 @@ limitations under the License.
 package api
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
@@ -31,7 +34,7 @@ var fakeUsers = []user{{
 	Id:      "1",
 	Name:    "Tyrone K. Cummings",
 	Email:   "TyroneKCummings@teleworm.us",
-	TeamIds: "1;2",
+	TeamIds: "1@2023-01-01/2023-06-30;2@2023-07-01/",
 }, {
 	Id:      "2",
 	Name:    "Dorothy R. Updegraff",
@@ -88,7 +91,7 @@ func (*user) fromDomainLayer(users []crossdomain.User, teamUsers []crossdomain.T
 	var result []user
 	teamUserMap := make(map[string][]string)
 	for _, tu := range teamUsers {
-		teamUserMap[tu.UserId] = append(teamUserMap[tu.UserId], tu.TeamId)
+		teamUserMap[tu.UserId] = append(teamUserMap[tu.UserId], formatTeamMembership(tu))
 	}
 	for _, u := range users {
 		result = append(result, user{
@@ -101,26 +104,95 @@ func (*user) fromDomainLayer(users []crossdomain.User, teamUsers []crossdomain.T
 	return result
 }
 
-func (*user) toDomainLayer(uu []user) (users []*crossdomain.User, teamUsers []*crossdomain.TeamUser) {
+func (*user) toDomainLayer(uu []user) (users []*crossdomain.User, teamUsers []*crossdomain.TeamUser, err errors.Error) {
 	for _, u := range uu {
 		users = append(users, &crossdomain.User{
 			DomainEntity: domainlayer.DomainEntity{Id: u.Id},
 			Email:        u.Email,
 			Name:         u.Name,
 		})
-		for _, teamId := range strings.Split(u.TeamIds, ";") {
-			if u.Id == "" || teamId == "" {
+		for _, membership := range strings.Split(u.TeamIds, ";") {
+			if u.Id == "" || membership == "" {
 				continue
 			}
-			teamUsers = append(teamUsers, &crossdomain.TeamUser{
-				TeamId: teamId,
-				UserId: u.Id,
-			})
+			teamUser, err := parseTeamMembership(membership)
+			if err != nil {
+				return nil, nil, errors.BadInput.Wrap(err, fmt.Sprintf("invalid TeamIds of user %s", u.Id))
+			}
+			teamUser.UserId = u.Id
+			teamUsers = append(teamUsers, teamUser)
 		}
 	}
 	return
 }
 
+// parseTeamMembership parses the team id with the optional dates like "1@2023-01-01/2023-06-30", either date can
+// be left out for an open-ended membership, like "1@2023-07-01/"
+func parseTeamMembership(membership string) (*crossdomain.TeamUser, errors.Error) {
+	teamUser := &crossdomain.TeamUser{TeamId: membership, StartDate: crossdomain.OpenStartDate}
+	at := strings.LastIndex(membership, "@")
+	if at < 0 {
+		return teamUser, nil
+	}
+	teamUser.TeamId = membership[:at]
+	dates := strings.Split(membership[at+1:], "/")
+	if teamUser.TeamId == "" || len(dates) != 2 {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s should be like teamId@startDate/endDate", membership))
+	}
+	var err errors.Error
+	if teamUser.StartDate, err = parseStartDate(dates[0]); err != nil {
+		return nil, err
+	}
+	if teamUser.EndDate, err = parseDate(dates[1]); err != nil {
+		return nil, err
+	}
+	if teamUser.EndDate != nil && teamUser.EndDate.Before(teamUser.StartDate) {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s ends before it starts", membership))
+	}
+	return teamUser, nil
+}
+
+func formatTeamMembership(tu crossdomain.TeamUser) string {
+	if !tu.HasStartDate() && tu.EndDate == nil {
+		return tu.TeamId
+	}
+	return tu.TeamId + "@" + formatStartDate(tu) + "/" + formatDate(tu.EndDate)
+}
+
+// parseStartDate parses the start date of a membership, which is crossdomain.OpenStartDate when absent
+func parseStartDate(s string) (time.Time, errors.Error) {
+	t, err := parseDate(s)
+	if err != nil || t == nil {
+		return crossdomain.OpenStartDate, err
+	}
+	return *t, nil
+}
+
+func formatStartDate(tu crossdomain.TeamUser) string {
+	if !tu.HasStartDate() {
+		return ""
+	}
+	return tu.StartDate.Format(TimeFormat)
+}
+
+func parseDate(s string) (*time.Time, errors.Error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(TimeFormat, s)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("date %s should be like %s", s, TimeFormat))
+	}
+	return &t, nil
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(TimeFormat)
+}
+
 func (*user) fakeData() []user {
 	return fakeUsers
 }
@@ -64,7 +64,7 @@ func (h *Handlers) GetUser(input *plugin.ApiResourceInput) (*plugin.ApiResourceO
 
 // CreateUser accepts a CSV file containing user information mapping and saves it to the database
 // @Summary      Upload users.csv file
-// @Description  upload users.csv file
+// @Description  upload users.csv file, TeamIds are separated by ";" and each may carry the membership dates like "1@2023-01-01/2023-06-30"
 // @Tags 		 plugins/org
 // @Accept       multipart/form-data
 // @Param        file formData file true "select file to upload"
@@ -81,7 +81,10 @@ func (h *Handlers) CreateUser(input *plugin.ApiResourceInput) (*plugin.ApiResour
 	}
 	var u *user
 	var items []interface{}
-	users, teamUsers := u.toDomainLayer(uu)
+	users, teamUsers, err := u.toDomainLayer(uu)
+	if err != nil {
+		return nil, err
+	}
 	for _, user := range users {
 		items = append(items, user)
 	}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"net/http"
+)
+
+type userAccountCandidate struct {
+	AccountId       string  `json:"accountId"`
+	AccountEmail    string  `json:"accountEmail"`
+	AccountFullName string  `json:"accountFullName"`
+	AccountUserName string  `json:"accountUserName"`
+	UserId          string  `json:"userId"`
+	UserName        string  `json:"userName"`
+	UserEmail       string  `json:"userEmail"`
+	Score           float64 `json:"score"`
+	Reasons         string  `json:"reasons"`
+	Status          string  `json:"status"`
+}
+
+type userAccountDecision struct {
+	AccountId string `json:"accountId" mapstructure:"accountId"`
+	UserId    string `json:"userId" mapstructure:"userId"`
+	Accept    bool   `json:"accept" mapstructure:"accept"`
+}
+
+type userAccountReview struct {
+	Decisions []userAccountDecision `json:"decisions" mapstructure:"decisions"`
+}
+
+// GetUserAccountCandidates returns the guessed links between users and accounts
+// @Summary      Get the review queue of user/account links
+// @Description  get the candidates of user/account links, filtered by the status which defaults to PENDING, use ALL to get all of them
+// @Tags 		 plugins/org
+// @Param        status query string false "PENDING, AUTO, ACCEPTED, REJECTED or ALL"
+// @Produce      json
+// @Success      200  {object} []userAccountCandidate
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_candidates [get]
+func (h *Handlers) GetUserAccountCandidates(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	status := input.Query.Get("status")
+	switch status {
+	case "":
+		status = crossdomain.USER_ACCOUNT_CANDIDATE_PENDING
+	case "ALL":
+		status = ""
+	case crossdomain.USER_ACCOUNT_CANDIDATE_PENDING, crossdomain.USER_ACCOUNT_CANDIDATE_AUTO,
+		crossdomain.USER_ACCOUNT_CANDIDATE_ACCEPTED, crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED:
+	default:
+		return nil, errors.BadInput.New("unknown status " + status)
+	}
+	candidates, err := h.store.findUserAccountCandidates(status)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: candidates, Status: http.StatusOK}, nil
+}
+
+// ReviewUserAccountCandidates accepts or rejects the guessed links between users and accounts
+// @Summary      Review user/account links
+// @Description  accepting a candidate links the account to the user, rejecting one unlinks them, and the decisions are kept across runs
+// @Tags 		 plugins/org
+// @Accept       application/json
+// @Param        body body userAccountReview true "json"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/user_account_candidates [post]
+func (h *Handlers) ReviewUserAccountCandidates(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var review userAccountReview
+	if err := helper.Decode(input.Body, &review, nil); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid review")
+	}
+	for _, decision := range review.Decisions {
+		if decision.AccountId == "" || decision.UserId == "" {
+			return nil, errors.BadInput.New("accountId and userId are required")
+		}
+		if err := h.store.reviewUserAccountCandidate(decision); err != nil {
+			return nil, err
+		}
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
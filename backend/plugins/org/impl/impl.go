@@ -60,6 +60,8 @@ func (p Org) Name() string {
 func (p Org) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
 		tasks.ConnectUserAccountsExactMeta,
+		tasks.ConnectUserAccountsFuzzyMeta,
+		tasks.AttributeTeamsMeta,
 		tasks.SetProjectMappingMeta,
 	}
 }
@@ -107,6 +109,21 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetTeam,
 			"PUT": p.handlers.CreateTeam,
 		},
+		"teams": {
+			"GET":  p.handlers.ListTeams,
+			"POST": p.handlers.SaveTeams,
+		},
+		"teams/:teamId": {
+			"GET":    p.handlers.GetTeamDetail,
+			"DELETE": p.handlers.DeleteTeam,
+		},
+		"teams/:teamId/members": {
+			"GET":  p.handlers.ListTeamMembers,
+			"POST": p.handlers.SaveTeamMembers,
+		},
+		"teams/:teamId/members/:userId": {
+			"DELETE": p.handlers.DeleteTeamMember,
+		},
 		"users.csv": {
 			"GET": p.handlers.GetUser,
 			"PUT": p.handlers.CreateUser,
@@ -116,6 +133,10 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetUserAccountMapping,
 			"PUT": p.handlers.CreateUserAccountMapping,
 		},
+		"user_account_candidates": {
+			"GET":  p.handlers.GetUserAccountCandidates,
+			"POST": p.handlers.ReviewUserAccountCandidates,
+		},
 		"project_mapping.csv": {
 			"GET": p.handlers.GetProjectMapping,
 			"PUT": p.handlers.CreateProjectMapping,
@@ -0,0 +1,257 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+)
+
+// scores of the signals, an account is scored by its strongest signal plus the co-occurrence bonus
+const (
+	scoreEmail          = 1.0
+	scoreNoReplyLogin   = 0.9
+	scoreUserName       = 0.8
+	scoreEmailLocalPart = 0.7
+	scoreName           = 0.7
+	scoreNameLogin      = 0.6
+	scoreCoOccurrence   = 0.1
+	maxCoOccurrence     = 0.3
+)
+
+// noReplyEmailPatterns capture the login out of the no-reply emails of the git hosting services
+var noReplyEmailPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`),
+	regexp.MustCompile(`^(?:\d+-)?([^@]+)@users\.noreply\.gitlab\.com$`),
+}
+
+// IdentityMatch is a user the account may belong to
+type IdentityMatch struct {
+	UserId  string
+	Score   float64
+	Reasons []string
+}
+
+// IdentityResolver scores the users an account may belong to, by the identities of the users and of the
+// accounts already linked to them
+type IdentityResolver struct {
+	emails      map[string]map[string]bool
+	logins      map[string]map[string]bool
+	names       map[string]map[string]bool
+	nameLogins  map[string]map[string]bool
+	accountUser map[string]string
+	// pull request author account id -> user id of the commit authors -> number of pull requests
+	coOccurrenceByAccount map[string]map[string]int
+	// commit author email -> user id of the pull request authors -> number of pull requests
+	coOccurrenceByEmail map[string]map[string]int
+}
+
+func NewIdentityResolver(users []crossdomain.User, accounts []crossdomain.Account, userAccounts []crossdomain.UserAccount) *IdentityResolver {
+	r := &IdentityResolver{
+		emails:                make(map[string]map[string]bool),
+		logins:                make(map[string]map[string]bool),
+		names:                 make(map[string]map[string]bool),
+		nameLogins:            make(map[string]map[string]bool),
+		accountUser:           make(map[string]string),
+		coOccurrenceByAccount: make(map[string]map[string]int),
+		coOccurrenceByEmail:   make(map[string]map[string]int),
+	}
+	for _, user := range users {
+		r.addEmail(user.Id, user.Email)
+		r.addName(user.Id, user.Name)
+	}
+	for _, userAccount := range userAccounts {
+		r.accountUser[userAccount.AccountId] = userAccount.UserId
+	}
+	for _, account := range accounts {
+		userId, ok := r.accountUser[account.Id]
+		if !ok {
+			continue
+		}
+		r.addEmail(userId, account.Email)
+		r.addName(userId, account.FullName)
+		index(r.logins, normalizeLogin(account.UserName), userId)
+	}
+	for name, userIds := range r.names {
+		for _, login := range nameLogins(name) {
+			for userId := range userIds {
+				index(r.nameLogins, login, userId)
+			}
+		}
+	}
+	return r
+}
+
+func index(m map[string]map[string]bool, key, userId string) {
+	if key == "" {
+		return
+	}
+	if m[key] == nil {
+		m[key] = make(map[string]bool)
+	}
+	m[key][userId] = true
+}
+
+func (r *IdentityResolver) addEmail(userId, email string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	index(r.emails, email, userId)
+	index(r.logins, emailLogin(email), userId)
+}
+
+func (r *IdentityResolver) addName(userId, name string) {
+	index(r.names, normalizeName(name), userId)
+}
+
+// AddCoOccurrence records that the pull requests authored by the account contain commits authored with the email
+func (r *IdentityResolver) AddCoOccurrence(accountId, email string, count int) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	for userId := range r.emails[email] {
+		if r.coOccurrenceByAccount[accountId] == nil {
+			r.coOccurrenceByAccount[accountId] = make(map[string]int)
+		}
+		r.coOccurrenceByAccount[accountId][userId] += count
+	}
+	if userId, ok := r.accountUser[accountId]; ok && email != "" {
+		if r.coOccurrenceByEmail[email] == nil {
+			r.coOccurrenceByEmail[email] = make(map[string]int)
+		}
+		r.coOccurrenceByEmail[email][userId] += count
+	}
+}
+
+// Resolve returns the users the account may belong to, the most likely first
+func (r *IdentityResolver) Resolve(account *crossdomain.Account) []*IdentityMatch {
+	matches := make(map[string]*IdentityMatch)
+	signal := func(users map[string]bool, score float64, reason string) {
+		for userId := range users {
+			match := matches[userId]
+			if match == nil {
+				match = &IdentityMatch{UserId: userId}
+				matches[userId] = match
+			}
+			if score > match.Score {
+				match.Score = score
+			}
+			if len(match.Reasons) == 0 || match.Reasons[len(match.Reasons)-1] != reason {
+				match.Reasons = append(match.Reasons, reason)
+			}
+		}
+	}
+	email := strings.ToLower(strings.TrimSpace(account.Email))
+	signal(r.emails[email], scoreEmail, "email")
+	for _, pattern := range noReplyEmailPatterns {
+		if m := pattern.FindStringSubmatch(email); m != nil {
+			signal(r.logins[normalizeLogin(m[1])], scoreNoReplyLogin, "noReplyEmail")
+		}
+	}
+	userName := normalizeLogin(account.UserName)
+	signal(r.logins[userName], scoreUserName, "userName")
+	localPart := emailLogin(email)
+	if localPart != userName {
+		signal(r.logins[localPart], scoreEmailLocalPart, "emailLocalPart")
+	}
+	signal(r.names[normalizeName(account.FullName)], scoreName, "name")
+	signal(r.nameLogins[userName], scoreNameLogin, "nameLogin")
+	if localPart != userName {
+		signal(r.nameLogins[localPart], scoreNameLogin, "nameLogin")
+	}
+
+	coOccurrences := make(map[string]int)
+	for userId, count := range r.coOccurrenceByAccount[account.Id] {
+		coOccurrences[userId] += count
+	}
+	for userId, count := range r.coOccurrenceByEmail[email] {
+		coOccurrences[userId] += count
+	}
+	result := make([]*IdentityMatch, 0, len(matches))
+	for _, match := range matches {
+		if count := coOccurrences[match.UserId]; count > 0 {
+			bonus := scoreCoOccurrence * float64(count)
+			if bonus > maxCoOccurrence {
+				bonus = maxCoOccurrence
+			}
+			match.Score += bonus
+			match.Reasons = append(match.Reasons, "coOccurrence")
+		}
+		if match.Score > 1 {
+			match.Score = 1
+		}
+		result = append(result, match)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].UserId < result[j].UserId
+	})
+	return result
+}
+
+// normalizeName lowercases the name and sorts its words, so "Smith, John" and "john smith" are the same
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+// normalizeLogin lowercases the login and drops the separators, so "john.smith" and "John_Smith" are the same
+func normalizeLogin(login string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, login)
+}
+
+// emailLogin returns the normalized local part of the email, without the "+tag"
+func emailLogin(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return ""
+	}
+	localPart := email[:at]
+	if plus := strings.Index(localPart, "+"); plus > 0 {
+		localPart = localPart[:plus]
+	}
+	return normalizeLogin(localPart)
+}
+
+// nameLogins returns the logins commonly derived from the normalized name, like "johnsmith" and "jsmith"
+func nameLogins(name string) []string {
+	words := strings.Fields(name)
+	var logins []string
+	if len(words) < 2 {
+		return logins
+	}
+	for i := range words {
+		for j := range words {
+			if i == j {
+				continue
+			}
+			logins = append(logins, words[i]+words[j], string([]rune(words[i])[:1])+words[j])
+		}
+	}
+	return logins
+}
@@ -0,0 +1,109 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityResolver(t *testing.T) {
+	users := []crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "u1"}, Name: "John Smith", Email: "john.smith@example.com"},
+		{DomainEntity: domainlayer.DomainEntity{Id: "u2"}, Name: "Jane Doe", Email: "jane@example.com"},
+	}
+	accounts := []crossdomain.Account{
+		{DomainEntity: domainlayer.DomainEntity{Id: "github:1"}, UserName: "octojane", Email: "jane@example.com"},
+	}
+	userAccounts := []crossdomain.UserAccount{
+		{UserId: "u2", AccountId: "github:1"},
+	}
+	resolver := NewIdentityResolver(users, accounts, userAccounts)
+
+	resolve := func(account crossdomain.Account) *IdentityMatch {
+		matches := resolver.Resolve(&account)
+		if len(matches) == 0 {
+			return nil
+		}
+		return matches[0]
+	}
+
+	// github no-reply email of the login known from the linked account
+	match := resolve(crossdomain.Account{Email: "12345+OctoJane@users.noreply.github.com"})
+	assert.Equal(t, "u2", match.UserId)
+	assert.Equal(t, scoreNoReplyLogin, match.Score)
+	assert.Equal(t, []string{"noReplyEmail"}, match.Reasons)
+
+	// the email in different case
+	match = resolve(crossdomain.Account{Email: "John.Smith@Example.com"})
+	assert.Equal(t, "u1", match.UserId)
+	assert.Equal(t, scoreEmail, match.Score)
+
+	// jira display name in another order
+	match = resolve(crossdomain.Account{FullName: "Smith, John"})
+	assert.Equal(t, "u1", match.UserId)
+	assert.Equal(t, scoreName, match.Score)
+
+	// gitlab username derived from the name
+	match = resolve(crossdomain.Account{UserName: "jsmith"})
+	assert.Equal(t, "u1", match.UserId)
+	assert.Equal(t, scoreNameLogin, match.Score)
+	assert.Equal(t, []string{"nameLogin"}, match.Reasons)
+
+	// the local part of the email at another domain
+	match = resolve(crossdomain.Account{Email: "john_smith+dev@corp.example.org"})
+	assert.Equal(t, "u1", match.UserId)
+	assert.Equal(t, scoreEmailLocalPart, match.Score)
+
+	assert.Nil(t, resolve(crossdomain.Account{UserName: "someone", FullName: "Some One"}))
+}
+
+func TestIdentityResolverCoOccurrence(t *testing.T) {
+	users := []crossdomain.User{
+		{DomainEntity: domainlayer.DomainEntity{Id: "u1"}, Name: "John Smith", Email: "john.smith@example.com"},
+	}
+	resolver := NewIdentityResolver(users, nil, nil)
+	// the pull requests of the account are made of the commits of the user
+	resolver.AddCoOccurrence("gitlab:7", "john.smith@example.com", 2)
+
+	matches := resolver.Resolve(&crossdomain.Account{
+		DomainEntity: domainlayer.DomainEntity{Id: "gitlab:7"},
+		UserName:     "jsmith",
+	})
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "u1", matches[0].UserId)
+	assert.InDelta(t, scoreNameLogin+2*scoreCoOccurrence, matches[0].Score, 1e-9)
+	assert.Equal(t, []string{"nameLogin", "coOccurrence"}, matches[0].Reasons)
+
+	// co-occurrence alone doesn't make a match
+	assert.Empty(t, resolver.Resolve(&crossdomain.Account{
+		DomainEntity: domainlayer.DomainEntity{Id: "gitlab:7"},
+		UserName:     "stranger",
+	}))
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "john smith", normalizeName("Smith, John"))
+	assert.Equal(t, "john smith", normalizeName(" john  SMITH "))
+	assert.Equal(t, "johnsmith", normalizeLogin("John.Smith"))
+	assert.Equal(t, "john", emailLogin("john+tag@example.com"))
+	assert.Equal(t, "", emailLogin("invalid"))
+}
@@ -22,6 +22,10 @@ import "github.com/apache/incubator-devlake/core/plugin"
 type Options struct {
 	ConnectionId    uint64           `json:"connectionId"`
 	ProjectMappings []ProjectMapping `json:"projectMappings"`
+	// AutoLinkScore is the score above which an account is linked to the user automatically
+	AutoLinkScore float64 `json:"autoLinkScore"`
+	// ReviewScore is the score above which an account is put into the review queue
+	ReviewScore float64 `json:"reviewScore"`
 }
 
 // ProjectMapping represents the relations between project and scopes
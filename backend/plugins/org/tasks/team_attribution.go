@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var AttributeTeamsMeta = plugin.SubTaskMeta{
+	Name:             "attributeTeams",
+	EntryPoint:       AttributeTeams,
+	EnabledByDefault: true,
+	Description:      "attribute pull requests, commits and issues to the teams their authors belonged to at the time",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{
+		crossdomain.TeamUser{}.TableName(),
+		crossdomain.UserAccount{}.TableName(),
+		code.PullRequest{}.TableName(),
+		code.Commit{}.TableName(),
+		ticket.Issue{}.TableName(),
+	},
+	ProductTables: []string{crossdomain.TeamAttribution{}.TableName()},
+}
+
+// authoredRow is a pull request, commit or issue with its author, which is either an account id or an email
+type authoredRow struct {
+	RowId   string
+	Author  string
+	RowDate *time.Time
+}
+
+// TeamMemberships finds the teams the users belonged to at the time
+type TeamMemberships struct {
+	memberships map[string][]crossdomain.TeamUser
+}
+
+func NewTeamMemberships(teamUsers []crossdomain.TeamUser) *TeamMemberships {
+	m := &TeamMemberships{memberships: make(map[string][]crossdomain.TeamUser)}
+	for _, teamUser := range teamUsers {
+		m.memberships[teamUser.UserId] = append(m.memberships[teamUser.UserId], teamUser)
+	}
+	return m
+}
+
+// TeamsAt returns the teams the user belonged to at the time, or to the teams the user currently belongs to if
+// the time is unknown
+func (m *TeamMemberships) TeamsAt(userId string, t *time.Time) []string {
+	var teamIds []string
+	for _, membership := range m.memberships[userId] {
+		if (t == nil && membership.EndDate == nil) || (t != nil && membership.ActiveAt(*t)) {
+			teamIds = append(teamIds, membership.TeamId)
+		}
+	}
+	return teamIds
+}
+
+func AttributeTeams(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	err := db.Delete(&crossdomain.TeamAttribution{}, dal.Where("1 = 1"))
+	if err != nil {
+		return err
+	}
+	var teamUsers []crossdomain.TeamUser
+	if err = db.All(&teamUsers); err != nil {
+		return err
+	}
+	if len(teamUsers) == 0 {
+		return nil
+	}
+	memberships := NewTeamMemberships(teamUsers)
+
+	// the accounts and the emails of the users, commits are authored by emails
+	accountUsers := make(map[string]string)
+	var userAccounts []crossdomain.UserAccount
+	if err = db.All(&userAccounts); err != nil {
+		return err
+	}
+	for _, userAccount := range userAccounts {
+		accountUsers[userAccount.AccountId] = userAccount.UserId
+	}
+	emailUsers := make(map[string]string)
+	var accounts []crossdomain.Account
+	if err = db.All(&accounts, dal.Where("email != ''")); err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		if userId, ok := accountUsers[account.Id]; ok {
+			emailUsers[strings.ToLower(account.Email)] = userId
+		}
+	}
+	var users []crossdomain.User
+	if err = db.All(&users, dal.Where("email != ''")); err != nil {
+		return err
+	}
+	for _, user := range users {
+		emailUsers[strings.ToLower(user.Email)] = user.Id
+	}
+
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.TeamAttribution{}), 500)
+	if err != nil {
+		return err
+	}
+	attribute := func(table string, userOf func(author string) string, clauses ...dal.Clause) errors.Error {
+		cursor, err := db.Cursor(clauses...)
+		if err != nil {
+			return err
+		}
+		defer cursor.Close()
+		for cursor.Next() {
+			row := &authoredRow{}
+			if err = db.Fetch(cursor, row); err != nil {
+				return err
+			}
+			userId := userOf(row.Author)
+			if userId == "" {
+				continue
+			}
+			for _, teamId := range memberships.TeamsAt(userId, row.RowDate) {
+				err = batch.Add(&crossdomain.TeamAttribution{
+					RowTable: table,
+					RowId:    row.RowId,
+					TeamId:   teamId,
+					UserId:   userId,
+					Date:     row.RowDate,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	byAccount := func(author string) string {
+		return accountUsers[author]
+	}
+	byEmail := func(author string) string {
+		return emailUsers[strings.ToLower(author)]
+	}
+
+	err = attribute(code.PullRequest{}.TableName(), byAccount,
+		dal.Select("id AS row_id, author_id AS author, created_date AS row_date"),
+		dal.From(&code.PullRequest{}),
+		dal.Where("author_id != ''"),
+	)
+	if err != nil {
+		return err
+	}
+	err = attribute(ticket.Issue{}.TableName(), byAccount,
+		dal.Select("id AS row_id, creator_id AS author, created_date AS row_date"),
+		dal.From(&ticket.Issue{}),
+		dal.Where("creator_id != ''"),
+	)
+	if err != nil {
+		return err
+	}
+	err = attribute(code.Commit{}.TableName(), byEmail,
+		dal.Select("sha AS row_id, author_email AS author, authored_date AS row_date"),
+		dal.From(&code.Commit{}),
+		dal.Where("author_email != ''"),
+	)
+	if err != nil {
+		return err
+	}
+	return batch.Close()
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamMembershipsTeamsAt(t *testing.T) {
+	date := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+	memberships := NewTeamMemberships([]crossdomain.TeamUser{
+		{TeamId: "web", UserId: "u1", EndDate: date("2023-06-30")},
+		{TeamId: "api", UserId: "u1", StartDate: *date("2023-07-01")},
+		{TeamId: "ops", UserId: "u2"},
+		// u3 left the web team and rejoined it later
+		{TeamId: "web", UserId: "u3", StartDate: crossdomain.OpenStartDate, EndDate: date("2023-03-31")},
+		{TeamId: "web", UserId: "u3", StartDate: *date("2023-09-01")},
+	})
+	assert.Equal(t, []string{"web"}, memberships.TeamsAt("u1", date("2023-01-15")))
+	// the end date is inclusive
+	assert.Equal(t, []string{"web"}, memberships.TeamsAt("u1", func() *time.Time {
+		t := date("2023-06-30").Add(23 * time.Hour)
+		return &t
+	}()))
+	assert.Equal(t, []string{"api"}, memberships.TeamsAt("u1", date("2023-07-01")))
+	// the current teams when the time is unknown
+	assert.Equal(t, []string{"api"}, memberships.TeamsAt("u1", nil))
+	assert.Equal(t, []string{"ops"}, memberships.TeamsAt("u2", date("2020-01-01")))
+	assert.Equal(t, []string{"web"}, memberships.TeamsAt("u3", date("2023-01-15")))
+	assert.Empty(t, memberships.TeamsAt("u3", date("2023-06-01")))
+	assert.Equal(t, []string{"web"}, memberships.TeamsAt("u3", date("2023-10-01")))
+	assert.Empty(t, memberships.TeamsAt("u4", date("2020-01-01")))
+}
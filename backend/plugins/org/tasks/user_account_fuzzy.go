@@ -0,0 +1,190 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const (
+	DefaultAutoLinkScore = 0.8
+	DefaultReviewScore   = 0.5
+	// maxReviewCandidates is the max number of users put into the review queue for an account
+	maxReviewCandidates = 3
+)
+
+var ConnectUserAccountsFuzzyMeta = plugin.SubTaskMeta{
+	Name:             "connectUserAccountsFuzzy",
+	EntryPoint:       ConnectUserAccountsFuzzy,
+	EnabledByDefault: true,
+	Description:      "associate users and accounts by scoring the similar identities, the uncertain ones are put into the review queue",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+	DependencyTables: []string{crossdomain.User{}.TableName(), crossdomain.Account{}.TableName()},
+	ProductTables:    []string{crossdomain.UserAccount{}.TableName(), crossdomain.UserAccountCandidate{}.TableName()},
+}
+
+type coOccurrence struct {
+	AccountId string
+	Email     string
+	Count     int
+}
+
+func ConnectUserAccountsFuzzy(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*TaskData)
+	autoLinkScore, reviewScore := data.Options.AutoLinkScore, data.Options.ReviewScore
+	if autoLinkScore <= 0 {
+		autoLinkScore = DefaultAutoLinkScore
+	}
+	if reviewScore <= 0 {
+		reviewScore = DefaultReviewScore
+	}
+
+	var users []crossdomain.User
+	if err := db.All(&users); err != nil {
+		return err
+	}
+	var accounts []crossdomain.Account
+	if err := db.All(&accounts); err != nil {
+		return err
+	}
+	var userAccounts []crossdomain.UserAccount
+	if err := db.All(&userAccounts); err != nil {
+		return err
+	}
+	var decisions []crossdomain.UserAccountCandidate
+	err := db.All(&decisions, dal.Where("status IN ?", []string{
+		crossdomain.USER_ACCOUNT_CANDIDATE_ACCEPTED,
+		crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED,
+	}))
+	if err != nil {
+		return err
+	}
+	// the pending ones are recalculated every time
+	err = db.Delete(&crossdomain.UserAccountCandidate{}, dal.Where("status = ?", crossdomain.USER_ACCOUNT_CANDIDATE_PENDING))
+	if err != nil {
+		return err
+	}
+
+	userAccountSaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.UserAccount{}), 500)
+	if err != nil {
+		return err
+	}
+	candidateSaver, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&crossdomain.UserAccountCandidate{}), 500)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[string]bool)
+	for _, userAccount := range userAccounts {
+		linked[userAccount.AccountId] = true
+	}
+	rejected := make(map[string]bool)
+	for _, decision := range decisions {
+		if decision.Status == crossdomain.USER_ACCOUNT_CANDIDATE_REJECTED {
+			rejected[decision.AccountId+":"+decision.UserId] = true
+			continue
+		}
+		// the accepted ones are restored in case the links were overwritten
+		if !linked[decision.AccountId] {
+			linked[decision.AccountId] = true
+			userAccount := crossdomain.UserAccount{UserId: decision.UserId, AccountId: decision.AccountId}
+			userAccounts = append(userAccounts, userAccount)
+			if err := userAccountSaver.Add(&userAccount); err != nil {
+				return err
+			}
+		}
+	}
+
+	resolver := NewIdentityResolver(users, accounts, userAccounts)
+	var coOccurrences []coOccurrence
+	err = db.All(&coOccurrences,
+		dal.Select("pr.author_id AS account_id, c.author_email AS email, COUNT(DISTINCT pr.id) AS count"),
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN pull_request_commits prc ON prc.pull_request_id = pr.id"),
+		dal.Join("JOIN commits c ON c.sha = prc.commit_sha"),
+		dal.Where("pr.author_id != '' AND c.author_email != ''"),
+		dal.Groupby("pr.author_id, c.author_email"),
+	)
+	if err != nil {
+		return err
+	}
+	for _, c := range coOccurrences {
+		resolver.AddCoOccurrence(c.AccountId, c.Email, c.Count)
+	}
+
+	taskCtx.SetProgress(0, len(accounts))
+	for i := range accounts {
+		account := &accounts[i]
+		taskCtx.IncProgress(1)
+		if linked[account.Id] {
+			continue
+		}
+		var matches []*IdentityMatch
+		for _, match := range resolver.Resolve(account) {
+			if match.Score >= reviewScore && !rejected[account.Id+":"+match.UserId] {
+				matches = append(matches, match)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		// an account is linked automatically only if there is no tie
+		if matches[0].Score >= autoLinkScore && (len(matches) == 1 || matches[1].Score < matches[0].Score) {
+			err = userAccountSaver.Add(&crossdomain.UserAccount{UserId: matches[0].UserId, AccountId: account.Id})
+			if err != nil {
+				return err
+			}
+			err = candidateSaver.Add(newUserAccountCandidate(account.Id, matches[0], crossdomain.USER_ACCOUNT_CANDIDATE_AUTO))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if len(matches) > maxReviewCandidates {
+			matches = matches[:maxReviewCandidates]
+		}
+		for _, match := range matches {
+			err = candidateSaver.Add(newUserAccountCandidate(account.Id, match, crossdomain.USER_ACCOUNT_CANDIDATE_PENDING))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := userAccountSaver.Close(); err != nil {
+		return err
+	}
+	return candidateSaver.Close()
+}
+
+func newUserAccountCandidate(accountId string, match *IdentityMatch, status string) *crossdomain.UserAccountCandidate {
+	return &crossdomain.UserAccountCandidate{
+		AccountId: accountId,
+		UserId:    match.UserId,
+		Score:     match.Score,
+		Reasons:   strings.Join(match.Reasons, ","),
+		Status:    status,
+	}
+}
@@ -0,0 +1,344 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/refdiff/models"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+type ChangeReportRef struct {
+	Ref       string `json:"ref"`
+	CommitSha string `json:"commitSha"`
+}
+
+type ChangeReportPullRequest struct {
+	Id             string     `json:"id"`
+	PullRequestKey int        `json:"pullRequestKey"`
+	Title          string     `json:"title"`
+	Url            string     `json:"url"`
+	AuthorName     string     `json:"authorName"`
+	MergedDate     *time.Time `json:"mergedDate"`
+}
+
+type ChangeReportIssue struct {
+	Id       string `json:"id"`
+	IssueKey string `json:"issueKey"`
+	Title    string `json:"title"`
+	Url      string `json:"url"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+}
+
+type ChangeReportIssueGroup struct {
+	Type   string               `json:"type"`
+	Issues []*ChangeReportIssue `json:"issues"`
+}
+
+type ChangeReportContributor struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Commits int    `json:"commits"`
+}
+
+type ChangeReportDeployment struct {
+	Id           string     `json:"id"`
+	Name         string     `json:"name"`
+	Environment  string     `json:"environment"`
+	Result       string     `json:"result"`
+	FinishedDate *time.Time `json:"finishedDate"`
+}
+
+// ChangeReport is what changed in the repo between the two refs, i.e. what the commits reachable from To but not
+// from From brought in
+type ChangeReport struct {
+	RepoId       string                     `json:"repoId"`
+	From         ChangeReportRef            `json:"from"`
+	To           ChangeReportRef            `json:"to"`
+	Commits      int                        `json:"commits"`
+	PullRequests []*ChangeReportPullRequest `json:"pullRequests"`
+	IssueGroups  []*ChangeReportIssueGroup  `json:"issueGroups"`
+	Contributors []*ChangeReportContributor `json:"contributors"`
+	Deployments  []*ChangeReportDeployment  `json:"deployments"`
+}
+
+// GetChanges returns the release notes between two refs
+// @Summary get the changes between two refs
+// @Description the merged pull requests, the linked issues grouped by type, the contributors and the deployments
+// @Description between two refs, the commits diff is calculated on demand if it hasn't been done by the pipelines
+// @Tags plugins/refdiff
+// @Param repoId path string true "repo id"
+// @Param from query string true "the old ref, a tag, a branch or a commit sha"
+// @Param to query string true "the new ref, a tag, a branch or a commit sha"
+// @Param format query string false "json or markdown, json by default"
+// @Success 200  {object} ChangeReport
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/refdiff/repos/{repoId}/changes [GET]
+func GetChanges(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	repoId := input.Params["repoId"]
+	from := input.Query.Get("from")
+	to := input.Query.Get("to")
+	format := input.Query.Get("format")
+	if from == "" || to == "" {
+		return nil, errors.BadInput.New("both from and to are required")
+	}
+	if format != "" && format != "json" && format != "markdown" {
+		return nil, errors.BadInput.New("format must be either json or markdown")
+	}
+	db := basicRes.GetDal()
+	fromSha, err := resolveRef(db, repoId, from)
+	if err != nil {
+		return nil, err
+	}
+	toSha, err := resolveRef(db, repoId, to)
+	if err != nil {
+		return nil, err
+	}
+	if err = ensureCommitsDiff(db, repoId, models.RefCommitPair{toSha, fromSha, to, from}); err != nil {
+		return nil, err
+	}
+	report, err := buildChangeReport(db, repoId, ChangeReportRef{from, fromSha}, ChangeReportRef{to, toSha})
+	if err != nil {
+		return nil, err
+	}
+	if format == "markdown" {
+		return &plugin.ApiResourceOutput{
+			Body:        []byte(renderChangeReportMarkdown(report)),
+			ContentType: "text/markdown; charset=utf-8",
+			Status:      http.StatusOK,
+		}, nil
+	}
+	return &plugin.ApiResourceOutput{Body: report, Status: http.StatusOK}, nil
+}
+
+// resolveRef returns the commit sha of the ref, which could be the full ref name, a tag, a branch or a commit sha
+func resolveRef(db dal.Dal, repoId string, refName string) (string, errors.Error) {
+	for _, name := range []string{refName, "refs/tags/" + refName, "refs/heads/" + refName} {
+		ref := &code.Ref{}
+		err := db.First(ref, dal.Where("id = ?", fmt.Sprintf("%s:%s", repoId, name)))
+		if err == nil {
+			return ref.CommitSha, nil
+		}
+		if !db.IsErrorNotFound(err) {
+			return "", errors.Default.Wrap(err, "failed to load the ref")
+		}
+	}
+	count, err := db.Count(
+		dal.From(&code.RepoCommit{}),
+		dal.Where("repo_id = ? AND commit_sha = ?", repoId, refName),
+	)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to load the commit")
+	}
+	if count == 0 {
+		return "", errors.NotFound.New(fmt.Sprintf("ref %s not found in repo %s", refName, repoId))
+	}
+	return refName, nil
+}
+
+// ensureCommitsDiff calculates the commits diff of the pair unless it has been done
+func ensureCommitsDiff(db dal.Dal, repoId string, pair models.RefCommitPair) errors.Error {
+	if pair[0] == pair[1] {
+		return nil
+	}
+	count, err := db.Count(
+		dal.From(&models.FinishedCommitsDiff{}),
+		dal.Where("new_commit_sha = ? AND old_commit_sha = ?", pair[0], pair[1]),
+	)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	commitNodeGraph, err := tasks.LoadCommitNodeGraph(context.Background(), db, repoId)
+	if err != nil {
+		return err
+	}
+	_, _, err = tasks.SaveCommitsDiff(db, basicRes.GetLogger(), commitNodeGraph, pair, nil)
+	return err
+}
+
+func buildChangeReport(db dal.Dal, repoId string, from ChangeReportRef, to ChangeReportRef) (*ChangeReport, errors.Error) {
+	report := &ChangeReport{
+		RepoId:       repoId,
+		From:         from,
+		To:           to,
+		PullRequests: []*ChangeReportPullRequest{},
+		IssueGroups:  []*ChangeReportIssueGroup{},
+		Contributors: []*ChangeReportContributor{},
+		Deployments:  []*ChangeReportDeployment{},
+	}
+	diffCommits := "SELECT commit_sha FROM commits_diffs WHERE new_commit_sha = ? AND old_commit_sha = ?"
+
+	err := db.All(&report.Contributors,
+		dal.Select("c.author_name AS name, c.author_email AS email, COUNT(*) AS commits"),
+		dal.From("commits c"),
+		dal.Where("c.sha IN ("+diffCommits+")", to.CommitSha, from.CommitSha),
+		dal.Groupby("c.author_name, c.author_email"),
+		dal.Orderby("commits DESC, name"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the contributors")
+	}
+	for _, contributor := range report.Contributors {
+		report.Commits += contributor.Commits
+	}
+
+	err = db.All(&report.PullRequests,
+		dal.Select("pr.id, pr.pull_request_key, pr.title, pr.url, pr.author_name, pr.merged_date"),
+		dal.From("pull_requests pr"),
+		dal.Where(`pr.base_repo_id = ? AND pr.merged_date IS NOT NULL AND (
+				pr.merge_commit_sha IN (`+diffCommits+`)
+				OR pr.id IN (SELECT prc.pull_request_id FROM pull_request_commits prc WHERE prc.commit_sha IN (`+diffCommits+`))
+			)`, repoId, to.CommitSha, from.CommitSha, to.CommitSha, from.CommitSha),
+		dal.Orderby("pr.merged_date, pr.pull_request_key"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the pull requests")
+	}
+
+	if len(report.PullRequests) > 0 {
+		prIds := make([]string, 0, len(report.PullRequests))
+		for _, pr := range report.PullRequests {
+			prIds = append(prIds, pr.Id)
+		}
+		var issues []*ChangeReportIssue
+		err = db.All(&issues,
+			dal.Select("DISTINCT i.id, i.issue_key, i.title, i.url, i.type, i.status"),
+			dal.From("issues i"),
+			dal.Join("JOIN pull_request_issues pri ON pri.issue_id = i.id"),
+			dal.Where("pri.pull_request_id IN ?", prIds),
+			dal.Orderby("i.type, i.issue_key"),
+		)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "failed to load the issues")
+		}
+		report.IssueGroups = groupIssuesByType(issues)
+	}
+
+	err = db.All(&report.Deployments,
+		dal.Select("DISTINCT dc.cicd_deployment_id AS id, dc.name, dc.environment, dc.result, dc.finished_date"),
+		dal.From("cicd_deployment_commits dc"),
+		dal.Where("dc.repo_id = ? AND dc.commit_sha IN ("+diffCommits+")", repoId, to.CommitSha, from.CommitSha),
+		dal.Orderby("dc.finished_date"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the deployments")
+	}
+	return report, nil
+}
+
+func groupIssuesByType(issues []*ChangeReportIssue) []*ChangeReportIssueGroup {
+	groups := make(map[string]*ChangeReportIssueGroup)
+	for _, issue := range issues {
+		issueType := issue.Type
+		if issueType == "" {
+			issueType = "OTHER"
+		}
+		group, ok := groups[issueType]
+		if !ok {
+			group = &ChangeReportIssueGroup{Type: issueType}
+			groups[issueType] = group
+		}
+		group.Issues = append(group.Issues, issue)
+	}
+	result := make([]*ChangeReportIssueGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+func renderChangeReportMarkdown(report *ChangeReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Changes from %s to %s\n\n", report.From.Ref, report.To.Ref)
+	fmt.Fprintf(&sb, "%d commits by %d contributors.\n", report.Commits, len(report.Contributors))
+	if len(report.PullRequests) > 0 {
+		sb.WriteString("\n## Pull Requests\n\n")
+		for _, pr := range report.PullRequests {
+			fmt.Fprintf(&sb, "- %s #%d", markdownLink(pr.Title, pr.Url), pr.PullRequestKey)
+			if pr.AuthorName != "" {
+				fmt.Fprintf(&sb, " by %s", pr.AuthorName)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	if len(report.IssueGroups) > 0 {
+		sb.WriteString("\n## Issues\n")
+		for _, group := range report.IssueGroups {
+			fmt.Fprintf(&sb, "\n### %s\n\n", group.Type)
+			for _, issue := range group.Issues {
+				fmt.Fprintf(&sb, "- %s %s", issue.IssueKey, markdownLink(issue.Title, issue.Url))
+				if issue.Status != "" {
+					fmt.Fprintf(&sb, " (%s)", issue.Status)
+				}
+				sb.WriteString("\n")
+			}
+		}
+	}
+	if len(report.Contributors) > 0 {
+		sb.WriteString("\n## Contributors\n\n")
+		for _, contributor := range report.Contributors {
+			fmt.Fprintf(&sb, "- %s (%d commits)\n", contributor.Name, contributor.Commits)
+		}
+	}
+	if len(report.Deployments) > 0 {
+		sb.WriteString("\n## Deployments\n\n")
+		for _, deployment := range report.Deployments {
+			fmt.Fprintf(&sb, "- %s", deployment.Name)
+			details := make([]string, 0, 3)
+			for _, detail := range []string{deployment.Environment, deployment.Result} {
+				if detail != "" {
+					details = append(details, detail)
+				}
+			}
+			if deployment.FinishedDate != nil {
+				details = append(details, deployment.FinishedDate.Format(time.RFC3339))
+			}
+			if len(details) > 0 {
+				fmt.Fprintf(&sb, " (%s)", strings.Join(details, ", "))
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func markdownLink(text string, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", strings.NewReplacer("[", "\\[", "]", "\\]").Replace(text), url)
+}
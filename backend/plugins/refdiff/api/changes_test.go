@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderChangeReportMarkdown(t *testing.T) {
+	finishedDate := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	report := &ChangeReport{
+		From:    ChangeReportRef{Ref: "v1.0.0"},
+		To:      ChangeReportRef{Ref: "v1.1.0"},
+		Commits: 3,
+		PullRequests: []*ChangeReportPullRequest{
+			{PullRequestKey: 12, Title: "Add [beta] API", Url: "https://example.com/pr/12", AuthorName: "alice"},
+			{PullRequestKey: 13, Title: "Fix typo"},
+		},
+		IssueGroups: groupIssuesByType([]*ChangeReportIssue{
+			{IssueKey: "DL-2", Title: "Crash on start", Type: "BUG", Status: "DONE"},
+			{IssueKey: "DL-1", Title: "Beta API", Type: "REQUIREMENT"},
+			{IssueKey: "DL-3", Title: "Cleanup"},
+		}),
+		Contributors: []*ChangeReportContributor{
+			{Name: "alice", Commits: 2},
+			{Name: "bob", Commits: 1},
+		},
+		Deployments: []*ChangeReportDeployment{
+			{Name: "deploy prod", Environment: "PRODUCTION", Result: "SUCCESS", FinishedDate: &finishedDate},
+		},
+	}
+	assert.Equal(t, `# Changes from v1.0.0 to v1.1.0
+
+3 commits by 2 contributors.
+
+## Pull Requests
+
+- [Add \[beta\] API](https://example.com/pr/12) #12 by alice
+- Fix typo #13
+
+## Issues
+
+### BUG
+
+- DL-2 Crash on start (DONE)
+
+### OTHER
+
+- DL-3 Cleanup
+
+### REQUIREMENT
+
+- DL-1 Beta API
+
+## Contributors
+
+- alice (2 commits)
+- bob (1 commits)
+
+## Deployments
+
+- deploy prod (PRODUCTION, SUCCESS, 2024-06-01T08:00:00Z)
+`, renderChangeReportMarkdown(report))
+}
@@ -18,10 +18,12 @@ limitations under the License.
 package impl
 
 import (
+	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/refdiff/api"
 	"github.com/apache/incubator-devlake/plugins/refdiff/models"
 	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
 )
@@ -33,6 +35,7 @@ var _ interface {
 	plugin.PluginApi
 	plugin.PluginModel
 	plugin.PluginMetric
+	plugin.PluginInit
 } = (*RefDiff)(nil)
 
 type RefDiff struct{}
@@ -41,6 +44,11 @@ func (p RefDiff) Description() string {
 	return "Calculate commits diff for specified ref pairs based on `commits` and `commit_parents` tables"
 }
 
+func (p RefDiff) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes)
+	return nil
+}
+
 func (p RefDiff) Name() string {
 	return "refdiff"
 }
@@ -84,15 +92,11 @@ func (p RefDiff) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]
 	}
 
 	db := taskCtx.GetDal()
-	tagsPattern := op.TagsPattern
-	tagsLimit := op.TagsLimit
-	tagsOrder := op.TagsOrder
-
-	rs, err := tasks.CalculateTagPattern(db, tagsPattern, tagsLimit, tagsOrder)
+	tagGroups, err := tasks.CalculateTagPattern(db, &op)
 	if err != nil {
 		return nil, err
 	}
-	op.AllPairs, err = tasks.CalculateCommitPairs(db, op.RepoId, op.Pairs, rs)
+	op.AllPairs, err = tasks.CalculateCommitPairs(db, op.RepoId, op.Pairs, tagGroups)
 	if err != nil {
 		return nil, err
 	}
@@ -108,5 +112,9 @@ func (p RefDiff) RootPkgPath() string {
 }
 
 func (p RefDiff) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
-	return nil
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"repos/:repoId/changes": {
+			"GET": api.GetChanges,
+		},
+	}
 }
@@ -33,6 +33,10 @@ type RefdiffOptions struct {
 	TagsPattern string // The Pattern to match from all tags
 	TagsLimit   int    // How many tags be matched should be used.
 	TagsOrder   string // The Rule to Order the tag list
+	// Pair the tags only within the same prefix, e.g. service-a/v1.2.3 and service-a/v1.2.4, for monorepos
+	TagsPrefixScoped bool
+	// Leave the pre-releases out of the pairs
+	TagsExcludePrerelease bool
 
 	AllPairs    RefCommitPairs // Pairs and TagsPattern Pairs
 	ProjectName string
@@ -35,7 +35,9 @@ func main() {
 
 	tagsPattern := refdiffCmd.Flags().StringP("tags-pattern", "p", "", "tags pattern")
 	tagsLimit := refdiffCmd.Flags().IntP("tags-limit", "l", 2, "tags limit")
-	tagsOrder := refdiffCmd.Flags().StringP("tags-order", "d", "", "tags order")
+	tagsOrder := refdiffCmd.Flags().StringP("tags-order", "d", "", "tags order, one of alphabetically, semver, strict semver, calver and created date, prefixed with 'reverse ' for the descending order")
+	tagsPrefixScoped := refdiffCmd.Flags().BoolP("tags-prefix-scoped", "s", false, "pair tags only within the same prefix, e.g. service-a/v1.2.3")
+	tagsExcludePrerelease := refdiffCmd.Flags().BoolP("tags-exclude-prerelease", "e", false, "exclude pre-release tags")
 
 	projectName := refdiffCmd.Flags().StringP("project-name", "P", "", "project name")
 	timeAfter := refdiffCmd.Flags().StringP("time-after", "a", "", "collect data that are created after specified time, ie 2006-01-02T15:04:05Z")
@@ -54,12 +56,14 @@ func main() {
 		}
 
 		runner.DirectRun(cmd, args, PluginEntry, map[string]interface{}{
-			"repoId":      repoId,
-			"pairs":       pairs,
-			"tagsPattern": *tagsPattern,
-			"tagsLimit":   *tagsLimit,
-			"tagsOrder":   *tagsOrder,
-			"projectName": *projectName,
+			"repoId":                repoId,
+			"pairs":                 pairs,
+			"tagsPattern":           *tagsPattern,
+			"tagsLimit":             *tagsLimit,
+			"tagsOrder":             *tagsOrder,
+			"projectName":           *projectName,
+			"tagsPrefixScoped":      *tagsPrefixScoped,
+			"tagsExcludePrerelease": *tagsExcludePrerelease,
 		}, *timeAfter)
 	}
 	runner.RunCmd(refdiffCmd)
@@ -18,11 +18,13 @@ limitations under the License.
 package tasks
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/plugins/refdiff/models"
@@ -71,11 +73,49 @@ func CalculateCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error {
 		return nil
 	}
 
-	commitNodeGraph := utils.NewCommitNodeGraph()
-	// mysql limit
-	insertCountLimitOfCommitsDiff := int(65535 / reflect.ValueOf(code.CommitsDiff{}).NumField())
+	commitNodeGraph, err := LoadCommitNodeGraph(ctx, db, repoId)
+	if err != nil {
+		return err
+	}
+	logger.Info("Create a commit node graph with node count[%d]", commitNodeGraph.Size())
+
+	// calculate diffs for commits pairs and store them into database
+	lenCommitPairs := len(commitPairs)
+	taskCtx.SetProgress(0, lenCommitPairs)
+
+	for _, pair := range commitPairs {
+		select {
+		case <-ctx.Done():
+			return errors.Convert(ctx.Err())
+		default:
+		}
+		if pair[0] == pair[1] {
+			// different refs might point to a same commit, it is ok
+			logger.Info(
+				"skipping ref pair due to they are the same %s",
+				pair[0],
+			)
+			continue
+		}
+		oldCount, newCount, err := SaveCommitsDiff(db, logger, commitNodeGraph, pair, refCommit)
+		if err != nil {
+			return err
+		}
+		logger.Info(
+			"total %d commits of difference found between [new][%s] and [old][%s(total:%d)]",
+			newCount,
+			pair[0],
+			pair[1],
+			oldCount,
+		)
+		taskCtx.IncProgress(1)
+	}
+	return nil
+}
 
-	// load commits from db
+// LoadCommitNodeGraph loads the commit graph of the repo from commit_parents
+func LoadCommitNodeGraph(ctx context.Context, db dal.Dal, repoId string) (*utils.CommitNodeGraph, errors.Error) {
+	commitNodeGraph := utils.NewCommitNodeGraph()
 	cursor, err := db.Cursor(
 		dal.Select("cp.*"),
 		dal.Join("LEFT JOIN repo_commits rc ON (rc.commit_sha = cp.commit_sha)"),
@@ -83,112 +123,83 @@ func CalculateCommitsDiff(taskCtx plugin.SubTaskContext) errors.Error {
 		dal.Where("rc.repo_id = ?", repoId),
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer cursor.Close()
 
 	for cursor.Next() {
 		select {
 		case <-ctx.Done():
-			return errors.Convert(ctx.Err())
+			return nil, errors.Convert(ctx.Err())
 		default:
 		}
 		commitParent := &code.CommitParent{}
 		err = db.Fetch(cursor, commitParent)
 		if err != nil {
-			return errors.Default.Wrap(err, "failed to read commit from database")
+			return nil, errors.Default.Wrap(err, "failed to read commit from database")
 		}
 		commitNodeGraph.AddParent(commitParent.CommitSha, commitParent.ParentCommitSha)
 	}
+	return commitNodeGraph, nil
+}
 
-	logger.Info("Create a commit node graph with node count[%d]", commitNodeGraph.Size())
-
-	// calculate diffs for commits pairs and store them into database
-	commitsDiff := &code.CommitsDiff{}
-	finishedCommitDiff := &models.FinishedCommitsDiff{}
-	lenCommitPairs := len(commitPairs)
-	taskCtx.SetProgress(0, lenCommitPairs)
-
-	for _, pair := range commitPairs {
-		select {
-		case <-ctx.Done():
-			return errors.Convert(ctx.Err())
-		default:
-		}
-		// ref might advance, keep commit sha for debugging
-		commitsDiff.NewCommitSha = pair[0]
-		commitsDiff.OldCommitSha = pair[1]
+// SaveCommitsDiff stores the commits the new commit of the pair has but the old one does not have into commits_diffs,
+// and marks the pair finished
+func SaveCommitsDiff(db dal.Dal, logger log.Logger, commitNodeGraph *utils.CommitNodeGraph, pair models.RefCommitPair, refCommit *code.RefCommit) (int, int, errors.Error) {
+	// mysql limit
+	insertCountLimitOfCommitsDiff := int(65535 / reflect.ValueOf(code.CommitsDiff{}).NumField())
 
-		finishedCommitDiff.NewCommitSha = pair[0]
-		finishedCommitDiff.OldCommitSha = pair[1]
+	// ref might advance, keep commit sha for debugging
+	commitsDiff := &code.CommitsDiff{
+		NewCommitSha: pair[0],
+		OldCommitSha: pair[1],
+	}
+	finishedCommitDiff := &models.FinishedCommitsDiff{
+		NewCommitSha: pair[0],
+		OldCommitSha: pair[1],
+	}
 
-		if commitsDiff.NewCommitSha == commitsDiff.OldCommitSha {
-			// different refs might point to a same commit, it is ok
-			logger.Info(
-				"skipping ref pair due to they are the same %s",
-				commitsDiff.NewCommitSha,
-			)
-			continue
-		}
+	lostSha, oldCount, newCount := commitNodeGraph.CalculateLostSha(pair[1], pair[0])
 
-		lostSha, oldCount, newCount := commitNodeGraph.CalculateLostSha(pair[1], pair[0])
-
-		commitsDiffs := []code.CommitsDiff{}
-		refCommits := []code.RefCommit{}
-		finishedCommitDiffs := []models.FinishedCommitsDiff{}
-
-		commitsDiff.SortingIndex = 1
-		for _, sha := range lostSha {
-			commitsDiff.CommitSha = sha
-			commitsDiffs = append(commitsDiffs, *commitsDiff)
-
-			// sql limit placeholders count only 65535
-			if commitsDiff.SortingIndex%insertCountLimitOfCommitsDiff == 0 {
-				logger.Info("commitsDiffs count in limited[%d] index[%d]--exec and clean", len(commitsDiffs), commitsDiff.SortingIndex)
-				err = db.CreateIfNotExist(commitsDiffs)
-				if err != nil {
-					return err
-				}
-				commitsDiffs = []code.CommitsDiff{}
-			}
+	commitsDiffs := []code.CommitsDiff{}
+	commitsDiff.SortingIndex = 1
+	for _, sha := range lostSha {
+		commitsDiff.CommitSha = sha
+		commitsDiffs = append(commitsDiffs, *commitsDiff)
 
-			commitsDiff.SortingIndex++
-		}
-
-		if len(commitsDiffs) > 0 {
-			logger.Info("insert data count [%d]", len(commitsDiffs))
-			err = db.CreateIfNotExist(commitsDiffs)
+		// sql limit placeholders count only 65535
+		if commitsDiff.SortingIndex%insertCountLimitOfCommitsDiff == 0 {
+			logger.Info("commitsDiffs count in limited[%d] index[%d]--exec and clean", len(commitsDiffs), commitsDiff.SortingIndex)
+			err := db.CreateIfNotExist(commitsDiffs)
 			if err != nil {
-				return err
+				return 0, 0, err
 			}
+			commitsDiffs = []code.CommitsDiff{}
 		}
 
-		refCommits = append(refCommits, *refCommit)
-		if len(refCommits) > 0 {
-			err = db.CreateIfNotExist(refCommits)
-			if err != nil {
-				return err
-			}
+		commitsDiff.SortingIndex++
+	}
+
+	if len(commitsDiffs) > 0 {
+		logger.Info("insert data count [%d]", len(commitsDiffs))
+		err := db.CreateIfNotExist(commitsDiffs)
+		if err != nil {
+			return 0, 0, err
 		}
+	}
 
-		finishedCommitDiffs = append(finishedCommitDiffs, *finishedCommitDiff)
-		if len(finishedCommitDiffs) > 0 {
-			err = db.CreateIfNotExist(finishedCommitDiffs)
-			if err != nil {
-				return err
-			}
+	if refCommit != nil {
+		err := db.CreateIfNotExist([]code.RefCommit{*refCommit})
+		if err != nil {
+			return 0, 0, err
 		}
+	}
 
-		logger.Info(
-			"total %d commits of difference found between [new][%s] and [old][%s(total:%d)]",
-			newCount,
-			commitsDiff.NewCommitSha,
-			commitsDiff.OldCommitSha,
-			oldCount,
-		)
-		taskCtx.IncProgress(1)
+	err := db.CreateIfNotExist([]models.FinishedCommitsDiff{*finishedCommitDiff})
+	if err != nil {
+		return 0, 0, err
 	}
-	return nil
+	return oldCount, newCount, nil
 }
 
 var CalculateCommitsDiffMeta = plugin.SubTaskMeta{
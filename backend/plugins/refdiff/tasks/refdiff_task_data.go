@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
@@ -38,142 +37,87 @@ type RefdiffTaskData struct {
 type RefPairLists []models.RefPairList
 
 type Refs []code.Ref
-type RefsAlphabetically Refs
-type RefsReverseAlphabetically Refs
-type RefsSemver Refs
-type RefsReverseSemver Refs
-
-func (rs Refs) Len() int {
-	return len(rs)
-}
-
-func (rs RefsAlphabetically) Len() int {
-	return len(rs)
-}
-
-func (rs RefsAlphabetically) Less(i, j int) bool {
-	return rs[i].Id < rs[j].Id
-}
-
-func (rs RefsAlphabetically) Swap(i, j int) {
-	rs[i], rs[j] = rs[j], rs[i]
-}
-
-func (rs RefsReverseAlphabetically) Len() int {
-	return len(rs)
-}
-
-func (rs RefsReverseAlphabetically) Less(i, j int) bool {
-	return rs[i].Id > rs[j].Id
-}
-
-func (rs RefsReverseAlphabetically) Swap(i, j int) {
-	rs[i], rs[j] = rs[j], rs[i]
-}
-
-func (rs RefsSemver) Len() int {
-	return len(rs)
-}
-
-func (rs RefsSemver) Less(i, j int) bool {
-	parti := strings.Split(rs[i].Name, ".")
-	partj := strings.Split(rs[j].Name, ".")
-
-	for k := 0; k < len(partj); k++ {
-		if k >= len(parti) {
-			return true
-		}
-
-		if len(parti[k]) < len(partj[k]) {
-			return true
-		}
-		if len(parti[k]) > len(partj[k]) {
-			return false
-		}
-
-		if parti[k] < partj[k] {
-			return true
-		}
-		if parti[k] > partj[k] {
-			return false
-		}
-	}
-	return false
-}
-
-func (rs RefsSemver) Swap(i, j int) {
-	rs[i], rs[j] = rs[j], rs[i]
-}
-
-func (rs RefsReverseSemver) Len() int {
-	return len(rs)
-}
-
-func (rs RefsReverseSemver) Less(i, j int) bool {
-	return RefsSemver(rs).Less(j, i)
-}
-
-func (rs RefsReverseSemver) Swap(i, j int) {
-	rs[i], rs[j] = rs[j], rs[i]
-}
-
-// CalculateTagPattern Calculate the TagPattern order by tagsOrder and return the Refs
-func CalculateTagPattern(db dal.Dal, tagsPattern string, tagsLimit int, tagsOrder string) (Refs, errors.Error) {
-	rs := Refs{}
 
+// CalculateTagPattern returns the tags matching the TagsPattern ordered by the TagsOrder, the tags are grouped by the
+// scopes if TagsPrefixScoped, otherwise all of them are in a single group. Each group is limited to TagsLimit tags.
+func CalculateTagPattern(db dal.Dal, op *models.RefdiffOptions) ([]Refs, errors.Error) {
 	// caculate Pattern part
-	if tagsPattern == "" || tagsLimit <= 1 {
-		return rs, nil
+	if op.TagsPattern == "" || op.TagsLimit <= 1 {
+		return nil, nil
 	}
-	rows, err := db.Cursor(
+	clauses := []dal.Clause{
 		dal.From("refs"),
-		dal.Where(""),
 		dal.Orderby("created_date desc"),
-	)
-
+	}
+	if op.RepoId != "" {
+		clauses = append(clauses, dal.Where("repo_id = ?", op.RepoId))
+	}
+	rows, err := db.Cursor(clauses...)
 	if err != nil {
-		return rs, err
+		return nil, err
 	}
-	defer rows.Next()
-	r, err := errors.Convert01(regexp.Compile(tagsPattern))
+	defer rows.Close()
+	r, err := errors.Convert01(regexp.Compile(op.TagsPattern))
 	if err != nil {
-		return rs, errors.Default.Wrap(err, fmt.Sprintf("unable to parse: %s", tagsPattern))
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("unable to parse: %s", op.TagsPattern))
 	}
+	var tags []*TagVersion
 	for rows.Next() {
-		var ref code.Ref
-		err = db.Fetch(rows, &ref)
+		ref := &code.Ref{}
+		err = db.Fetch(rows, ref)
 		if err != nil {
-			return rs, err
+			return nil, err
 		}
 
 		if ok := r.Match([]byte(ref.Name)); ok {
-			rs = append(rs, ref)
+			tags = append(tags, NewTagVersion(ref))
 		}
 	}
-	switch tagsOrder {
-	case "alphabetically":
-		sort.Sort(RefsAlphabetically(rs))
-	case "reverse alphabetically":
-		sort.Sort(RefsReverseAlphabetically(rs))
-	case "semver":
-		sort.Sort(RefsSemver(rs))
-	case "reverse semver":
-		sort.Sort(RefsReverseSemver(rs))
-	default:
-	}
 
-	if tagsLimit < rs.Len() {
-		rs = rs[:tagsLimit]
+	// the tags are kept in the order of the created dates, newest first, for an empty or unknown TagsOrder
+	strategy, descending, ok := GetTagOrderStrategy(op.TagsOrder)
+	if !ok {
+		strategy = nil
 	}
 
-	return rs, nil
+	var scopes []string
+	scopedTags := make(map[string][]*TagVersion)
+	for _, tag := range tags {
+		scope := ""
+		if op.TagsPrefixScoped {
+			scope = tag.Scope
+		}
+		if _, ok := scopedTags[scope]; !ok {
+			scopes = append(scopes, scope)
+		}
+		scopedTags[scope] = append(scopedTags[scope], tag)
+	}
+	sort.Strings(scopes)
+
+	groups := make([]Refs, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeTags := scopedTags[scope]
+		scopeTags = SortTagVersions(scopeTags, strategy, descending, op.TagsExcludePrerelease)
+		if op.TagsLimit < len(scopeTags) {
+			scopeTags = scopeTags[:op.TagsLimit]
+		}
+		rs := make(Refs, 0, len(scopeTags))
+		for _, tag := range scopeTags {
+			rs = append(rs, *tag.Ref)
+		}
+		groups = append(groups, rs)
+	}
+	return groups, nil
 }
 
 // CalculateCommitPairs Calculate the commits pairs both from Options.Pairs and TagPattern
-func CalculateCommitPairs(db dal.Dal, repoId string, pairs []models.RefPair, rs Refs) (models.RefCommitPairs, errors.Error) {
-	commitPairs := make(models.RefCommitPairs, 0, len(rs)+len(pairs))
-	for i := 1; i < len(rs); i++ {
-		commitPairs = append(commitPairs, [4]string{rs[i-1].CommitSha, rs[i].CommitSha, rs[i-1].Name, rs[i].Name})
+func CalculateCommitPairs(db dal.Dal, repoId string, pairs []models.RefPair, tagGroups []Refs) (models.RefCommitPairs, errors.Error) {
+	commitPairs := make(models.RefCommitPairs, 0, len(pairs))
+	// tags are paired within the groups
+	for _, rs := range tagGroups {
+		for i := 1; i < len(rs); i++ {
+			commitPairs = append(commitPairs, [4]string{rs[i-1].CommitSha, rs[i].CommitSha, rs[i-1].Name, rs[i].Name})
+		}
 	}
 
 	// caculate pairs part
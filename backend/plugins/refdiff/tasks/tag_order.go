@@ -0,0 +1,297 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"golang.org/x/mod/semver"
+)
+
+// TagOrderStrategy orders the tags by the versions they stand for
+type TagOrderStrategy interface {
+	// Accept reports whether the tag follows the versioning scheme, tags not accepted are left out of the pairs
+	Accept(version string) bool
+	// Compare returns a negative number if tag a is older than tag b, a positive number if a is newer than b, 0 if
+	// they can't be told apart
+	Compare(a, b *TagVersion) int
+	// IsPrerelease reports whether the tag is a pre-release
+	IsPrerelease(version string) bool
+}
+
+// TagVersion is a tag split into the scope and the version, e.g. refs/tags/service-a/v1.2.3 is split into
+// service-a and v1.2.3. The scope is empty for the tags without a prefix
+type TagVersion struct {
+	Ref     *code.Ref
+	Scope   string
+	Version string
+}
+
+var tagOrderStrategies = map[string]TagOrderStrategy{
+	"alphabetically": alphabeticalTagOrder{},
+	"semver":         legacySemverTagOrder{},
+	"strict semver":  semverTagOrder{},
+	"calver":         calverTagOrder{},
+	"created date":   createdDateTagOrder{},
+}
+var tagOrderStrategiesLock sync.RWMutex
+
+// RegisterTagOrderStrategy makes the strategy available as TagsOrder, and "reverse <name>" for the descending order
+func RegisterTagOrderStrategy(name string, strategy TagOrderStrategy) {
+	tagOrderStrategiesLock.Lock()
+	defer tagOrderStrategiesLock.Unlock()
+	tagOrderStrategies[name] = strategy
+}
+
+// GetTagOrderStrategy returns the strategy of the TagsOrder and whether the order is descending
+func GetTagOrderStrategy(tagsOrder string) (strategy TagOrderStrategy, descending bool, ok bool) {
+	name := strings.TrimSpace(tagsOrder)
+	if strings.HasPrefix(name, "reverse ") {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "reverse "))
+		descending = true
+	}
+	tagOrderStrategiesLock.RLock()
+	defer tagOrderStrategiesLock.RUnlock()
+	strategy, ok = tagOrderStrategies[name]
+	return strategy, descending, ok
+}
+
+// NewTagVersion splits the tag name into the scope and the version, the scope is everything before the last / or @
+// of the name without the refs/tags/ prefix
+func NewTagVersion(ref *code.Ref) *TagVersion {
+	name := strings.TrimPrefix(ref.Name, "refs/tags/")
+	tag := &TagVersion{Ref: ref, Version: name}
+	if i := strings.LastIndexAny(name, "/@"); i >= 0 {
+		tag.Scope = name[:i]
+		tag.Version = name[i+1:]
+	}
+	return tag
+}
+
+// SortTagVersions sorts the tags accepted by the strategy from the oldest to the newest, or the other way around if
+// descending, pre-releases are removed if excludePrerelease. The tags are kept in order if the strategy is nil
+func SortTagVersions(tags []*TagVersion, strategy TagOrderStrategy, descending bool, excludePrerelease bool) []*TagVersion {
+	accept, prerelease := func(string) bool { return true }, isPrerelease
+	if strategy != nil {
+		accept, prerelease = strategy.Accept, strategy.IsPrerelease
+	}
+	accepted := make([]*TagVersion, 0, len(tags))
+	for _, tag := range tags {
+		if !accept(tag.Version) || excludePrerelease && prerelease(tag.Version) {
+			continue
+		}
+		accepted = append(accepted, tag)
+	}
+	if strategy == nil {
+		return accepted
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		c := strategy.Compare(accepted[i], accepted[j])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+	return accepted
+}
+
+type alphabeticalTagOrder struct{}
+
+func (alphabeticalTagOrder) Accept(string) bool {
+	return true
+}
+
+func (alphabeticalTagOrder) Compare(a, b *TagVersion) int {
+	return strings.Compare(a.Ref.Name, b.Ref.Name)
+}
+
+func (alphabeticalTagOrder) IsPrerelease(version string) bool {
+	return isPrerelease(version)
+}
+
+// legacySemverTagOrder compares the dot separated parts of the tag names one by one, a longer part is a greater
+// number, e.g. v1.10 is newer than v1.9. It accepts any tag, like v1.2 or release-1.2.3
+type legacySemverTagOrder struct{}
+
+func (legacySemverTagOrder) Accept(string) bool {
+	return true
+}
+
+func (legacySemverTagOrder) Compare(a, b *TagVersion) int {
+	switch {
+	case legacySemverLess(a.Ref.Name, b.Ref.Name):
+		return -1
+	case legacySemverLess(b.Ref.Name, a.Ref.Name):
+		return 1
+	}
+	return 0
+}
+
+func (legacySemverTagOrder) IsPrerelease(version string) bool {
+	return isPrerelease(version)
+}
+
+func legacySemverLess(a, b string) bool {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for k := 0; k < len(partsB); k++ {
+		if k >= len(partsA) {
+			return true
+		}
+		if len(partsA[k]) != len(partsB[k]) {
+			return len(partsA[k]) < len(partsB[k])
+		}
+		if partsA[k] != partsB[k] {
+			return partsA[k] < partsB[k]
+		}
+	}
+	return false
+}
+
+// semverRegex is the strict SemVer 2.0 with an optional v prefix
+var semverRegex = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// semverTagOrder follows the precedence of SemVer 2.0, the build metadata is ignored
+type semverTagOrder struct{}
+
+func (semverTagOrder) Accept(version string) bool {
+	return semverRegex.MatchString(version)
+}
+
+func (semverTagOrder) Compare(a, b *TagVersion) int {
+	return semver.Compare(canonicalSemver(a.Version), canonicalSemver(b.Version))
+}
+
+func (semverTagOrder) IsPrerelease(version string) bool {
+	return semver.Prerelease(canonicalSemver(version)) != ""
+}
+
+func canonicalSemver(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return version
+}
+
+// calverRegex matches the calendar versions led by a 4-digit or 2-digit year, e.g. 2024.03.1, 24.3 or
+// 2024.03.1-rc.1
+var calverRegex = regexp.MustCompile(`^v?(\d{4}|\d{2})((?:\.\d+)*)(?:-([0-9A-Za-z.-]+))?$`)
+
+// calverTagOrder compares the numeric parts one by one, a pre-release comes before the release of the same numbers
+type calverTagOrder struct{}
+
+func (calverTagOrder) Accept(version string) bool {
+	return calverRegex.MatchString(version)
+}
+
+func (calverTagOrder) Compare(a, b *TagVersion) int {
+	numbersA, preA := parseCalver(a.Version)
+	numbersB, preB := parseCalver(b.Version)
+	for i := 0; i < len(numbersA) || i < len(numbersB); i++ {
+		var x, y int
+		if i < len(numbersA) {
+			x = numbersA[i]
+		}
+		if i < len(numbersB) {
+			y = numbersB[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	versionA, versionB := "v0.0.0-"+preA, "v0.0.0-"+preB
+	if semver.IsValid(versionA) && semver.IsValid(versionB) {
+		return semver.Compare(versionA, versionB)
+	}
+	return strings.Compare(preA, preB)
+}
+
+func (calverTagOrder) IsPrerelease(version string) bool {
+	_, pre := parseCalver(version)
+	return pre != ""
+}
+
+func parseCalver(version string) ([]int, string) {
+	m := calverRegex.FindStringSubmatch(version)
+	if m == nil {
+		return nil, ""
+	}
+	var numbers []int
+	for _, part := range strings.Split(m[1]+m[2], ".") {
+		n, _ := strconv.Atoi(part)
+		numbers = append(numbers, n)
+	}
+	return numbers, m[3]
+}
+
+// createdDateTagOrder orders the tags by code.Ref.CreatedDate, the tags without the date are the oldest
+type createdDateTagOrder struct{}
+
+func (createdDateTagOrder) Accept(string) bool {
+	return true
+}
+
+func (createdDateTagOrder) Compare(a, b *TagVersion) int {
+	dateA, dateB := a.Ref.CreatedDate, b.Ref.CreatedDate
+	switch {
+	case dateA == nil && dateB == nil:
+		return strings.Compare(a.Ref.Name, b.Ref.Name)
+	case dateA == nil:
+		return -1
+	case dateB == nil:
+		return 1
+	case dateA.Before(*dateB):
+		return -1
+	case dateA.After(*dateB):
+		return 1
+	}
+	return strings.Compare(a.Ref.Name, b.Ref.Name)
+}
+
+func (createdDateTagOrder) IsPrerelease(version string) bool {
+	return isPrerelease(version)
+}
+
+// isPrerelease tells the pre-releases apart for the strategies without a versioning scheme
+func isPrerelease(version string) bool {
+	if semverRegex.MatchString(version) {
+		return semverTagOrder{}.IsPrerelease(version)
+	}
+	if calverRegex.MatchString(version) {
+		return calverTagOrder{}.IsPrerelease(version)
+	}
+	return false
+}
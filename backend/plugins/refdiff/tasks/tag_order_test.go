@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTagVersions(names ...string) []*TagVersion {
+	tags := make([]*TagVersion, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, NewTagVersion(&code.Ref{Name: "refs/tags/" + name}))
+	}
+	return tags
+}
+
+func tagNames(tags []*TagVersion) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Ref.Name[len("refs/tags/"):])
+	}
+	return names
+}
+
+func TestNewTagVersion(t *testing.T) {
+	tag := NewTagVersion(&code.Ref{Name: "refs/tags/services/api/v1.2.3"})
+	assert.Equal(t, "services/api", tag.Scope)
+	assert.Equal(t, "v1.2.3", tag.Version)
+	tag = NewTagVersion(&code.Ref{Name: "refs/tags/@scope/pkg@2.0.0"})
+	assert.Equal(t, "@scope/pkg", tag.Scope)
+	assert.Equal(t, "2.0.0", tag.Version)
+	tag = NewTagVersion(&code.Ref{Name: "v1.0.0"})
+	assert.Equal(t, "", tag.Scope)
+	assert.Equal(t, "v1.0.0", tag.Version)
+}
+
+func TestLegacySemverTagOrder(t *testing.T) {
+	strategy, descending, ok := GetTagOrderStrategy("semver")
+	assert.True(t, ok)
+	assert.False(t, descending)
+	tags := newTagVersions("v1.10", "v1.2", "release-1.2.3", "v1.9.1")
+	assert.Equal(t,
+		[]string{"v1.2", "v1.9.1", "v1.10", "release-1.2.3"},
+		tagNames(SortTagVersions(tags, strategy, descending, false)),
+	)
+}
+
+func TestStrictSemverTagOrder(t *testing.T) {
+	strategy, descending, ok := GetTagOrderStrategy("reverse strict semver")
+	assert.True(t, ok)
+	assert.True(t, descending)
+	tags := newTagVersions("v1.10.0", "1.2.0", "v1.2.0-rc.1", "v1.2.0-rc.10", "v1.2.0-beta", "v2.0.0+build.5", "latest", "v1.2", "v01.2.3")
+	assert.Equal(t,
+		[]string{"v2.0.0+build.5", "v1.10.0", "1.2.0", "v1.2.0-rc.10", "v1.2.0-rc.1", "v1.2.0-beta"},
+		tagNames(SortTagVersions(tags, strategy, descending, false)),
+	)
+	assert.Equal(t,
+		[]string{"1.2.0", "v1.10.0", "v2.0.0+build.5"},
+		tagNames(SortTagVersions(tags, strategy, false, true)),
+	)
+}
+
+func TestCalverTagOrder(t *testing.T) {
+	strategy, descending, ok := GetTagOrderStrategy("calver")
+	assert.True(t, ok)
+	assert.False(t, descending)
+	tags := newTagVersions("2024.03.1", "2024.10", "2023.12.25", "2024.03.1-rc.1", "v2024.3", "24.1", "release")
+	assert.Equal(t,
+		[]string{"24.1", "2023.12.25", "v2024.3", "2024.03.1-rc.1", "2024.03.1", "2024.10"},
+		tagNames(SortTagVersions(tags, strategy, descending, false)),
+	)
+	assert.Equal(t,
+		[]string{"2024.10", "2024.03.1", "v2024.3", "2023.12.25", "24.1"},
+		tagNames(SortTagVersions(tags, strategy, true, true)),
+	)
+}
+
+func TestCreatedDateTagOrder(t *testing.T) {
+	strategy, descending, ok := GetTagOrderStrategy("reverse created date")
+	assert.True(t, ok)
+	day1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	tags := []*TagVersion{
+		NewTagVersion(&code.Ref{Name: "refs/tags/b", CreatedDate: &day1}),
+		NewTagVersion(&code.Ref{Name: "refs/tags/a"}),
+		NewTagVersion(&code.Ref{Name: "refs/tags/c-rc1", CreatedDate: &day2}),
+		NewTagVersion(&code.Ref{Name: "refs/tags/c", CreatedDate: &day2}),
+	}
+	assert.Equal(t, []string{"c-rc1", "c", "b", "a"}, tagNames(SortTagVersions(tags, strategy, descending, false)))
+}
+
+func TestUnknownTagOrder(t *testing.T) {
+	_, _, ok := GetTagOrderStrategy("reverse lunar")
+	assert.False(t, ok)
+	tags := newTagVersions("v1.1.0", "v1.0.0-rc.1", "v1.0.0")
+	assert.Equal(t, []string{"v1.1.0", "v1.0.0"}, tagNames(SortTagVersions(tags, nil, false, true)))
+}
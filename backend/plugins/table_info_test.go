@@ -27,16 +27,20 @@ import (
 	bitbucket "github.com/apache/incubator-devlake/plugins/bitbucket/impl"
 	bitbucket_server "github.com/apache/incubator-devlake/plugins/bitbucket_server/impl"
 	circleci "github.com/apache/incubator-devlake/plugins/circleci/impl"
+	codechurn "github.com/apache/incubator-devlake/plugins/codechurn/impl"
 	customize "github.com/apache/incubator-devlake/plugins/customize/impl"
 	dbt "github.com/apache/incubator-devlake/plugins/dbt/impl"
 	dora "github.com/apache/incubator-devlake/plugins/dora/impl"
 	feishu "github.com/apache/incubator-devlake/plugins/feishu/impl"
+	forecast "github.com/apache/incubator-devlake/plugins/forecast/impl"
 	gitee "github.com/apache/incubator-devlake/plugins/gitee/impl"
 	gitextractor "github.com/apache/incubator-devlake/plugins/gitextractor/impl"
 	github "github.com/apache/incubator-devlake/plugins/github/impl"
 	githubGraphql "github.com/apache/incubator-devlake/plugins/github_graphql/impl"
 	gitlab "github.com/apache/incubator-devlake/plugins/gitlab/impl"
 	icla "github.com/apache/incubator-devlake/plugins/icla/impl"
+	issueRollup "github.com/apache/incubator-devlake/plugins/issue_rollup/impl"
+	issueTrace "github.com/apache/incubator-devlake/plugins/issue_trace/impl"
 	jenkins "github.com/apache/incubator-devlake/plugins/jenkins/impl"
 	jira "github.com/apache/incubator-devlake/plugins/jira/impl"
 	linker "github.com/apache/incubator-devlake/plugins/linker/impl"
@@ -90,6 +94,10 @@ func Test_GetPluginTablesInfo(t *testing.T) {
 	checker.FeedIn("circleci/models", circleci.Circleci{}.GetTablesInfo)
 	checker.FeedIn("opsgenie/models", opsgenie.Opsgenie{}.GetTablesInfo)
 	checker.FeedIn("linker/models", linker.Linker{}.GetTablesInfo)
+	checker.FeedIn("codechurn/models", codechurn.CodeChurn{}.GetTablesInfo)
+	checker.FeedIn("issue_trace/models", issueTrace.IssueTrace{}.GetTablesInfo)
+	checker.FeedIn("forecast/models", forecast.Forecast{}.GetTablesInfo)
+	checker.FeedIn("issue_rollup/models", issueRollup.IssueRollup{}.GetTablesInfo)
 	err := checker.Verify()
 	if err != nil {
 		t.Error(err)
@@ -172,6 +172,7 @@ func (p Tapd) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertBugChangelogMeta,
 		tasks.ConvertStoryChangelogMeta,
 		tasks.ConvertTaskChangelogMeta,
+		tasks.TrackIterationScopeMeta,
 		tasks.ConvertBugCommitMeta,
 		tasks.ConvertStoryCommitMeta,
 		tasks.ConvertTaskCommitMeta,
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func TrackIterationScope(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*TapdTaskData)
+	workspaceId := getWorkspaceIdGen().Generate(data.Options.ConnectionId, data.Options.WorkspaceId)
+	return helper.TrackSprintScope(taskCtx, workspaceId)
+}
+
+var TrackIterationScopeMeta = plugin.SubTaskMeta{
+	Name:             "trackIterationScope",
+	EntryPoint:       TrackIterationScope,
+	EnabledByDefault: true,
+	Description:      "derive sprint_issue_events and sprint_scope_metrics from the iteration changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
@@ -34,6 +34,13 @@ type PaginatedBlueprint struct {
 	Count      int64               `json:"count"`
 }
 
+// TriggerRequest is the optional body of the trigger api
+type TriggerRequest struct {
+	models.SyncPolicy
+	// JumpQueue runs the pipeline ahead of the scheduled ones waiting in the queue
+	JumpQueue bool `json:"jumpQueue"`
+}
+
 // @Summary post blueprints
 // @Description post blueprints
 // @Tags framework/blueprints
@@ -165,7 +172,7 @@ func Patch(c *gin.Context) {
 // @Tags framework/blueprints
 // @Accept application/json
 // @Param blueprintId path string true "blueprintId"
-// @Param skipCollectors body models.SyncPolicy false "json"
+// @Param skipCollectors body TriggerRequest false "json"
 // @Success 200 {object} models.Pipeline
 // @Failure 400 {object} shared.ApiBody "Bad Request"
 // @Failure 500 {object} shared.ApiBody "Internal Error"
@@ -178,18 +185,22 @@ func Trigger(c *gin.Context) {
 		return
 	}
 
-	syncPolicy := &models.SyncPolicy{}
+	request := &TriggerRequest{}
 	if c.Request.Body == nil || c.Request.ContentLength == 0 {
-		syncPolicy.SkipCollectors = false
-		syncPolicy.FullSync = false
+		request.SkipCollectors = false
+		request.FullSync = false
 	} else {
-		err = c.ShouldBindJSON(syncPolicy)
+		err = c.ShouldBindJSON(request)
 		if err != nil {
 			shared.ApiOutputError(c, errors.BadInput.Wrap(err, "error binding request body"))
 			return
 		}
 	}
-	pipeline, err := services.TriggerBlueprint(id, syncPolicy, true)
+	priority := 0
+	if request.JumpQueue {
+		priority = services.PipelinePriorityJumpQueue
+	}
+	pipeline, err := services.TriggerBlueprint(id, &request.SyncPolicy, priority, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error triggering blueprint"))
 		return
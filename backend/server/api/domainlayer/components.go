@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainlayer
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get the components of a repo
+// @Description Components are ordered as they are matched against the file paths, the first matched one wins
+// @Tags framework/domainlayer
+// @Param repoId path string true "repo id"
+// @Success 200  {object} []code.Component
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/repos/{repoId}/components [get]
+func ComponentsIndex(c *gin.Context) {
+	components, err := services.GetComponents(c.Param("repoId"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting components"))
+		return
+	}
+	shared.ApiOutputSuccess(c, components, http.StatusOK)
+}
+
+// @Summary Create a component for a repo
+// @Tags framework/domainlayer
+// @Accept application/json
+// @Param repoId path string true "repo id"
+// @Param component body services.ComponentInput true "json"
+// @Success 201  {object} code.Component
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 409  {string} errcode.Error "Conflict"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/repos/{repoId}/components [post]
+func ComponentsPost(c *gin.Context) {
+	input := &services.ComponentInput{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	component, err := services.CreateComponent(c.Param("repoId"), input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating component"))
+		return
+	}
+	shared.ApiOutputSuccess(c, component, http.StatusCreated)
+}
+
+// @Summary Update a component of a repo
+// @Description The component would no longer be overwritten by CODEOWNERS once updated
+// @Tags framework/domainlayer
+// @Accept application/json
+// @Param repoId path string true "repo id"
+// @Param componentName path string true "component name"
+// @Param component body services.ComponentInput true "json, name is ignored"
+// @Success 200  {object} code.Component
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/repos/{repoId}/components/{componentName} [patch]
+func ComponentsPatch(c *gin.Context) {
+	input := &services.ComponentInput{}
+	if err := c.ShouldBindJSON(input); err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	component, err := services.UpdateComponent(c.Param("repoId"), c.Param("componentName"), input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error updating component"))
+		return
+	}
+	shared.ApiOutputSuccess(c, component, http.StatusOK)
+}
+
+// @Summary Delete a component of a repo
+// @Tags framework/domainlayer
+// @Param repoId path string true "repo id"
+// @Param componentName path string true "component name"
+// @Success 200
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/repos/{repoId}/components/{componentName} [delete]
+func ComponentsDelete(c *gin.Context) {
+	err := services.DeleteComponent(c.Param("repoId"), c.Param("componentName"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting component"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
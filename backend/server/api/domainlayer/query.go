@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainlayer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Query a domain layer table
+// @Description GET /domainlayer/:tableName?project=foo&from=2024-01-01T00:00:00Z&fields=id,title&limit=100&format=csv
+// @Description Supported tables: issues, incidents, pull_requests, commits, cicd_deployments, cicd_pipelines, accounts and sprints.
+// @Description Rows are ordered by the primary key, pass the returned nextCursor to get the next page.
+// @Description When the format is csv, the next cursor is returned in the X-Next-Cursor header.
+// @Tags framework/domainlayer
+// @Param tableName path string true "table name"
+// @Param project query string false "only the rows belong to the project"
+// @Param from query string false "only the rows dated on or after the time, RFC3339"
+// @Param to query string false "only the rows dated before the time, RFC3339"
+// @Param fields query string false "comma separated columns to be returned"
+// @Param cursor query string false "cursor of the page"
+// @Param limit query int false "page size, 100 by default"
+// @Param format query string false "json or csv, json by default"
+// @Success 200  {object} services.DomainQueryResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Table Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /domainlayer/{tableName} [get]
+func Query(c *gin.Context) {
+	var query services.DomainQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	tableName := c.Param("tableName")
+	result, err := services.QueryDomainTable(tableName, &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error querying %s", tableName)))
+		return
+	}
+	switch c.Query("format") {
+	case "", "json":
+		shared.ApiOutputSuccess(c, result, http.StatusOK)
+	case "csv":
+		outputCsv(c, tableName, result)
+	default:
+		shared.ApiOutputError(c, errors.BadInput.New("format must be either json or csv"))
+	}
+}
+
+func outputCsv(c *gin.Context, tableName string, result *services.DomainQueryResult) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", tableName))
+	c.Header("X-Next-Cursor", result.NextCursor)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(c.Writer)
+	_ = writer.Write(result.Columns)
+	values := make([]string, len(result.Columns))
+	for _, row := range result.Rows {
+		for i, column := range result.Columns {
+			values[i] = formatCsvValue(row[column])
+		}
+		_ = writer.Write(values)
+	}
+	writer.Flush()
+}
+
+func formatCsvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -18,17 +18,26 @@ limitations under the License.
 package push
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
+// maxNdjsonLineSize is the maximum size of a single NDJSON row
+const maxNdjsonLineSize = 16 * 1024 * 1024
+
 /*
-	POST /push/:tableName
+	POST /push/:tableName?upsert=true
 	[
 		{
 			"id": 1,
@@ -37,28 +46,108 @@ import (
 	]
 */
 // @Summary POST /push/:tableName
-// @Description POST /push/:tableName
+// @Description Push rows into a domain layer table. The body is either a JSON array of rows,
+// @Description or one JSON row per line when the Content-Type is application/x-ndjson.
+// @Description A JSON array is parsed completely before any row is written, so a malformed array writes nothing.
+// @Description NDJSON is streamed: the lines are written in batches as they are read, a malformed line is rejected
+// @Description alone, and the rows before a line that can't be read at all are still written.
+// @Description Rows are validated against the table schema, rejected rows are reported by their 0-based index
+// @Description (line number for NDJSON) while the others are still written.
 // @Tags framework/push
 // @Accept application/json
+// @Accept application/x-ndjson
 // @Param tableName path string true "table name"
+// @Param upsert query bool false "update existing rows by primary key"
 // @Param data body string true "data"
-// @Success 200  {object} gin.H "{"rowsAffected": rowsAffected}"
+// @Success 200  {object} services.PushResult
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /push/{tableName} [post]
 func Post(c *gin.Context) {
-	var err error
 	tableName := c.Param("tableName")
-	var rowsToInsert []map[string]interface{}
-	err = c.ShouldBindJSON(&rowsToInsert)
+	writer, err := services.NewPushWriter(tableName, c.Query("upsert") == "true")
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	if strings.HasPrefix(c.ContentType(), "application/x-ndjson") {
+		err = readNdjson(c.Request.Body, writer)
+	} else {
+		var rows []*pushedRow
+		rows, err = readJsonArray(c.Request.Body)
+		if err != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+			return
+		}
+		for _, row := range rows {
+			if err = writer.Write(row.index, row.values); err != nil {
+				break
+			}
+		}
+	}
 	if err != nil {
-		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error inserting request body into table %s", tableName)))
 		return
 	}
-	rowsAffected, err := services.InsertRow(tableName, rowsToInsert)
+	result, err := writer.Close()
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("error inserting request body into table %s", tableName)))
 		return
 	}
-	shared.ApiOutputSuccess(c, gin.H{"rowsAffected": rowsAffected}, http.StatusOK)
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
+
+// pushedRow is a row of the body with its index, which is used to report errors
+type pushedRow struct {
+	index  int
+	values map[string]interface{}
+}
+
+func readJsonArray(body io.Reader) ([]*pushedRow, errors.Error) {
+	decoder := json.NewDecoder(body)
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.BadInput.New("the body must be an array of rows")
+	}
+	var rows []*pushedRow
+	for index := 0; decoder.More(); index++ {
+		var row map[string]interface{}
+		if err := decoder.Decode(&row); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("row %d is not a JSON object", index))
+		}
+		rows = append(rows, &pushedRow{index: index, values: row})
+	}
+	return rows, nil
+}
+
+// readNdjson streams the rows to the writer line by line, which writes them in batches, the malformed lines are
+// rejected by the writer. Reading stops at the line that can't be read, e.g. a line longer than maxNdjsonLineSize.
+func readNdjson(body io.Reader, writer *services.PushWriter) errors.Error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLineSize)
+	index := 0
+	for ; scanner.Scan(); index++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var row map[string]interface{}
+		if err := decoder.Decode(&row); err != nil {
+			writer.Reject(index, err)
+			continue
+		}
+		if err := writer.Write(index, row); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		writer.Reject(index, err)
+	}
+	return nil
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
 	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/api/sourcewebhooks"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/services"
 
@@ -64,6 +65,16 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 
 	r.POST("/push/:tableName", push.Post)
 	r.GET("/domainlayer/repos", domainlayer.ReposIndex)
+	r.GET("/domainlayer/:tableName", domainlayer.Query)
+	r.GET("/domainlayer/repos/:repoId/components", domainlayer.ComponentsIndex)
+	r.POST("/domainlayer/repos/:repoId/components", domainlayer.ComponentsPost)
+	r.PATCH("/domainlayer/repos/:repoId/components/:componentName", domainlayer.ComponentsPatch)
+	r.DELETE("/domainlayer/repos/:repoId/components/:componentName", domainlayer.ComponentsDelete)
+
+	// webhooks sent by the data sources to trigger scoped pipelines
+	r.POST("/source-webhooks/:plugin/:connectionId", sourcewebhooks.Post)
+	r.PUT("/source-webhooks/:plugin/:connectionId/secret", sourcewebhooks.PutSecret)
+	r.DELETE("/source-webhooks/:plugin/:connectionId/secret", sourcewebhooks.DeleteSecret)
 
 	// plugin api
 	r.GET("/plugininfo", plugininfo.Get)
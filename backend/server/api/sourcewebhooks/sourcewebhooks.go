@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sourcewebhooks
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// SecretInput is the body to set the webhook secret, leave it empty to generate a random one
+type SecretInput struct {
+	Secret string `json:"secret"`
+}
+
+func getConnectionId(c *gin.Context) (uint64, errors.Error) {
+	connectionId, err := strconv.ParseUint(c.Param("connectionId"), 10, 64)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, "bad connectionId format supplied")
+	}
+	return connectionId, nil
+}
+
+// @Summary Receive a webhook from the data source
+// @Description Verify the webhook with the secret of the connection, then trigger incremental pipelines of the
+// @Description blueprints for the affected scopes, webhooks received within SOURCE_WEBHOOK_DEBOUNCE_WINDOW are coalesced
+// @Tags framework/source-webhooks
+// @Accept application/json
+// @Param plugin path string true "plugin name, github/gitlab/bitbucket/jira"
+// @Param connectionId path int true "connection id"
+// @Success 200  {object} services.SourceWebhookResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 401  {string} errcode.Error "Unauthorized"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /source-webhooks/{plugin}/{connectionId} [post]
+func Post(c *gin.Context) {
+	connectionId, err := getConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	body, e := io.ReadAll(c.Request.Body)
+	if e != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+		return
+	}
+	result, err := services.HandleSourceWebhook(c.Param("plugin"), connectionId, c.Request.Header, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error handling the source webhook"))
+		return
+	}
+	shared.ApiOutputSuccess(c, result, http.StatusOK)
+}
+
+// @Summary Set the webhook secret of a connection
+// @Description The secret is returned only once, a random one is generated if the secret is empty
+// @Tags framework/source-webhooks
+// @Accept application/json
+// @Param plugin path string true "plugin name, github/gitlab/bitbucket/jira"
+// @Param connectionId path int true "connection id"
+// @Param secret body SecretInput false "json"
+// @Success 200  {object} models.SourceWebhook
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /source-webhooks/{plugin}/{connectionId}/secret [put]
+func PutSecret(c *gin.Context) {
+	connectionId, err := getConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	input := &SecretInput{}
+	if c.Request.Body != nil && c.Request.ContentLength != 0 {
+		if e := c.ShouldBindJSON(input); e != nil {
+			shared.ApiOutputError(c, errors.BadInput.Wrap(e, shared.BadRequestBody))
+			return
+		}
+	}
+	sourceWebhook, err := services.PutSourceWebhookSecret(c.Param("plugin"), connectionId, input.Secret)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error setting the source webhook secret"))
+		return
+	}
+	shared.ApiOutputSuccess(c, sourceWebhook, http.StatusOK)
+}
+
+// @Summary Delete the webhook secret of a connection
+// @Description Webhooks of the connection would be rejected afterward
+// @Tags framework/source-webhooks
+// @Param plugin path string true "plugin name, github/gitlab/bitbucket/jira"
+// @Param connectionId path int true "connection id"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /source-webhooks/{plugin}/{connectionId}/secret [delete]
+func DeleteSecret(c *gin.Context) {
+	connectionId, err := getConnectionId(c)
+	if err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	err = services.DeleteSourceWebhookSecret(c.Param("plugin"), connectionId)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting the source webhook secret"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+func newBlueprintsCmd() *cobra.Command {
+	blueprintsCmd := &cobra.Command{
+		Use:               "blueprints",
+		Short:             "Manage blueprints",
+		PersistentPreRunE: initServices,
+	}
+
+	syncPolicy := &models.SyncPolicy{}
+	var jumpQueue bool
+	triggerCmd := &cobra.Command{
+		Use:   "trigger <blueprintId>",
+		Short: "Create a pipeline for the blueprint, it is picked up by the api server",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blueprintId, err := parseId(args[0], "blueprint")
+			if err != nil {
+				return err
+			}
+			priority := 0
+			if jumpQueue {
+				priority = services.PipelinePriorityJumpQueue
+			}
+			pipeline, err := services.TriggerBlueprint(blueprintId, syncPolicy, priority, true)
+			if err != nil {
+				return errors.Default.Wrap(err, "error triggering blueprint")
+			}
+			return printResult(cmd.OutOrStdout(), pipeline, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "PIPELINE ID\tNAME\tSTATUS\tTASKS")
+				fmt.Fprintf(tw, "%d\t%s\t%s\t%d\n", pipeline.ID, pipeline.Name, pipeline.Status, pipeline.TotalTasks)
+			})
+		},
+	}
+	triggerCmd.Flags().BoolVar(&syncPolicy.SkipCollectors, "skip-collectors", false, "skip the collectors, only extract and convert the collected data")
+	triggerCmd.Flags().BoolVar(&syncPolicy.FullSync, "full-sync", false, "collect all data instead of only the updated ones")
+	triggerCmd.Flags().BoolVar(&jumpQueue, "jump-queue", false, "run the pipeline ahead of the scheduled ones waiting in the queue")
+
+	blueprintsCmd.AddCommand(triggerCmd)
+	return blueprintsCmd
+}
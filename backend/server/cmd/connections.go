@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+func newConnectionsCmd() *cobra.Command {
+	connectionsCmd := &cobra.Command{
+		Use:               "connections",
+		Short:             "Manage plugin connections",
+		PersistentPreRunE: initServices,
+	}
+
+	testCmd := &cobra.Command{
+		Use:   "test <plugin> <connectionId>",
+		Short: "Test an existing connection of a plugin",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			connectionId, err := parseId(args[1], "connection")
+			if err != nil {
+				return err
+			}
+			result, err := services.TestPluginConnection(args[0], connectionId)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), result.Body, func(tw *tabwriter.Writer) {
+				fmt.Fprintf(tw, "connection #%d of %s is OK\n", connectionId, args[0])
+			})
+		},
+	}
+
+	connectionsCmd.AddCommand(testCmd)
+	return connectionsCmd
+}
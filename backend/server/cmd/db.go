@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+func newDbCmd() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:               "db",
+		Short:             "Inspect the database",
+		PersistentPreRunE: initServices,
+	}
+
+	checkCmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check that the database is reachable, migrated and complete",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := services.CheckDatabase()
+			if err != nil {
+				return err
+			}
+			printErr := printResult(cmd.OutOrStdout(), result, func(tw *tabwriter.Writer) {
+				fmt.Fprintf(tw, "dialect\t%s\n", result.Dialect)
+				fmt.Fprintf(tw, "health\t%s\n", result.Health)
+				fmt.Fprintf(tw, "pending migrations\t%d\n", result.PendingMigrations)
+				fmt.Fprintf(tw, "missing tables\t%s\n", strings.Join(result.MissingTables, ", "))
+			})
+			if printErr != nil {
+				return printErr
+			}
+			if result.PendingMigrations > 0 || len(result.MissingTables) > 0 {
+				return errors.Default.New("database is not up to date, run `lake migrate`")
+			}
+			return nil
+		},
+	}
+
+	dbCmd.AddCommand(checkCmd)
+	return dbCmd
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+// lockMigration and getMigrator are replaced by the tests
+var lockMigration = services.LockMigration
+var getMigrator = services.GetMigrator
+
+type migrationScriptInfo struct {
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+}
+
+func newMigrateCmd() *cobra.Command {
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply all pending database migration scripts",
+		Long: "Apply all pending database migration scripts of the framework and the plugins.\n" +
+			"It can run while DevLake instances are serving the database, e.g. as a job before rolling them out,\n" +
+			"it waits for the other instances or commands migrating the database to finish first.",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: initMigrationServices,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			unlock, err := lockMigration()
+			if err != nil {
+				return errors.Default.Wrap(err, "error locking the database for migration")
+			}
+			defer unlock()
+			migrator := getMigrator()
+			pending := migrator.PendingScripts()
+			if err := migrator.Execute(); err != nil {
+				return errors.Default.Wrap(err, "error executing migration")
+			}
+			cmd.Printf("%d migration scripts applied\n", len(pending))
+			return nil
+		},
+	}
+
+	var exitCode bool
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "List pending database migration scripts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pending := make([]*migrationScriptInfo, 0)
+			for _, script := range getMigrator().PendingScripts() {
+				pending = append(pending, &migrationScriptInfo{Version: script.Version(), Name: script.Name()})
+			}
+			err := printResult(cmd.OutOrStdout(), pending, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "VERSION\tNAME")
+				for _, script := range pending {
+					fmt.Fprintf(tw, "%d\t%s\n", script.Version, script.Name)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if exitCode && len(pending) > 0 {
+				return errors.Default.New(fmt.Sprintf("%d migration scripts pending", len(pending)))
+			}
+			return nil
+		},
+	}
+	statusCmd.Flags().BoolVar(&exitCode, "exit-code", false, "exit with a non-zero code if there are pending migration scripts")
+	migrateCmd.AddCommand(statusCmd)
+	return migrateCmd
+}
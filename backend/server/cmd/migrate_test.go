@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func runMigrateCmd(t *testing.T, lock func() (func(), errors.Error), migrator plugin.Migrator) (string, error) {
+	oldLock, oldGetMigrator := lockMigration, getMigrator
+	t.Cleanup(func() {
+		lockMigration, getMigrator = oldLock, oldGetMigrator
+	})
+	lockMigration = lock
+	getMigrator = func() plugin.Migrator { return migrator }
+	cmd := newMigrateCmd()
+	cmd.PersistentPreRunE = nil
+	return executeCmd(cmd)
+}
+
+func executeCmd(cmd *cobra.Command, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestMigrateLocksMigration(t *testing.T) {
+	locked := false
+	migrator := mockplugin.NewMigrator(t)
+	migrator.On("PendingScripts").Return([]plugin.MigrationScript{mockplugin.NewMigrationScript(t)})
+	migrator.On("Execute").Run(func(_ mock.Arguments) {
+		assert.True(t, locked)
+	}).Return(nil)
+	out, err := runMigrateCmd(t, func() (func(), errors.Error) {
+		locked = true
+		return func() { locked = false }, nil
+	}, migrator)
+	assert.Nil(t, err)
+	assert.False(t, locked)
+	assert.Contains(t, out, "1 migration scripts applied")
+}
+
+func TestMigrateFailsWithoutMigrationLock(t *testing.T) {
+	// the migrator is not called at all
+	migrator := mockplugin.NewMigrator(t)
+	_, err := runMigrateCmd(t, func() (func(), errors.Error) {
+		return nil, errors.Default.New("failed to lock _devlake_migration_locking_stub")
+	}, migrator)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,146 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+func newPipelinesCmd() *cobra.Command {
+	pipelinesCmd := &cobra.Command{
+		Use:               "pipelines",
+		Short:             "Manage pipelines",
+		PersistentPreRunE: initServices,
+	}
+
+	query := &services.PipelineQuery{}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List pipelines, the latest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelines, count, err := services.GetPipelines(query, true)
+			if err != nil {
+				return errors.Default.Wrap(err, "error getting pipelines")
+			}
+			return printResult(cmd.OutOrStdout(), pipelines, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "ID\tNAME\tBLUEPRINT\tSTATUS\tTASKS\tBEGAN AT\tFINISHED AT")
+				for _, p := range pipelines {
+					fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%d/%d\t%s\t%s\n",
+						p.ID, p.Name, p.BlueprintId, p.Status, p.FinishedTasks, p.TotalTasks,
+						formatTime(p.BeganAt), formatTime(p.FinishedAt),
+					)
+				}
+				fmt.Fprintf(tw, "\n%d of %d pipelines\n", len(pipelines), count)
+			})
+		},
+	}
+	listCmd.Flags().StringVar(&query.Status, "status", "", "only list pipelines with the status, i.e. TASK_FAILED")
+	listCmd.Flags().IntVar(&query.Pending, "pending", 0, "only list pending pipelines when greater than 0")
+	listCmd.Flags().Uint64Var(&query.BlueprintId, "blueprint-id", 0, "only list pipelines of the blueprint")
+	listCmd.Flags().StringVar(&query.Label, "label", "", "only list pipelines with the label")
+	listCmd.Flags().IntVar(&query.Page, "page", 1, "page number")
+	listCmd.Flags().IntVar(&query.PageSize, "page-size", 50, "page size")
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <pipelineId>",
+		Short: "Cancel a pending pipeline",
+		Long: "Cancel a pending pipeline. Running pipelines are executed by the api server " +
+			"and have to be cancelled through DELETE /pipelines/:pipelineId instead.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelineId, err := parseId(args[0], "pipeline")
+			if err != nil {
+				return err
+			}
+			pipeline, err := services.GetPipeline(pipelineId, true)
+			if err != nil {
+				return err
+			}
+			if pipeline.Status == models.TASK_RUNNING {
+				return errors.BadInput.New(fmt.Sprintf("pipeline #%d is running in the api server, use the api to cancel it", pipelineId))
+			}
+			if err = services.CancelPipeline(pipelineId); err != nil {
+				return errors.Default.Wrap(err, "error cancelling pipeline")
+			}
+			cmd.Printf("pipeline #%d cancelled\n", pipelineId)
+			return nil
+		},
+	}
+
+	rerunCmd := &cobra.Command{
+		Use:   "rerun <pipelineId>",
+		Short: "Rerun all failed tasks of a pipeline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelineId, err := parseId(args[0], "pipeline")
+			if err != nil {
+				return err
+			}
+			tasks, err := services.RerunPipeline(pipelineId, nil)
+			if err != nil {
+				return errors.Default.Wrap(err, "failed to rerun pipeline")
+			}
+			for _, task := range tasks {
+				if task.Options, err = sanitizeTaskOptions(task); err != nil {
+					return err
+				}
+			}
+			return printResult(cmd.OutOrStdout(), tasks, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "TASK ID\tPLUGIN\tROW\tCOL\tSTATUS")
+				for _, t := range tasks {
+					fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%s\n", t.ID, t.Plugin, t.PipelineRow, t.PipelineCol, t.Status)
+				}
+			})
+		},
+	}
+
+	pipelinesCmd.AddCommand(listCmd, cancelCmd, rerunCmd)
+	return pipelinesCmd
+}
+
+func sanitizeTaskOptions(task *models.Task) (map[string]interface{}, errors.Error) {
+	options, err := services.SanitizePluginOption(task.Plugin, task.Options)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to sanitize task")
+	}
+	return options, nil
+}
+
+func parseId(arg string, entity string) (uint64, errors.Error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, fmt.Sprintf("bad %s ID format supplied", entity))
+	}
+	return id, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+func newRawCmd() *cobra.Command {
+	rawCmd := &cobra.Command{
+		Use:               "raw",
+		Short:             "Manage the raw layer data",
+		PersistentPreRunE: initServices,
+	}
+
+	query := &services.RawDataPurgeQuery{}
+	var before string
+	var yes bool
+	purgeCmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Delete collected records from the raw layer tables",
+		Long: "Delete collected records from the raw layer tables.\n" +
+			"Purged data has to be collected again before it can be extracted, pipelines with skipCollectors would not see it.\n" +
+			"The purge asks for confirmation unless --yes or --dry-run is given.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if before != "" {
+				t, err := time.Parse(time.RFC3339, before)
+				if err != nil {
+					return errors.BadInput.Wrap(err, "--before must be in RFC3339 format, i.e. 2006-01-02T15:04:05Z")
+				}
+				query.Before = &t
+			}
+			if !query.DryRun && !yes && !confirmPurge(cmd, query) {
+				return errors.Default.New("purge aborted")
+			}
+			purged, err := services.PurgeRawData(query)
+			if err != nil {
+				return err
+			}
+			return printResult(cmd.OutOrStdout(), purged, func(tw *tabwriter.Writer) {
+				fmt.Fprintln(tw, "TABLE\tROWS")
+				total := int64(0)
+				for _, p := range purged {
+					fmt.Fprintf(tw, "%s\t%d\n", p.Table, p.Rows)
+					total += p.Rows
+				}
+				if query.DryRun {
+					fmt.Fprintf(tw, "\n%d rows would be purged\n", total)
+				} else {
+					fmt.Fprintf(tw, "\n%d rows purged\n", total)
+				}
+			})
+		},
+	}
+	purgeCmd.Flags().StringVar(&query.Plugin, "plugin", "", "only purge the raw tables of the plugin")
+	purgeCmd.Flags().StringVar(&before, "before", "", "only purge records collected before the time, i.e. 2006-01-02T15:04:05Z")
+	purgeCmd.Flags().BoolVar(&query.DryRun, "dry-run", false, "count the records without deleting them")
+	purgeCmd.Flags().BoolVarP(&yes, "yes", "y", false, "purge without asking for confirmation")
+
+	rawCmd.AddCommand(purgeCmd)
+	return rawCmd
+}
+
+// confirmPurge asks the user to confirm the purge on the standard input
+func confirmPurge(cmd *cobra.Command, query *services.RawDataPurgeQuery) bool {
+	tables := "all the raw tables"
+	if query.Plugin != "" {
+		tables = fmt.Sprintf("the raw tables of %s", query.Plugin)
+	}
+	records := "all the records"
+	if query.Before != nil {
+		records = fmt.Sprintf("the records collected before %s", query.Before.Format(time.RFC3339))
+	}
+	cmd.Printf("Purging %s of %s, continue? [y/N] ", records, tables)
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
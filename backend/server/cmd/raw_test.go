@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawPurgeAskForConfirmation(t *testing.T) {
+	cmd := newRawCmd()
+	cmd.PersistentPreRunE = nil
+	cmd.SetIn(strings.NewReader("n\n"))
+	out, err := executeCmd(cmd, "purge", "--plugin", "github", "--before", "2024-01-01T00:00:00Z")
+	assert.NotNil(t, err)
+	assert.Contains(t, out, "Purging the records collected before 2024-01-01T00:00:00Z of the raw tables of github, continue? [y/N]")
+	assert.Contains(t, out, "purge aborted")
+}
+
+func TestRawPurgeInvalidBefore(t *testing.T) {
+	cmd := newRawCmd()
+	cmd.PersistentPreRunE = nil
+	_, err := executeCmd(cmd, "purge", "--yes", "--before", "2024-01-01")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+var output string
+
+// NewRootCmd returns the command of the `lake` binary. The api server is started by `serve`
+// when no subcommand is given, the subcommands operate the instance through the services module
+func NewRootCmd(serve func()) *cobra.Command {
+	rootCmd := &cobra.Command{
+		Use:          "lake",
+		Short:        "Apache DevLake server and administration commands",
+		SilenceUsage: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			serve()
+		},
+	}
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", outputTable, "output format of the subcommands, table or json")
+	rootCmd.AddCommand(
+		newMigrateCmd(),
+		newPipelinesCmd(),
+		newBlueprintsCmd(),
+		newConnectionsCmd(),
+		newRawCmd(),
+		newDbCmd(),
+	)
+	return rootCmd
+}
+
+// Execute runs the `lake` command and exits with a non-zero code on failure
+func Execute(serve func()) {
+	if err := NewRootCmd(serve).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// initServices prepares the services module for the subcommands
+func initServices(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(); err != nil {
+		return err
+	}
+	services.InitCli()
+	return nil
+}
+
+// initMigrationServices prepares the services module for the migration, the plugins are not initialized
+// as they may depend on the tables being migrated
+func initMigrationServices(cmd *cobra.Command, args []string) error {
+	if err := checkOutput(); err != nil {
+		return err
+	}
+	services.InitCliMigration()
+	return nil
+}
+
+func checkOutput() errors.Error {
+	if output != outputTable && output != outputJson {
+		return errors.BadInput.New(fmt.Sprintf("unsupported output format: %s", output))
+	}
+	return nil
+}
+
+// printResult writes `result` as JSON, or calls `printTable` to render it as a table
+func printResult(w io.Writer, result interface{}, printTable func(tw *tabwriter.Writer)) error {
+	if output == outputJson {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	printTable(tw)
+	return tw.Flush()
+}
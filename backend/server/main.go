@@ -22,13 +22,16 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	_ "github.com/apache/incubator-devlake/core/version"
 	"github.com/apache/incubator-devlake/server/api"
+	"github.com/apache/incubator-devlake/server/cmd"
 )
 
 func main() {
-	v := config.GetConfig()
-	encryptionSecret := v.GetString(plugin.EncodeKeyEnvStr)
-	if encryptionSecret == "" {
-		panic("ENCRYPTION_SECRET must be set in environment variable or .env file")
-	}
-	api.CreateAndRunApiServer()
+	cmd.Execute(func() {
+		v := config.GetConfig()
+		encryptionSecret := v.GetString(plugin.EncodeKeyEnvStr)
+		if encryptionSecret == "" {
+			panic("ENCRYPTION_SECRET must be set in environment variable or .env file")
+		}
+		api.CreateAndRunApiServer()
+	})
 }
@@ -54,7 +54,7 @@ type BlueprintJob struct {
 
 func (bj BlueprintJob) Run() {
 	blueprint := bj.Blueprint
-	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy)
+	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy, 0)
 	if err == ErrEmptyPlan {
 		blueprintLog.Info("Empty plan, blueprint id:[%d] blueprint name:[%s]", blueprint.ID, blueprint.Name)
 		return
@@ -299,7 +299,7 @@ func reloadBlueprint(blueprint *models.Blueprint) errors.Error {
 	return nil
 }
 
-func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy) (*models.Pipeline, errors.Error) {
+func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy, priority int) (*models.Pipeline, errors.Error) {
 	var plan models.PipelinePlan
 	var err errors.Error
 	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
@@ -318,6 +318,7 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.S
 	newPipeline.BlueprintId = blueprint.ID
 	newPipeline.Labels = blueprint.Labels
 	newPipeline.SyncPolicy = blueprint.SyncPolicy
+	newPipeline.Priority = priority
 
 	// if the plan is empty, we should not create the pipeline
 	// var shouldCreatePipeline bool
@@ -399,8 +400,8 @@ func SequencializePipelinePlans(plans ...models.PipelinePlan) models.PipelinePla
 	return merged
 }
 
-// TriggerBlueprint triggers blueprint immediately
-func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, shouldSanitize bool) (*models.Pipeline, errors.Error) {
+// TriggerBlueprint triggers blueprint immediately, the pipeline is queued with the given priority
+func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, priority int, shouldSanitize bool) (*models.Pipeline, errors.Error) {
 	// load record from db
 	blueprint, err := GetBlueprint(id, false)
 	if err != nil {
@@ -412,7 +413,7 @@ func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, shouldSanitize b
 	}
 	blueprint.SkipCollectors = syncPolicy.SkipCollectors
 	blueprint.FullSync = syncPolicy.FullSync
-	pipeline, err := createPipelineByBlueprint(blueprint, syncPolicy)
+	pipeline, err := createPipelineByBlueprint(blueprint, syncPolicy, priority)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ package services
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -96,8 +97,8 @@ func GeneratePlanJsonV200(
 	}
 
 	// make plans for metric plugins
-	metricPlans := make([]coreModels.PipelinePlan, len(metrics))
-	i := 0
+	metricPlans := make(map[string]coreModels.PipelinePlan, len(metrics))
+	metricRunAfter := make(map[string][]string, len(metrics))
 	for metricPluginName, metricPluginOptJson := range metrics {
 		p, err := plugin.GetPlugin(metricPluginName)
 		if err != nil {
@@ -108,16 +109,25 @@ func GeneratePlanJsonV200(
 			if len(metricPluginOptJson) == 0 {
 				metricPluginOptJson = json.RawMessage("{}")
 			}
-			metricPlans[i], err = pluginBp.MakeMetricPluginPipelinePlanV200(projectName, metricPluginOptJson)
+			metricPlans[metricPluginName], err = pluginBp.MakeMetricPluginPipelinePlanV200(projectName, metricPluginOptJson)
 			if err != nil {
 				return nil, err
 			}
-			i++
 		} else {
 			return nil, errors.Default.New(
 				fmt.Sprintf("plugin %s does not support MetricPluginBlueprintV200", metricPluginName),
 			)
 		}
+		if pluginMetric, ok := p.(plugin.PluginMetric); ok {
+			metricRunAfter[metricPluginName], err = pluginMetric.RunAfter()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	metricPlan, err := sequenceMetricPlans(metricPlans, metricRunAfter)
+	if err != nil {
+		return nil, err
 	}
 	var planForProjectMapping coreModels.PipelinePlan
 	if projectName != "" {
@@ -135,11 +145,46 @@ func GeneratePlanJsonV200(
 	plan := SequencializePipelinePlans(
 		planForProjectMapping,
 		ParallelizePipelinePlans(sourcePlans...),
-		ParallelizePipelinePlans(metricPlans...),
+		metricPlan,
 	)
 	return plan, err
 }
 
+// sequenceMetricPlans runs the plans of the metric plugins after the plans of the plugins they RunAfter,
+// the plans not depending on each other run in parallel
+func sequenceMetricPlans(plans map[string]coreModels.PipelinePlan, runAfter map[string][]string) (coreModels.PipelinePlan, errors.Error) {
+	done := make(map[string]bool, len(plans))
+	var sequence []coreModels.PipelinePlan
+	for len(done) < len(plans) {
+		var ready []string
+		for name := range plans {
+			if done[name] {
+				continue
+			}
+			isReady := true
+			for _, dependency := range runAfter[name] {
+				if _, enabled := plans[dependency]; enabled && !done[dependency] {
+					isReady = false
+				}
+			}
+			if isReady {
+				ready = append(ready, name)
+			}
+		}
+		if len(ready) == 0 {
+			return nil, errors.Default.New("circular RunAfter dependencies between the metric plugins")
+		}
+		sort.Strings(ready)
+		parallel := make([]coreModels.PipelinePlan, 0, len(ready))
+		for _, name := range ready {
+			parallel = append(parallel, plans[name])
+			done[name] = true
+		}
+		sequence = append(sequence, ParallelizePipelinePlans(parallel...))
+	}
+	return SequencializePipelinePlans(sequence...), nil
+}
+
 func removeCollectorTasks(plan coreModels.PipelinePlan) coreModels.PipelinePlan {
 	for j, stage := range plan {
 		for k, task := range stage {
@@ -27,6 +27,11 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	doraImpl "github.com/apache/incubator-devlake/plugins/dora/impl"
+	forecastImpl "github.com/apache/incubator-devlake/plugins/forecast/impl"
+	issueRollupImpl "github.com/apache/incubator-devlake/plugins/issue_rollup/impl"
+	issueTraceImpl "github.com/apache/incubator-devlake/plugins/issue_trace/impl"
+	linkerImpl "github.com/apache/incubator-devlake/plugins/linker/impl"
 	"github.com/apache/incubator-devlake/plugins/org/tasks"
 	"github.com/stretchr/testify/assert"
 )
@@ -101,3 +106,72 @@ func TestMakePlanV200(t *testing.T) {
 
 	assert.Equal(t, expectedPlan, plan)
 }
+
+func TestSequenceMetricPlans(t *testing.T) {
+	stage := func(name string) coreModels.PipelineStage {
+		return coreModels.PipelineStage{{Plugin: name}}
+	}
+	plans := map[string]coreModels.PipelinePlan{
+		"dora":         {stage("dora"), stage("refdiff"), stage("dora")},
+		"forecast":     {stage("forecast")},
+		"issue_rollup": {stage("issue_rollup")},
+	}
+	// the plugins not enabled are ignored
+	runAfter := map[string][]string{"issue_rollup": {"dora", "forecast", "linker"}}
+	plan, err := sequenceMetricPlans(plans, runAfter)
+	assert.Nil(t, err)
+	assert.Equal(t, coreModels.PipelinePlan{
+		{{Plugin: "dora"}, {Plugin: "forecast"}},
+		stage("refdiff"),
+		stage("dora"),
+		stage("issue_rollup"),
+	}, plan)
+
+	_, err = sequenceMetricPlans(plans, map[string][]string{"dora": {"forecast"}, "forecast": {"dora"}})
+	assert.NotNil(t, err)
+}
+
+func TestMakePlanV200MetricPluginsWithoutRunAfter(t *testing.T) {
+	metricPlugins := map[string]plugin.PluginMeta{
+		"dora":        doraImpl.Dora{},
+		"forecast":    forecastImpl.Forecast{},
+		"issue_trace": issueTraceImpl.IssueTrace{},
+		"linker":      linkerImpl.Linker{},
+	}
+	metrics := make(map[string]json.RawMessage)
+	var plans []coreModels.PipelinePlan
+	for name, p := range metricPlugins {
+		assert.Nil(t, plugin.RegisterPlugin(name, p))
+		metrics[name] = json.RawMessage("{}")
+		plan, err := p.(plugin.MetricPluginBlueprintV200).MakeMetricPluginPipelinePlanV200("", metrics[name])
+		assert.Nil(t, err)
+		plans = append(plans, plan)
+	}
+	// the plans of the plugins without RunAfter are merged stage by stage as they have always been
+	expectedPlan := ParallelizePipelinePlans(plans...)
+
+	plan, err := GeneratePlanJsonV200("", nil, metrics, false)
+	assert.Nil(t, err)
+	assert.Len(t, plan, len(expectedPlan))
+	for i := range expectedPlan {
+		assert.ElementsMatch(t, expectedPlan[i], plan[i])
+	}
+}
+
+func TestMakePlanV200IssueRollupRunsAfterLinker(t *testing.T) {
+	assert.Nil(t, plugin.RegisterPlugin("linker", linkerImpl.Linker{}))
+	assert.Nil(t, plugin.RegisterPlugin("issue_rollup", issueRollupImpl.IssueRollup{}))
+	plan, err := GeneratePlanJsonV200("", nil, map[string]json.RawMessage{
+		"linker":       json.RawMessage("{}"),
+		"issue_rollup": json.RawMessage("{}"),
+	}, false)
+	assert.Nil(t, err)
+	stages := make(map[string]int)
+	for i, stage := range plan {
+		for _, task := range stage {
+			stages[task.Plugin] = i
+		}
+	}
+	assert.Equal(t, 0, stages["linker"])
+	assert.Equal(t, 1, stages["issue_rollup"])
+}
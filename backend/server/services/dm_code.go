@@ -18,6 +18,8 @@ limitations under the License.
 package services
 
 import (
+	"regexp"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
@@ -29,3 +31,98 @@ func GetRepos() ([]*code.Repo, int64, errors.Error) {
 	err := db.All(&repos, dal.Orderby("id DESC"))
 	return repos, int64(len(repos)), err
 }
+
+// ComponentInput is the body to create or update a Component of a repo
+type ComponentInput struct {
+	Name         string `json:"name" validate:"required,max=255"`
+	PathRegex    string `json:"pathRegex" validate:"required,max=255"`
+	SortingIndex int    `json:"sortingIndex"`
+}
+
+func validateComponentInput(input *ComponentInput) errors.Error {
+	if err := VerifyStruct(input); err != nil {
+		return err
+	}
+	if _, err := regexp.Compile(input.PathRegex); err != nil {
+		return errors.BadInput.Wrap(err, "invalid pathRegex")
+	}
+	return nil
+}
+
+// GetComponents returns the Components of the repo, in the order they are matched against the file paths
+func GetComponents(repoId string) ([]*code.Component, errors.Error) {
+	components := make([]*code.Component, 0)
+	err := db.All(&components, dal.Where("repo_id = ?", repoId), dal.Orderby("sorting_index DESC, name"))
+	return components, err
+}
+
+// GetComponent returns the Component of the repo by name
+func GetComponent(repoId string, name string) (*code.Component, errors.Error) {
+	component := &code.Component{}
+	err := db.First(component, dal.Where("repo_id = ? AND name = ?", repoId, name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("component not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the component from database")
+	}
+	return component, nil
+}
+
+// CreateComponent creates a Component for the repo
+func CreateComponent(repoId string, input *ComponentInput) (*code.Component, errors.Error) {
+	if err := validateComponentInput(input); err != nil {
+		return nil, err
+	}
+	if err := db.First(&code.Repo{}, dal.Where("id = ?", repoId)); err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New("repo not found")
+		}
+		return nil, errors.Default.Wrap(err, "error getting the repo from database")
+	}
+	count, err := db.Count(dal.From(&code.Component{}), dal.Where("repo_id = ? AND name = ?", repoId, input.Name))
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.Conflict.New("component exists already")
+	}
+	component := &code.Component{
+		RepoId:       repoId,
+		Name:         input.Name,
+		PathRegex:    input.PathRegex,
+		Source:       code.COMPONENT_SOURCE_MANUAL,
+		SortingIndex: input.SortingIndex,
+	}
+	if err := db.Create(component); err != nil {
+		return nil, errors.Default.Wrap(err, "error creating the component")
+	}
+	return component, nil
+}
+
+// UpdateComponent updates the Component, it would no longer be overwritten by CODEOWNERS afterward
+func UpdateComponent(repoId string, name string, input *ComponentInput) (*code.Component, errors.Error) {
+	input.Name = name
+	if err := validateComponentInput(input); err != nil {
+		return nil, err
+	}
+	component, err := GetComponent(repoId, name)
+	if err != nil {
+		return nil, err
+	}
+	component.PathRegex = input.PathRegex
+	component.SortingIndex = input.SortingIndex
+	component.Source = code.COMPONENT_SOURCE_MANUAL
+	if err := db.Update(component); err != nil {
+		return nil, errors.Default.Wrap(err, "error updating the component")
+	}
+	return component, nil
+}
+
+// DeleteComponent deletes the Component, the files would be assigned to other Components in the next collection
+func DeleteComponent(repoId string, name string) errors.Error {
+	if _, err := GetComponent(repoId, name); err != nil {
+		return err
+	}
+	return db.Delete(&code.Component{}, dal.Where("repo_id = ? AND name = ?", repoId, name))
+}
@@ -0,0 +1,345 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+const (
+	domainQueryDefaultLimit = 100
+	domainQueryMaxLimit     = 10000
+)
+
+// DomainQuery is a query for QueryDomainTable
+type DomainQuery struct {
+	// Project limits the rows to the scopes of the project via `project_mapping`
+	Project string `form:"project"`
+	// From and To limit the rows by the date column of the table, i.e. `created_date` of issues
+	From *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	// Fields is a comma separated list of the columns to be returned, all columns if empty
+	Fields string `form:"fields"`
+	// Cursor is the `nextCursor` of the previous page
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// DomainQueryResult is a page of rows returned by QueryDomainTable
+type DomainQueryResult struct {
+	Columns    []string                 `json:"columns"`
+	Rows       []map[string]interface{} `json:"rows"`
+	NextCursor string                   `json:"nextCursor"`
+}
+
+// domainQueryable describes how a domain table could be queried
+type domainQueryable struct {
+	table      dal.Tabler
+	pkColumn   string
+	dateColumn string
+	// projectScope is a subquery selecting the primary keys belong to the project, the project name is its only parameter
+	projectScope string
+	// where is applied to every query, to select a subset of the table, i.e. incidents from issues
+	where     string
+	whereArgs []interface{}
+}
+
+var issuesProjectScope = `SELECT bi.issue_id FROM board_issues bi
+	JOIN project_mapping pm ON pm.row_id = bi.board_id AND pm.table = 'boards'
+	WHERE pm.project_name = ?`
+
+var domainQueryables = map[string]*domainQueryable{
+	"issues": {
+		table:        &ticket.Issue{},
+		pkColumn:     "id",
+		dateColumn:   "created_date",
+		projectScope: issuesProjectScope,
+	},
+	// incidents are the issues with the INCIDENT type
+	"incidents": {
+		table:        &ticket.Issue{},
+		pkColumn:     "id",
+		dateColumn:   "created_date",
+		projectScope: issuesProjectScope,
+		where:        "type = ?",
+		whereArgs:    []interface{}{ticket.INCIDENT},
+	},
+	"pull_requests": {
+		table:      &code.PullRequest{},
+		pkColumn:   "id",
+		dateColumn: "created_date",
+		projectScope: `SELECT pr.id FROM pull_requests pr
+			JOIN project_mapping pm ON pm.row_id = pr.base_repo_id AND pm.table = 'repos'
+			WHERE pm.project_name = ?`,
+	},
+	"commits": {
+		table:      &code.Commit{},
+		pkColumn:   "sha",
+		dateColumn: "authored_date",
+		projectScope: `SELECT rc.commit_sha FROM repo_commits rc
+			JOIN project_mapping pm ON pm.row_id = rc.repo_id AND pm.table = 'repos'
+			WHERE pm.project_name = ?`,
+	},
+	"cicd_deployments": {
+		table:      &devops.CICDDeployment{},
+		pkColumn:   "id",
+		dateColumn: "finished_date",
+		projectScope: `SELECT d.id FROM cicd_deployments d
+			JOIN project_mapping pm ON pm.row_id = d.cicd_scope_id AND pm.table = 'cicd_scopes'
+			WHERE pm.project_name = ?`,
+	},
+	"cicd_pipelines": {
+		table:      &devops.CICDPipeline{},
+		pkColumn:   "id",
+		dateColumn: "finished_date",
+		projectScope: `SELECT p.id FROM cicd_pipelines p
+			JOIN project_mapping pm ON pm.row_id = p.cicd_scope_id AND pm.table = 'cicd_scopes'
+			WHERE pm.project_name = ?`,
+	},
+	// accounts are shared by all projects
+	"accounts": {
+		table:      &crossdomain.Account{},
+		pkColumn:   "id",
+		dateColumn: "created_date",
+	},
+	"sprints": {
+		table:      &ticket.Sprint{},
+		pkColumn:   "id",
+		dateColumn: "started_date",
+		projectScope: `SELECT bs.sprint_id FROM board_sprints bs
+			JOIN project_mapping pm ON pm.row_id = bs.board_id AND pm.table = 'boards'
+			WHERE pm.project_name = ?`,
+	},
+}
+
+// QueryableDomainTables returns the names of the tables supported by QueryDomainTable
+func QueryableDomainTables() []string {
+	names := make([]string, 0, len(domainQueryables))
+	for name := range domainQueryables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QueryDomainTable returns a page of rows from the domain table `name` matching the `query`
+func QueryDomainTable(name string, query *DomainQuery) (*DomainQueryResult, errors.Error) {
+	queryable, ok := domainQueryables[name]
+	if !ok {
+		return nil, errors.NotFound.New(fmt.Sprintf("domain table %s is not queryable, supported tables: %s", name, strings.Join(QueryableDomainTables(), ", ")))
+	}
+	columns, err := selectDomainColumns(queryable, query.Fields)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = domainQueryDefaultLimit
+	}
+	if limit > domainQueryMaxLimit {
+		return nil, errors.BadInput.New(fmt.Sprintf("limit must not be greater than %d", domainQueryMaxLimit))
+	}
+
+	clauses := []dal.Clause{
+		dal.Select(strings.Join(columns, ",")),
+		dal.From(queryable.table.TableName()),
+	}
+	if queryable.where != "" {
+		clauses = append(clauses, dal.Where(queryable.where, queryable.whereArgs...))
+	}
+	if query.Project != "" {
+		if queryable.projectScope == "" {
+			return nil, errors.BadInput.New(fmt.Sprintf("%s can not be filtered by project", name))
+		}
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s IN (%s)", queryable.pkColumn, queryable.projectScope), query.Project))
+	}
+	if query.From != nil {
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s >= ?", queryable.dateColumn), *query.From))
+	}
+	if query.To != nil {
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s < ?", queryable.dateColumn), *query.To))
+	}
+	if query.Cursor != "" {
+		after, err := decodeDomainCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, dal.Where(fmt.Sprintf("%s > ?", queryable.pkColumn), after))
+	}
+	clauses = append(clauses,
+		dal.Orderby(fmt.Sprintf("%s ASC", queryable.pkColumn)),
+		dal.Limit(limit),
+	)
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error querying %s", name))
+	}
+	defer cursor.Close()
+	rows, err := scanDomainRows(cursor)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error reading %s", name))
+	}
+	result := &DomainQueryResult{Columns: columns, Rows: rows}
+	if len(rows) == limit {
+		result.NextCursor = encodeDomainCursor(fmt.Sprintf("%v", rows[len(rows)-1][queryable.pkColumn]))
+	}
+	return result, nil
+}
+
+// selectDomainColumns validates the comma separated `fields` against the columns of the table,
+// the primary key is always selected since pagination relies on it
+func selectDomainColumns(queryable *domainQueryable, fields string) ([]string, errors.Error) {
+	columnMetas, err := db.GetColumns(queryable.table, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error getting columns of %s", queryable.table.TableName()))
+	}
+	allColumns := make([]string, 0, len(columnMetas))
+	for _, columnMeta := range columnMetas {
+		allColumns = append(allColumns, columnMeta.Name())
+	}
+	return pickDomainColumns(allColumns, queryable.pkColumn, fields)
+}
+
+func pickDomainColumns(allColumns []string, pkColumn string, fields string) ([]string, errors.Error) {
+	if strings.TrimSpace(fields) == "" {
+		return allColumns, nil
+	}
+	known := make(map[string]bool, len(allColumns))
+	for _, column := range allColumns {
+		known[column] = true
+	}
+	columns := []string{pkColumn}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || field == pkColumn {
+			continue
+		}
+		if !known[field] {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown field %s", field))
+		}
+		columns = append(columns, field)
+	}
+	return columns, nil
+}
+
+func encodeDomainCursor(pk string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pk))
+}
+
+func decodeDomainCursor(cursor string) (string, errors.Error) {
+	pk, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, "invalid cursor")
+	}
+	return string(pk), nil
+}
+
+// scanDomainRows reads all rows from the cursor into maps keyed by column names, NULL values are kept as nil
+func scanDomainRows(cursor dal.Rows) ([]map[string]interface{}, errors.Error) {
+	columns, err := errors.Convert01(cursor.Columns())
+	if err != nil {
+		return nil, err
+	}
+	columnTypes, err := errors.Convert01(cursor.ColumnTypes())
+	if err != nil {
+		return nil, err
+	}
+	forScanValues := make([]interface{}, len(columns))
+	for i, columnType := range columnTypes {
+		forScanValues[i] = newDomainScanValue(columnType.ScanType())
+	}
+	rows := make([]map[string]interface{}, 0)
+	for cursor.Next() {
+		err = errors.Convert(cursor.Scan(forScanValues...))
+		if err != nil {
+			return nil, err
+		}
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = domainScanValueOf(forScanValues[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func newDomainScanValue(scanType reflect.Type) interface{} {
+	if scanType == nil {
+		return new(sql.NullString)
+	}
+	switch scanType.Name() {
+	case "Time", "NullTime":
+		return new(sql.NullTime)
+	case "NullInt64", "NullInt32", "NullInt16":
+		return new(sql.NullInt64)
+	case "NullFloat64":
+		return new(sql.NullFloat64)
+	case "NullBool":
+		return new(sql.NullBool)
+	}
+	switch scanType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(sql.NullInt64)
+	case reflect.Float32, reflect.Float64:
+		return new(sql.NullFloat64)
+	case reflect.Bool:
+		return new(sql.NullBool)
+	}
+	return new(sql.NullString)
+}
+
+func domainScanValueOf(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *sql.NullTime:
+		if v.Valid {
+			return v.Time
+		}
+	case *sql.NullInt64:
+		if v.Valid {
+			return v.Int64
+		}
+	case *sql.NullFloat64:
+		if v.Valid {
+			return v.Float64
+		}
+	case *sql.NullBool:
+		if v.Valid {
+			return v.Bool
+		}
+	case *sql.NullString:
+		if v.Valid {
+			return v.String
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickDomainColumns(t *testing.T) {
+	allColumns := []string{"id", "title", "status", "created_date"}
+
+	columns, err := pickDomainColumns(allColumns, "id", "")
+	assert.Nil(t, err)
+	assert.Equal(t, allColumns, columns)
+
+	columns, err = pickDomainColumns(allColumns, "id", "status, title,id")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"id", "status", "title"}, columns)
+
+	_, err = pickDomainColumns(allColumns, "id", "title,password")
+	assert.NotNil(t, err)
+}
+
+func TestDomainCursor(t *testing.T) {
+	cursor := encodeDomainCursor("github:GithubIssue:1:42")
+	pk, err := decodeDomainCursor(cursor)
+	assert.Nil(t, err)
+	assert.Equal(t, "github:GithubIssue:1:42", pk)
+
+	_, err = decodeDomainCursor("not a cursor!")
+	assert.NotNil(t, err)
+}
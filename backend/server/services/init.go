@@ -90,17 +90,13 @@ func Init() {
 	InitResources()
 
 	// lock the database to avoid multiple devlake instances from sharing the same one
-	lockDatabase()
+	errors.Must(lockDatabase())
 
 	// now, load the plugins
 	errors.Must(runner.LoadPlugins(basicRes))
 
 	// pull migration scripts from plugins to migrator
-	for _, pluginInst := range plugin.AllPlugins() {
-		if migratable, ok := pluginInst.(plugin.PluginMigration); ok {
-			migrator.Register(migratable.MigrationScripts(), pluginInst.Name())
-		}
-	}
+	registerPluginMigrations()
 
 	// check if there are pending migration
 	if migrator.HasPendingScripts() {
@@ -117,6 +113,30 @@ func Init() {
 	}
 }
 
+// InitCli initializes the services module for the command line interface.
+// Unlike Init, it neither locks the database nor starts the pipeline queue, so
+// it is safe to use while a DevLake instance is serving the same database.
+func InitCli() {
+	InitCliMigration()
+	plugin.InitPlugins(basicRes)
+}
+
+// InitCliMigration prepares the migrator for the command line interface, the plugins
+// are loaded for their migration scripts but not initialized before migrating
+func InitCliMigration() {
+	InitResources()
+	errors.Must(runner.LoadPlugins(basicRes))
+	registerPluginMigrations()
+}
+
+func registerPluginMigrations() {
+	for _, pluginInst := range plugin.AllPlugins() {
+		if migratable, ok := pluginInst.(plugin.PluginMigration); ok {
+			migrator.Register(migratable.MigrationScripts(), pluginInst.Name())
+		}
+	}
+}
+
 var statusLock sync.Mutex
 
 // ExecuteMigration executes all pending migration scripts and initialize services module
@@ -133,7 +153,7 @@ func ExecuteMigration() errors.Error {
 	serviceStatus = SERVICE_STATUS_MIGRATING
 	statusLock.Unlock() // unlock to allow other API requests to check the status
 	// apply all pending migration scripts
-	err := migrator.Execute()
+	err := MigrateDatabase()
 	if err != nil {
 		return err
 	}
@@ -144,6 +164,8 @@ func ExecuteMigration() errors.Error {
 
 	// initialize pipeline server, mainly to start the pipeline consuming process
 	pipelineServiceInit()
+	// debounce the scoped pipelines triggered by the webhooks of the data sources
+	sourceWebhookServiceInit()
 	statusLock.Lock()
 	serviceStatus = SERVICE_STATUS_READY
 	return nil
@@ -18,7 +18,6 @@ limitations under the License.
 package services
 
 import (
-	"fmt"
 	"os"
 	"time"
 
@@ -33,34 +32,87 @@ var lockingTx dal.Transaction
 
 // lockDatabase prevents multiple devlake instances from sharing the same lockDatabase
 // check the models.LockingHistory for the detail
-func lockDatabase() {
+func lockDatabase() errors.Error {
 	db := basicRes.GetDal()
 	// first, register the instance
-	errors.Must(db.AutoMigrate(&models.LockingHistory{}))
-	hostName := errors.Must1(os.Hostname())
+	err := db.AutoMigrate(&models.LockingHistory{})
+	if err != nil {
+		return err
+	}
+	hostName, e := os.Hostname()
+	if e != nil {
+		return errors.Convert(e)
+	}
 	lockingHistory := &models.LockingHistory{
 		HostName: hostName,
 		Version:  version.Version,
 	}
-	errors.Must(db.Create(lockingHistory))
+	err = db.Create(lockingHistory)
+	if err != nil {
+		return err
+	}
 	// 2. obtain the lock: using a never released transaction
 	// This prevents multiple devlake instances from sharing the same database by locking the migration history table
 	// However, it would not work if any older devlake instances were already using the database.
 	lockingTx = db.Begin()
-	c := make(chan bool, 1)
+	c := make(chan errors.Error, 1)
 	go func() {
-		errors.Must(lockingTx.DropTables(models.LockingStub{}.TableName()))
-		errors.Must(lockingTx.AutoMigrate(&models.LockingStub{}))
-		errors.Must(lockingTx.LockTables(dal.LockTables{{Table: "_devlake_locking_stub", Exclusive: true}}))
-		lockingHistory.Succeeded = true
-		errors.Must(db.Update(lockingHistory))
-		c <- true
+		err := lockingTx.DropTables(models.LockingStub{}.TableName())
+		if err == nil {
+			err = lockingTx.AutoMigrate(&models.LockingStub{})
+		}
+		if err == nil {
+			err = lockingTx.LockTables(dal.LockTables{{Table: "_devlake_locking_stub", Exclusive: true}})
+		}
+		c <- err
 	}()
 
 	// 3. update the record
 	select {
-	case <-c:
+	case err = <-c:
+		if err != nil {
+			return err
+		}
 	case <-time.After(10 * time.Second):
-		panic(fmt.Errorf("locking _devlake_locking_stub timeout, the database might be locked by another devlake instance"))
+		return errors.Default.New("locking _devlake_locking_stub timeout, the database might be locked by another devlake instance")
+	}
+	lockingHistory.Succeeded = true
+	return db.Update(lockingHistory)
+}
+
+// LockMigration waits for the devlake instance or the `lake migrate` command migrating the database, and keeps the
+// others from migrating it until the returned function is called. Unlike lockDatabase, it is only held while
+// migrating, so the database can be migrated while a devlake instance is serving it.
+func LockMigration() (func(), errors.Error) {
+	db := basicRes.GetDal()
+	err := db.AutoMigrate(&models.MigrationLockingStub{})
+	if err != nil {
+		return nil, err
+	}
+	tx := db.Begin()
+	err = tx.LockTables(dal.LockTables{{Table: models.MigrationLockingStub{}.TableName(), Exclusive: true}})
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			logger.Error(e, "transaction Rollback")
+		}
+		return nil, err
+	}
+	return func() {
+		if err := tx.UnlockTables(); err != nil {
+			logger.Error(err, "failed to unlock %s", models.MigrationLockingStub{}.TableName())
+		}
+		if err := tx.Rollback(); err != nil {
+			logger.Error(err, "transaction Rollback")
+		}
+	}, nil
+}
+
+// MigrateDatabase applies the pending migration scripts while holding the migration lock
+func MigrateDatabase() errors.Error {
+	unlock, err := LockMigration()
+	if err != nil {
+		return err
 	}
+	defer unlock()
+	return migrator.Execute()
 }
@@ -24,7 +24,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/spf13/cast"
@@ -42,6 +41,7 @@ import (
 
 var notificationService *NotificationService
 var globalPipelineLog = logruslog.Global.Nested("pipeline service")
+var pipelineQueueLimits pipelineLimits
 var pluginOptionSanitizers = map[string]func(map[string]interface{}){
 	"gitextractor": func(options map[string]interface{}) {
 		if v, ok := options["url"]; ok {
@@ -96,6 +96,13 @@ func pipelineServiceInit() {
 		globalPipelineLog.Warn(nil, `pipelineMaxParallel=0 means pipeline will be run No Limit`)
 		pipelineMaxParallel = 10000
 	}
+	pipelineQueueLimits = pipelineLimits{
+		perBlueprint:  cfg.GetInt("PIPELINE_MAX_PARALLEL_PER_BLUEPRINT"),
+		perConnection: cfg.GetInt("PIPELINE_MAX_PARALLEL_PER_CONNECTION"),
+	}
+	if pipelineQueueLimits.perBlueprint < 0 || pipelineQueueLimits.perConnection < 0 {
+		panic(errors.BadInput.New(`PIPELINE_MAX_PARALLEL_PER_BLUEPRINT and PIPELINE_MAX_PARALLEL_PER_CONNECTION should not be negative`))
+	}
 	// run pipeline with independent goroutine
 	go RunPipelineInQueue(pipelineMaxParallel)
 }
@@ -223,7 +230,7 @@ func GetPipelineLogsArchivePath(pipeline *models.Pipeline) (string, errors.Error
 	return archive, err
 }
 
-func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline, err errors.Error) {
+func dequeuePipeline(running *runningPipelines) (pipeline *models.Pipeline, err errors.Error) {
 	txHelper := dbhelper.NewTxHelper(basicRes, &err)
 	defer txHelper.End()
 	tx := txHelper.Begin()
@@ -232,57 +239,61 @@ func dequeuePipeline(runningParallelLabels []string) (pipeline *models.Pipeline,
 		{Table: "_devlake_pipelines", Exclusive: false},
 		{Table: "_devlake_pipeline_labels", Exclusive: false},
 	}))
-	// prepare query to find an appropriate pipeline to execute
-	pipeline = &models.Pipeline{}
-	err = tx.First(pipeline,
-		dal.Where("status IN ?", []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME}),
+	// prepare query to find the waiting pipelines not blocked by the running parallel labels, by priority
+	var candidates []*models.Pipeline
+	err = tx.All(&candidates,
+		dal.Select("_devlake_pipelines.*"),
+		dal.From(&models.Pipeline{}),
+		dal.Where("status IN ?", models.WaitingTaskStatus),
 		dal.Join(
 			`left join _devlake_pipeline_labels ON
 				_devlake_pipeline_labels.pipeline_id = _devlake_pipelines.id AND
 				_devlake_pipeline_labels.name LIKE 'parallel/%' AND
 				_devlake_pipeline_labels.name in ?`,
-			runningParallelLabels,
+			running.labels(),
 		),
-		dal.Groupby("id"),
+		// grouped by the primary key, so the other columns of the pipelines could be selected as well
+		dal.Groupby("id, priority"),
 		dal.Having("count(_devlake_pipeline_labels.name)=0"),
-		dal.Select("id"),
-		dal.Orderby("id ASC"),
-		dal.Limit(1),
+		dal.Orderby("priority DESC, id ASC"),
 	)
-	if err == nil {
-		// mark the pipeline running, now we want a write lock
-		if pipeline.BeganAt == nil {
-			now := time.Now()
-			pipeline.BeganAt = &now
-			globalPipelineLog.Info("resumed pipeline #%d", pipeline.ID)
-		}
-		errors.Must(tx.LockTables(dal.LockTables{{Table: "_devlake_pipelines", Exclusive: true}}))
-		err = tx.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
-			{ColumnName: "status", Value: models.TASK_RUNNING},
-			{ColumnName: "message", Value: ""},
-			{ColumnName: "began_at", Value: pipeline.BeganAt},
-		}, dal.Where("id = ?", pipeline.ID))
-		if err != nil {
-			panic(err)
-		}
-		return
-	}
-	if tx.IsErrorNotFound(err) {
-		pipeline = nil
-		err = nil
-	} else {
+	if err != nil {
 		// log unexpected err
 		globalPipelineLog.Error(err, "dequeue failed")
+		return nil, err
+	}
+	// pick the first one which wouldn't exceed the per blueprint / per connection limits
+	for _, candidate := range candidates {
+		if running.accepts(candidate) {
+			pipeline = candidate
+			break
+		}
+	}
+	if pipeline == nil {
+		return nil, nil
+	}
+	// mark the pipeline running, now we want a write lock
+	if pipeline.BeganAt == nil {
+		now := time.Now()
+		pipeline.BeganAt = &now
+		globalPipelineLog.Info("resumed pipeline #%d", pipeline.ID)
+	}
+	errors.Must(tx.LockTables(dal.LockTables{{Table: "_devlake_pipelines", Exclusive: true}}))
+	err = tx.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+		{ColumnName: "status", Value: models.TASK_RUNNING},
+		{ColumnName: "message", Value: ""},
+		{ColumnName: "began_at", Value: pipeline.BeganAt},
+	}, dal.Where("id = ?", pipeline.ID))
+	if err != nil {
+		panic(err)
 	}
-
 	return
 }
 
 // RunPipelineInQueue query pipeline from db and run it in a queue
 func RunPipelineInQueue(pipelineMaxParallel int64) {
 	sema := semaphore.NewWeighted(pipelineMaxParallel)
-	runningParallelLabels := []string{}
-	var runningParallelLabelLock sync.Mutex
+	running := newRunningPipelines(pipelineQueueLimits)
 	var err error
 	for {
 		// start goroutine when sema lock ready and pipeline exist.
@@ -291,7 +302,7 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		var dbPipeline *models.Pipeline
 		for {
-			dbPipeline, err = dequeuePipeline(runningParallelLabels)
+			dbPipeline, err = dequeuePipeline(running)
 			if err == nil && dbPipeline != nil {
 				break
 			}
@@ -302,31 +313,20 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		if err != nil {
 			panic(err)
 		}
-		// add pipelineParallelLabels to runningParallelLabels
-		var pipelineParallelLabels []string
-		for _, dbLabel := range dbPipeline.Labels {
-			if strings.HasPrefix(dbLabel, `parallel/`) {
-				pipelineParallelLabels = append(pipelineParallelLabels, dbLabel)
-			}
-		}
-		runningParallelLabelLock.Lock()
-		runningParallelLabels = append(runningParallelLabels, pipelineParallelLabels...)
-		runningParallelLabelLock.Unlock()
+		running.add(dbPipeline)
 
-		go func(pipelineId uint64, parallelLabels []string) {
+		go func(dbPipeline *models.Pipeline) {
 			defer sema.Release(1)
 			defer func() {
-				runningParallelLabelLock.Lock()
-				runningParallelLabels = utils.SliceRemove(runningParallelLabels, parallelLabels...)
-				runningParallelLabelLock.Unlock()
-				globalPipelineLog.Info("finish pipeline #%d, now runningParallelLabels is %s", pipelineId, runningParallelLabels)
+				running.remove(dbPipeline)
+				globalPipelineLog.Info("finish pipeline #%d, now running %s", dbPipeline.ID, running)
 			}()
-			globalPipelineLog.Info("run pipeline, %d, now running runningParallelLabels are %s", pipelineId, runningParallelLabels)
-			err = runPipeline(pipelineId)
+			globalPipelineLog.Info("run pipeline, %d, now running %s", dbPipeline.ID, running)
+			err = runPipeline(dbPipeline.ID)
 			if err != nil {
-				globalPipelineLog.Error(err, "failed to run pipeline %d", pipelineId)
+				globalPipelineLog.Error(err, "failed to run pipeline %d", dbPipeline.ID)
 			}
-		}(dbPipeline.ID, pipelineParallelLabels)
+		}(dbPipeline)
 	}
 }
 
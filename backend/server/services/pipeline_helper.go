@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/dbhelper"
 )
 
@@ -47,11 +48,11 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (pipeline *models.Pipelin
 	if newPipeline.BlueprintId > 0 {
 		count := errors.Must1(tx.Count(
 			dal.From(&models.Pipeline{}),
-			dal.Where("blueprint_id = ? AND status IN ?", newPipeline.BlueprintId, models.PendingTaskStatus),
+			dal.Where("blueprint_id = ? AND status IN ?", newPipeline.BlueprintId, models.WaitingTaskStatus),
 		))
-		// some pipeline is ruunning , get the detail and output them.
+		// a running pipeline is fine since the new one would wait in the queue, but no need to queue up twice
 		if count > 0 {
-			return nil, errors.BadInput.New("there are pending pipelines of current blueprint already")
+			return nil, errors.BadInput.New("there are waiting pipelines of current blueprint already")
 		}
 	}
 	// create pipeline object from posted data
@@ -62,6 +63,7 @@ func CreateDbPipeline(newPipeline *models.NewPipeline) (pipeline *models.Pipelin
 		Message:       "",
 		SpentSeconds:  0,
 		Plan:          newPipeline.Plan,
+		Priority:      newPipeline.Priority,
 		SyncPolicy:    newPipeline.SyncPolicy,
 	}
 	if newPipeline.BlueprintId != 0 {
@@ -148,11 +150,15 @@ func GetDbPipelines(query *PipelineQuery) ([]*models.Pipeline, int64, errors.Err
 
 	// load labels for blueprints
 	for _, dbPipeline := range dbPipelines {
-		err = fillPipelineDetail(dbPipeline)
+		err = fillPipelineLabels(dbPipeline)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
+	err = fillQueuePositions(basicRes.GetDal(), dbPipelines)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	return dbPipelines, count, nil
 }
@@ -175,9 +181,58 @@ func GetDbPipeline(pipelineId uint64) (*models.Pipeline, errors.Error) {
 }
 
 func fillPipelineDetail(pipeline *models.Pipeline) errors.Error {
+	err := fillPipelineLabels(pipeline)
+	if err != nil {
+		return err
+	}
+	return fillQueuePositions(basicRes.GetDal(), []*models.Pipeline{pipeline})
+}
+
+func fillPipelineLabels(pipeline *models.Pipeline) errors.Error {
 	err := basicRes.GetDal().Pluck("name", &pipeline.Labels, dal.From(&models.DbPipelineLabel{}), dal.Where("pipeline_id = ?", pipeline.ID))
 	if err != nil {
 		return errors.Internal.Wrap(err, "error getting the pipeline labels from database")
 	}
 	return nil
 }
+
+// fillQueuePositions sets the queue positions of the waiting pipelines by loading the ids of the waiting pipelines
+// up to the last one of them in the queue
+func fillQueuePositions(db dal.Dal, pipelines []*models.Pipeline) errors.Error {
+	var last *models.Pipeline
+	for _, pipeline := range pipelines {
+		pipeline.QueuePosition = 0
+		if !utils.StringsContains(models.WaitingTaskStatus, pipeline.Status) {
+			continue
+		}
+		// waiting pipelines are dequeued by priority first, then by id
+		if last == nil || pipeline.Priority < last.Priority || (pipeline.Priority == last.Priority && pipeline.ID > last.ID) {
+			last = pipeline
+		}
+	}
+	if last == nil {
+		return nil
+	}
+	var queuedIds []uint64
+	err := db.Pluck("id", &queuedIds,
+		dal.From(&models.Pipeline{}),
+		dal.Where(
+			"status IN ? AND (priority > ? OR (priority = ? AND id <= ?))",
+			models.WaitingTaskStatus, last.Priority, last.Priority, last.ID,
+		),
+		dal.Orderby("priority DESC, id ASC"),
+	)
+	if err != nil {
+		return errors.Internal.Wrap(err, "error getting the pipeline queue positions from database")
+	}
+	positions := make(map[uint64]int, len(queuedIds))
+	for i, id := range queuedIds {
+		positions[id] = i + 1
+	}
+	for _, pipeline := range pipelines {
+		if utils.StringsContains(models.WaitingTaskStatus, pipeline.Status) {
+			pipeline.QueuePosition = positions[pipeline.ID]
+		}
+	}
+	return nil
+}
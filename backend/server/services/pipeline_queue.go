@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/spf13/cast"
+)
+
+// PipelinePriorityJumpQueue is the priority given to manually triggered pipelines that should run before the scheduled ones
+const PipelinePriorityJumpQueue = 100
+
+// pipelineLimits holds the concurrency limits beyond the global PIPELINE_MAX_PARALLEL, 0 means no limit
+type pipelineLimits struct {
+	perBlueprint  int
+	perConnection int
+}
+
+// runningPipelines keeps track of the resources held by the running pipelines
+type runningPipelines struct {
+	sync.Mutex
+	limits         pipelineLimits
+	parallelLabels []string
+	blueprints     map[uint64]int
+	connections    map[string]int
+}
+
+func newRunningPipelines(limits pipelineLimits) *runningPipelines {
+	return &runningPipelines{
+		limits:      limits,
+		blueprints:  make(map[uint64]int),
+		connections: make(map[string]int),
+	}
+}
+
+// labels returns a snapshot of the parallel labels held by the running pipelines
+func (r *runningPipelines) labels() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string{}, r.parallelLabels...)
+}
+
+// accepts tells whether the pipeline could be started without exceeding the per blueprint or per connection limits
+func (r *runningPipelines) accepts(pipeline *models.Pipeline) bool {
+	r.Lock()
+	defer r.Unlock()
+	if pipeline.BlueprintId > 0 && r.limits.perBlueprint > 0 && r.blueprints[pipeline.BlueprintId] >= r.limits.perBlueprint {
+		return false
+	}
+	if r.limits.perConnection > 0 {
+		for _, key := range pipelineConnectionKeys(pipeline.Plan) {
+			if r.connections[key] >= r.limits.perConnection {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// add records the resources held by the pipeline
+func (r *runningPipelines) add(pipeline *models.Pipeline) {
+	r.Lock()
+	defer r.Unlock()
+	r.parallelLabels = append(r.parallelLabels, pipelineParallelLabels(pipeline)...)
+	if pipeline.BlueprintId > 0 {
+		r.blueprints[pipeline.BlueprintId]++
+	}
+	for _, key := range pipelineConnectionKeys(pipeline.Plan) {
+		r.connections[key]++
+	}
+}
+
+// remove releases the resources held by the pipeline
+func (r *runningPipelines) remove(pipeline *models.Pipeline) {
+	r.Lock()
+	defer r.Unlock()
+	r.parallelLabels = utils.SliceRemove(r.parallelLabels, pipelineParallelLabels(pipeline)...)
+	if pipeline.BlueprintId > 0 {
+		if r.blueprints[pipeline.BlueprintId]--; r.blueprints[pipeline.BlueprintId] <= 0 {
+			delete(r.blueprints, pipeline.BlueprintId)
+		}
+	}
+	for _, key := range pipelineConnectionKeys(pipeline.Plan) {
+		if r.connections[key]--; r.connections[key] <= 0 {
+			delete(r.connections, key)
+		}
+	}
+}
+
+func (r *runningPipelines) String() string {
+	r.Lock()
+	defer r.Unlock()
+	return fmt.Sprintf("parallel labels: %v, blueprints: %v, connections: %v", r.parallelLabels, r.blueprints, r.connections)
+}
+
+func pipelineParallelLabels(pipeline *models.Pipeline) []string {
+	var labels []string
+	for _, label := range pipeline.Labels {
+		if strings.HasPrefix(label, `parallel/`) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// pipelineConnectionKeys returns the distinct "plugin:connectionId" keys of the connections used by the plan
+func pipelineConnectionKeys(plan models.PipelinePlan) []string {
+	keySet := make(map[string]struct{})
+	for _, stage := range plan {
+		for _, task := range stage {
+			if task == nil || task.Options == nil {
+				continue
+			}
+			connectionId := cast.ToUint64(task.Options["connectionId"])
+			if connectionId == 0 {
+				continue
+			}
+			keySet[fmt.Sprintf("%s:%d", task.Plugin, connectionId)] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
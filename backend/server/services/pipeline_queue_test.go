@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPipelineConnectionKeys(t *testing.T) {
+	plan := models.PipelinePlan{
+		{
+			{Plugin: "github", Options: map[string]interface{}{"connectionId": 1, "name": "foo"}},
+			{Plugin: "github", Options: map[string]interface{}{"connectionId": 1, "name": "bar"}},
+			{Plugin: "gitlab", Options: map[string]interface{}{"connectionId": float64(1)}},
+		},
+		{
+			{Plugin: "gitextractor", Options: map[string]interface{}{"url": "https://example.com"}},
+			{Plugin: "dora"},
+		},
+	}
+	assert.Equal(t, []string{"github:1", "gitlab:1"}, pipelineConnectionKeys(plan))
+}
+
+func TestRunningPipelinesAccepts(t *testing.T) {
+	github1 := models.PipelinePlan{{{Plugin: "github", Options: map[string]interface{}{"connectionId": 1}}}}
+	github2 := models.PipelinePlan{{{Plugin: "github", Options: map[string]interface{}{"connectionId": 2}}}}
+	running := newRunningPipelines(pipelineLimits{perBlueprint: 1, perConnection: 2})
+
+	first := &models.Pipeline{BlueprintId: 1, Plan: github1, Labels: []string{"parallel/a", "foo"}}
+	running.add(first)
+	assert.Equal(t, []string{"parallel/a"}, running.labels())
+	// the blueprint is running already
+	assert.False(t, running.accepts(&models.Pipeline{BlueprintId: 1, Plan: github2}))
+	assert.True(t, running.accepts(&models.Pipeline{BlueprintId: 2, Plan: github1}))
+
+	running.add(&models.Pipeline{BlueprintId: 2, Plan: github1})
+	// the connection is used by 2 pipelines already
+	assert.False(t, running.accepts(&models.Pipeline{BlueprintId: 3, Plan: github1}))
+	assert.True(t, running.accepts(&models.Pipeline{BlueprintId: 3, Plan: github2}))
+	assert.True(t, running.accepts(&models.Pipeline{Plan: github2}))
+
+	running.remove(first)
+	assert.Empty(t, running.labels())
+	assert.True(t, running.accepts(&models.Pipeline{BlueprintId: 1, Plan: github1}))
+}
+
+func TestRunningPipelinesNoLimit(t *testing.T) {
+	plan := models.PipelinePlan{{{Plugin: "github", Options: map[string]interface{}{"connectionId": 1}}}}
+	running := newRunningPipelines(pipelineLimits{})
+	for i := 0; i < 3; i++ {
+		running.add(&models.Pipeline{BlueprintId: 1, Plan: plan})
+	}
+	assert.True(t, running.accepts(&models.Pipeline{BlueprintId: 1, Plan: plan}))
+}
+
+func TestFillQueuePositions(t *testing.T) {
+	running := &models.Pipeline{Model: common.Model{ID: 9}, Status: models.TASK_RUNNING}
+	low := &models.Pipeline{Model: common.Model{ID: 8}, Status: models.TASK_CREATED}
+	high := &models.Pipeline{Model: common.Model{ID: 7}, Status: models.TASK_RERUN, Priority: 5}
+
+	mockDal := mockdal.NewDal(t)
+	// the waiting pipelines are loaded once, up to the last one of the page in the queue
+	mockDal.On("Pluck", "id", mock.Anything, mock.MatchedBy(func(clauses []dal.Clause) bool {
+		params := clauses[1].Data.(dal.DalClause).Params
+		return params[1] == 0 && params[3] == uint64(8)
+	})).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]uint64) = []uint64{7, 3, 8}
+	}).Return(nil).Once()
+
+	assert.Nil(t, fillQueuePositions(mockDal, []*models.Pipeline{running, low, high}))
+	assert.Equal(t, 0, running.QueuePosition)
+	assert.Equal(t, 1, high.QueuePosition)
+	assert.Equal(t, 3, low.QueuePosition)
+
+	// no query is needed if none of the pipelines is waiting
+	assert.Nil(t, fillQueuePositions(mockDal, []*models.Pipeline{running}))
+}
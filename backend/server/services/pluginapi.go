@@ -18,6 +18,10 @@ limitations under the License.
 package services
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 )
@@ -43,3 +47,28 @@ func GetPluginsApiResources() (map[string]map[string]map[string]plugin.ApiResour
 	}
 	return res, nil
 }
+
+// TestPluginConnection tests an existing connection by calling the `connections/:connectionId/test`
+// endpoint of the plugin, exactly like `POST /plugins/:plugin/connections/:connectionId/test` does
+func TestPluginConnection(pluginName string, connectionId uint64) (*plugin.ApiResourceOutput, errors.Error) {
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, errors.NotFound.Wrap(err, "plugin not found")
+	}
+	pluginApi, ok := pluginMeta.(plugin.PluginApi)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s doesn't provide any API", pluginName))
+	}
+	handler, ok := pluginApi.ApiResources()["connections/:connectionId/test"][http.MethodPost]
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s doesn't support testing existing connections", pluginName))
+	}
+	return handler(&plugin.ApiResourceInput{
+		Params: map[string]string{
+			"plugin":       pluginName,
+			"connectionId": fmt.Sprintf("%d", connectionId),
+		},
+		Query: url.Values{},
+		Body:  map[string]interface{}{},
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
 )
 
 // Ready returns the readiness status of the service
@@ -56,3 +57,40 @@ func Health() (string, errors.Error) {
 		return "good", nil
 	}
 }
+
+// DatabaseCheckResult is the outcome of CheckDatabase
+type DatabaseCheckResult struct {
+	Dialect           string   `json:"dialect"`
+	Health            string   `json:"health"`
+	PendingMigrations int      `json:"pendingMigrations"`
+	MissingTables     []string `json:"missingTables"`
+}
+
+// CheckDatabase verifies that the database is reachable, fully migrated and
+// contains the tables of every loaded plugin
+func CheckDatabase() (*DatabaseCheckResult, errors.Error) {
+	result := &DatabaseCheckResult{
+		Dialect:           db.Dialect(),
+		PendingMigrations: len(migrator.PendingScripts()),
+		MissingTables:     make([]string, 0),
+	}
+	// the service status is irrelevant here, read from the pipelines table directly
+	err := db.All(&[]models.Pipeline{}, dal.Limit(1))
+	if err != nil {
+		result.Health = "bad"
+		return result, errors.Default.Wrap(err, "error reading from pipelines")
+	}
+	result.Health = "good"
+	for _, pluginMeta := range plugin.AllPlugins() {
+		pluginModel, ok := pluginMeta.(plugin.PluginModel)
+		if !ok {
+			continue
+		}
+		for _, table := range pluginModel.GetTablesInfo() {
+			if !db.HasTable(table.TableName()) {
+				result.MissingTables = append(result.MissingTables, table.TableName())
+			}
+		}
+	}
+	return result, nil
+}
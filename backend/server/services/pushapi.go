@@ -18,15 +18,305 @@ limitations under the License.
 package services
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
 )
 
-// InsertRow FIXME ...
-func InsertRow(table string, rows []map[string]interface{}) (int64, errors.Error) {
-	err := db.Create(rows, dal.From(table))
+// PushBatchSize is the number of rows written to the database at once
+const PushBatchSize = 500
+
+var pushTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// PushRowError describes why a pushed row was rejected
+type PushRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// PushResult is the outcome of pushing rows into a domain table
+type PushResult struct {
+	RowsAffected int64           `json:"rowsAffected"`
+	Errors       []*PushRowError `json:"errors"`
+}
+
+type pushRow struct {
+	index  int
+	values map[string]interface{}
+}
+
+// PushWriter validates rows against the schema of a domain table and writes them in batches
+type PushWriter struct {
+	table     dal.Tabler
+	columns   map[string]dal.ColumnMeta
+	pkColumns []string
+	upsert    bool
+	batch     []*pushRow
+	result    *PushResult
+}
+
+// NewPushWriter returns a PushWriter for the domain table `tableName`, rows with existing
+// primary keys are updated when `upsert` is true, otherwise they are rejected by the database
+func NewPushWriter(tableName string, upsert bool) (*PushWriter, errors.Error) {
+	var table dal.Tabler
+	for _, t := range domaininfo.GetDomainTablesInfo() {
+		if t.TableName() == tableName {
+			table = t
+			break
+		}
+	}
+	if table == nil {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s is not a domain layer table", tableName))
+	}
+	columnMetas, err := db.GetColumns(table, nil)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error getting columns of %s", tableName))
+	}
+	w := &PushWriter{
+		table:   table,
+		columns: make(map[string]dal.ColumnMeta, len(columnMetas)),
+		upsert:  upsert,
+		result:  &PushResult{Errors: make([]*PushRowError, 0)},
+	}
+	for _, columnMeta := range columnMetas {
+		w.columns[columnMeta.Name()] = columnMeta
+		if isPk, ok := columnMeta.PrimaryKey(); ok && isPk {
+			w.pkColumns = append(w.pkColumns, columnMeta.Name())
+		}
+	}
+	return w, nil
+}
+
+// Write validates the row and queues it for writing, `index` is used to report errors
+func (w *PushWriter) Write(index int, row map[string]interface{}) errors.Error {
+	values, err := w.validate(row)
 	if err != nil {
-		return 0, err
+		w.reject(index, err)
+		return nil
+	}
+	w.batch = append(w.batch, &pushRow{index: index, values: values})
+	if len(w.batch) >= PushBatchSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Reject records an error for a row that could not be parsed
+func (w *PushWriter) Reject(index int, err error) {
+	w.reject(index, err)
+}
+
+// Flush writes the queued rows into the database
+func (w *PushWriter) Flush() errors.Error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+	// rows with different columns can not be inserted by the same statement
+	groups := make(map[string][]*pushRow)
+	signatures := make([]string, 0)
+	for _, row := range w.batch {
+		columns := make([]string, 0, len(row.values))
+		for column := range row.values {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		signature := strings.Join(columns, ",")
+		if _, ok := groups[signature]; !ok {
+			signatures = append(signatures, signature)
+		}
+		groups[signature] = append(groups[signature], row)
+	}
+	w.batch = nil
+	for _, signature := range signatures {
+		rows := groups[signature]
+		if err := w.save(rows); err == nil {
+			w.result.RowsAffected += int64(len(rows))
+			continue
+		}
+		// save them one by one to find out the bad ones
+		for _, row := range rows {
+			if err := w.save([]*pushRow{row}); err != nil {
+				w.reject(row.index, err)
+			} else {
+				w.result.RowsAffected++
+			}
+		}
+	}
+	return nil
+}
+
+// Close flushes the remaining rows and returns the result
+func (w *PushWriter) Close() (*PushResult, errors.Error) {
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return w.result, nil
+}
+
+func (w *PushWriter) save(rows []*pushRow) errors.Error {
+	values := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, row.values)
+	}
+	if !w.upsert {
+		return db.Create(&values, dal.From(w.table))
+	}
+	// rows of the same batch have the same columns, the ones without created_at get it only when inserted
+	if _, ok := w.columns["created_at"]; ok {
+		if _, ok := values[0]["created_at"]; !ok {
+			now := time.Now()
+			inserts := make([]map[string]interface{}, 0, len(values))
+			for _, value := range values {
+				insert := make(map[string]interface{}, len(value)+1)
+				for column, v := range value {
+					insert[column] = v
+				}
+				insert["created_at"] = now
+				inserts = append(inserts, insert)
+			}
+			if err := db.CreateIfNotExist(&inserts, dal.From(w.table)); err != nil {
+				return err
+			}
+		}
+	}
+	return db.CreateOrUpdate(&values, dal.From(w.table))
+}
+
+func (w *PushWriter) reject(index int, err error) {
+	w.result.Errors = append(w.result.Errors, &PushRowError{Row: index, Message: err.Error()})
+}
+
+func (w *PushWriter) validate(row map[string]interface{}) (map[string]interface{}, errors.Error) {
+	values := make(map[string]interface{}, len(row)+2)
+	for name, value := range row {
+		column, ok := w.columns[name]
+		if !ok {
+			return nil, errors.BadInput.New(fmt.Sprintf("unknown column %s", name))
+		}
+		v, err := convertPushValue(column, value)
+		if err != nil {
+			return nil, err
+		}
+		values[name] = v
+	}
+	for _, pk := range w.pkColumns {
+		if values[pk] == nil {
+			if isAutoIncrement, ok := w.columns[pk].AutoIncrement(); ok && isAutoIncrement {
+				continue
+			}
+			return nil, errors.BadInput.New(fmt.Sprintf("primary key %s is required", pk))
+		}
+	}
+	// the created_at of the existing rows is kept in the upsert mode, see save
+	now := time.Now()
+	for _, column := range []string{"created_at", "updated_at"} {
+		if _, ok := w.columns[column]; ok && values[column] == nil && (column != "created_at" || !w.upsert) {
+			values[column] = now
+		}
+	}
+	return values, nil
+}
+
+// convertPushValue checks the JSON value against the column type and converts it to what the database driver accepts
+func convertPushValue(column dal.ColumnMeta, value interface{}) (interface{}, errors.Error) {
+	if value == nil {
+		if nullable, ok := column.Nullable(); ok && !nullable {
+			return nil, errors.BadInput.New(fmt.Sprintf("column %s must not be null", column.Name()))
+		}
+		return nil, nil
+	}
+	mismatch := func() errors.Error {
+		return errors.BadInput.New(fmt.Sprintf("column %s expects a %s value, got %v", column.Name(), strings.ToLower(column.DatabaseTypeName()), value))
+	}
+	switch pushColumnKind(column.DatabaseTypeName()) {
+	case "int":
+		switch v := value.(type) {
+		case json.Number:
+			i, err := v.Int64()
+			if err != nil {
+				return nil, mismatch()
+			}
+			return i, nil
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, mismatch()
+			}
+			return int64(v), nil
+		case bool:
+			return v, nil
+		}
+		return nil, mismatch()
+	case "float":
+		switch v := value.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return nil, mismatch()
+			}
+			return f, nil
+		case float64:
+			return v, nil
+		}
+		return nil, mismatch()
+	case "bool":
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+		return nil, mismatch()
+	case "time":
+		s, ok := value.(string)
+		if !ok {
+			return nil, mismatch()
+		}
+		for _, layout := range pushTimeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, mismatch()
+	case "json":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		blob, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("column %s", column.Name()))
+		}
+		return string(blob), nil
+	default:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return nil, mismatch()
+	}
+}
+
+func pushColumnKind(databaseTypeName string) string {
+	t := strings.ToUpper(databaseTypeName)
+	switch {
+	case t == "BOOL" || t == "BOOLEAN":
+		return "bool"
+	case strings.Contains(t, "INT"):
+		return "int"
+	case strings.Contains(t, "FLOAT") || strings.Contains(t, "DOUBLE") || strings.Contains(t, "DECIMAL") ||
+		strings.Contains(t, "NUMERIC") || t == "REAL":
+		return "float"
+	case strings.Contains(t, "DATE") || strings.Contains(t, "TIME"):
+		return "time"
+	case strings.Contains(t, "JSON"):
+		return "json"
 	}
-	return 1, nil
+	return "string"
 }
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockColumn(name, databaseTypeName string, nullable bool) *mockdal.ColumnMeta {
+	column := new(mockdal.ColumnMeta)
+	column.On("Name").Return(name)
+	column.On("DatabaseTypeName").Return(databaseTypeName)
+	column.On("Nullable").Return(nullable, true)
+	return column
+}
+
+func TestConvertPushValue(t *testing.T) {
+	v, err := convertPushValue(mockColumn("additions", "INT", false), json.Number("42"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), v)
+
+	_, err = convertPushValue(mockColumn("additions", "INT", false), json.Number("4.2"))
+	assert.NotNil(t, err)
+
+	_, err = convertPushValue(mockColumn("additions", "INT", false), nil)
+	assert.NotNil(t, err)
+
+	v, err = convertPushValue(mockColumn("story_point", "DOUBLE", true), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	v, err = convertPushValue(mockColumn("story_point", "DOUBLE", true), json.Number("4.5"))
+	assert.Nil(t, err)
+	assert.Equal(t, 4.5, v)
+
+	v, err = convertPushValue(mockColumn("created_date", "DATETIME", true), "2024-03-01T08:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), v)
+
+	_, err = convertPushValue(mockColumn("created_date", "DATETIME", true), "yesterday")
+	assert.NotNil(t, err)
+
+	v, err = convertPushValue(mockColumn("title", "VARCHAR", true), "hello")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", v)
+
+	_, err = convertPushValue(mockColumn("title", "LONGTEXT", true), json.Number("1"))
+	assert.NotNil(t, err)
+
+	v, err = convertPushValue(mockColumn("input", "JSON", true), map[string]interface{}{"a": json.Number("1")})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1}`, v)
+}
+
+func TestPushWriterUpsertKeepsCreatedAt(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	var inserted, upserted []map[string]interface{}
+	mockDal.On("CreateIfNotExist", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		inserted = *args.Get(0).(*[]map[string]interface{})
+	}).Return(nil).Once()
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		upserted = *args.Get(0).(*[]map[string]interface{})
+	}).Return(nil).Once()
+	origin := db
+	db = mockDal
+	defer func() { db = origin }()
+
+	w := &PushWriter{
+		columns: map[string]dal.ColumnMeta{
+			"id":         mockColumn("id", "VARCHAR", false),
+			"title":      mockColumn("title", "VARCHAR", true),
+			"created_at": mockColumn("created_at", "DATETIME", true),
+			"updated_at": mockColumn("updated_at", "DATETIME", true),
+		},
+		pkColumns: []string{"id"},
+		upsert:    true,
+		result:    &PushResult{Errors: make([]*PushRowError, 0)},
+	}
+	assert.Nil(t, w.Write(0, map[string]interface{}{"id": "1", "title": "hello"}))
+	result, err := w.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	// new rows are inserted with created_at, existing rows are updated without touching it
+	assert.Len(t, inserted, 1)
+	assert.NotNil(t, inserted[0]["created_at"])
+	assert.Len(t, upserted, 1)
+	assert.NotContains(t, upserted[0], "created_at")
+	assert.NotNil(t, upserted[0]["updated_at"])
+	mockDal.AssertExpectations(t)
+}
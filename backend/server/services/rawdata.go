@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// RawDataPurgeQuery selects the raw data to be purged by PurgeRawData
+type RawDataPurgeQuery struct {
+	// Plugin limits the purge to the raw tables of the plugin, all raw tables are purged if empty
+	Plugin string
+	// Before limits the purge to records collected before the given time, all records are purged if nil
+	Before *time.Time
+	// DryRun counts the records to be purged without deleting them
+	DryRun bool
+}
+
+// PurgedRawTable records how many rows were (or would be) purged from a raw table
+type PurgedRawTable struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+// PurgeRawData deletes collected records from the raw layer tables.
+// Note that extractors can not be rerun with `skipCollectors` for the purged data afterward.
+func PurgeRawData(query *RawDataPurgeQuery) ([]*PurgedRawTable, errors.Error) {
+	tables, err := db.AllTables()
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error listing database tables")
+	}
+	var pluginNames []string
+	if query.Plugin != "" {
+		if _, err := plugin.GetPlugin(query.Plugin); err != nil {
+			return nil, errors.BadInput.Wrap(err, fmt.Sprintf("unknown plugin %s", query.Plugin))
+		}
+		for name := range plugin.AllPlugins() {
+			pluginNames = append(pluginNames, name)
+		}
+	}
+	result := make([]*PurgedRawTable, 0)
+	for _, table := range tables {
+		if !strings.HasPrefix(table, "_raw_") {
+			continue
+		}
+		if query.Plugin != "" && rawTablePlugin(table, pluginNames) != query.Plugin {
+			continue
+		}
+		clauses := []dal.Clause{dal.From(table)}
+		where := ""
+		var params []interface{}
+		if query.Before != nil {
+			where = " WHERE created_at < ?"
+			params = append(params, *query.Before)
+			clauses = append(clauses, dal.Where("created_at < ?", *query.Before))
+		}
+		count, err := db.Count(clauses...)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("error counting records of %s", table))
+		}
+		if count == 0 {
+			continue
+		}
+		if !query.DryRun {
+			logger.Info("purging %d records from %s", count, table)
+			err = db.Exec(fmt.Sprintf("DELETE FROM %s%s", table, where), params...)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, fmt.Sprintf("error purging records of %s", table))
+			}
+		}
+		result = append(result, &PurgedRawTable{Table: table, Rows: count})
+	}
+	return result, nil
+}
+
+// rawTablePlugin returns the plugin owning the raw table, which is the longest plugin name prefixing the table,
+// so that the `_raw_github_graphql_*` tables belong to github_graphql rather than github
+func rawTablePlugin(table string, pluginNames []string) string {
+	owner := ""
+	for _, name := range pluginNames {
+		if len(name) > len(owner) && strings.HasPrefix(table, "_raw_"+name+"_") {
+			owner = name
+		}
+	}
+	return owner
+}
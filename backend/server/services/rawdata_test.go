@@ -0,0 +1,33 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawTablePlugin(t *testing.T) {
+	plugins := []string{"github", "github_graphql", "gitlab", "jira"}
+	assert.Equal(t, "github", rawTablePlugin("_raw_github_api_issues", plugins))
+	assert.Equal(t, "github_graphql", rawTablePlugin("_raw_github_graphql_issues", plugins))
+	assert.Equal(t, "jira", rawTablePlugin("_raw_jira_api_issues", plugins))
+	assert.Equal(t, "", rawTablePlugin("_raw_jenkins_api_builds", plugins))
+	assert.Equal(t, "", rawTablePlugin("_raw_githubx_api_issues", plugins))
+}
@@ -0,0 +1,237 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+)
+
+var sourceWebhookLog = logruslog.Global.Nested("source webhook")
+var scopedRunDebouncer *debouncer
+
+// SourceWebhookResult tells what an incoming webhook would trigger
+type SourceWebhookResult struct {
+	Event        string   `json:"event"`
+	ScopeIds     []string `json:"scopeIds"`
+	BlueprintIds []uint64 `json:"blueprintIds"`
+}
+
+// scopedRunKey identifies the scoped pipeline to be triggered for a blueprint
+type scopedRunKey struct {
+	BlueprintId  uint64
+	PluginName   string
+	ConnectionId uint64
+}
+
+// debouncer coalesces the scopes of the webhooks received within the window into one run per key
+type debouncer struct {
+	sync.Mutex
+	window  time.Duration
+	pending map[scopedRunKey]map[string]bool
+	run     func(key scopedRunKey, scopeIds []string) errors.Error
+}
+
+func newDebouncer(window time.Duration, run func(key scopedRunKey, scopeIds []string) errors.Error) *debouncer {
+	return &debouncer{
+		window:  window,
+		pending: make(map[scopedRunKey]map[string]bool),
+		run:     run,
+	}
+}
+
+// schedule adds the scopes to the pending run of the key, the run starts once the window elapsed since the first scope
+func (d *debouncer) schedule(key scopedRunKey, scopeIds ...string) {
+	d.Lock()
+	defer d.Unlock()
+	scopes, ok := d.pending[key]
+	if !ok {
+		scopes = make(map[string]bool)
+		d.pending[key] = scopes
+		time.AfterFunc(d.window, func() { d.fire(key) })
+	}
+	for _, scopeId := range scopeIds {
+		scopes[scopeId] = true
+	}
+}
+
+func (d *debouncer) fire(key scopedRunKey) {
+	d.Lock()
+	scopes := d.pending[key]
+	delete(d.pending, key)
+	d.Unlock()
+	scopeIds := make([]string, 0, len(scopes))
+	for scopeId := range scopes {
+		scopeIds = append(scopeIds, scopeId)
+	}
+	sort.Strings(scopeIds)
+	if err := d.run(key, scopeIds); err != nil {
+		// most likely there is a pipeline of the blueprint waiting already, try again in the next window
+		sourceWebhookLog.Warn(err, "failed to run blueprint #%d for %s scopes %v, retry later", key.BlueprintId, key.PluginName, scopeIds)
+		d.schedule(key, scopeIds...)
+	}
+}
+
+func sourceWebhookServiceInit() {
+	window := 2 * time.Minute
+	if w := cfg.GetString("SOURCE_WEBHOOK_DEBOUNCE_WINDOW"); w != "" {
+		var err error
+		window, err = time.ParseDuration(w)
+		if err != nil {
+			panic(errors.BadInput.Wrap(err, "SOURCE_WEBHOOK_DEBOUNCE_WINDOW should be a duration like 2m"))
+		}
+	}
+	scopedRunDebouncer = newDebouncer(window, triggerBlueprintScopes)
+}
+
+func getSourceWebhookPlugin(pluginName string) (plugin.DataSourcePluginWebhook, errors.Error) {
+	pluginMeta, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, errors.NotFound.Wrap(err, "plugin not found")
+	}
+	webhookPlugin, ok := pluginMeta.(plugin.DataSourcePluginWebhook)
+	if !ok {
+		return nil, errors.BadInput.New("plugin does not support source webhooks")
+	}
+	return webhookPlugin, nil
+}
+
+// PutSourceWebhookSecret sets the secret to verify the webhooks of the connection, a random one is generated if empty
+func PutSourceWebhookSecret(pluginName string, connectionId uint64, secret string) (*models.SourceWebhook, errors.Error) {
+	if _, err := getSourceWebhookPlugin(pluginName); err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		var err errors.Error
+		secret, err = utils.RandLetterBytes(32)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sourceWebhook := &models.SourceWebhook{
+		PluginName:   pluginName,
+		ConnectionId: connectionId,
+		Secret:       secret,
+	}
+	err := db.CreateOrUpdate(sourceWebhook)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving the source webhook secret")
+	}
+	return sourceWebhook, nil
+}
+
+// DeleteSourceWebhookSecret removes the secret, webhooks of the connection would be rejected afterward
+func DeleteSourceWebhookSecret(pluginName string, connectionId uint64) errors.Error {
+	return db.Delete(&models.SourceWebhook{}, dal.Where("plugin_name = ? AND connection_id = ?", pluginName, connectionId))
+}
+
+// HandleSourceWebhook verifies the webhook sent by the data source, and schedules scoped pipelines for the
+// blueprints containing the affected scopes
+func HandleSourceWebhook(pluginName string, connectionId uint64, header http.Header, body []byte) (*SourceWebhookResult, errors.Error) {
+	webhookPlugin, err := getSourceWebhookPlugin(pluginName)
+	if err != nil {
+		return nil, err
+	}
+	sourceWebhook := &models.SourceWebhook{}
+	err = db.First(sourceWebhook, dal.Where("plugin_name = ? AND connection_id = ?", pluginName, connectionId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting the source webhook secret")
+	}
+	// the plugin would reject the request if the secret is not configured
+	event, err := webhookPlugin.ParseSourceWebhook(connectionId, sourceWebhook.Secret, header, body)
+	if err != nil {
+		return nil, err
+	}
+	result := &SourceWebhookResult{Event: event.Name, ScopeIds: event.ScopeIds, BlueprintIds: []uint64{}}
+	if len(event.ScopeIds) == 0 {
+		return result, nil
+	}
+	var blueprintScopes []*models.BlueprintScope
+	err = db.All(&blueprintScopes,
+		dal.Select("sc.*"),
+		dal.From("_devlake_blueprint_scopes sc"),
+		dal.Join("JOIN _devlake_blueprints bp ON bp.id = sc.blueprint_id"),
+		dal.Where(
+			"bp.enable = ? AND bp.mode = ? AND sc.plugin_name = ? AND sc.connection_id = ? AND sc.scope_id IN ?",
+			true, models.BLUEPRINT_MODE_NORMAL, pluginName, connectionId, event.ScopeIds,
+		),
+		dal.Orderby("sc.blueprint_id"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error getting the blueprints of the scopes")
+	}
+	for _, blueprintScope := range blueprintScopes {
+		key := scopedRunKey{BlueprintId: blueprintScope.BlueprintId, PluginName: pluginName, ConnectionId: connectionId}
+		scopedRunDebouncer.schedule(key, blueprintScope.ScopeId)
+		if n := len(result.BlueprintIds); n == 0 || result.BlueprintIds[n-1] != key.BlueprintId {
+			result.BlueprintIds = append(result.BlueprintIds, key.BlueprintId)
+		}
+	}
+	sourceWebhookLog.Info("received %s event %s for scopes %v, blueprints %v scheduled", pluginName, event.Name, event.ScopeIds, result.BlueprintIds)
+	return result, nil
+}
+
+// triggerBlueprintScopes creates an incremental pipeline of the blueprint for the given scopes only
+func triggerBlueprintScopes(key scopedRunKey, scopeIds []string) errors.Error {
+	blueprint, err := GetBlueprint(key.BlueprintId, false)
+	if err != nil {
+		if err.GetType() == errors.NotFound {
+			return nil
+		}
+		return err
+	}
+	if !blueprint.Enable {
+		return nil
+	}
+	scopes := make([]*models.BlueprintScope, 0, len(scopeIds))
+	for _, scopeId := range scopeIds {
+		scopes = append(scopes, &models.BlueprintScope{
+			BlueprintId:  key.BlueprintId,
+			PluginName:   key.PluginName,
+			ConnectionId: key.ConnectionId,
+			ScopeId:      scopeId,
+		})
+	}
+	blueprint.Connections = []*models.BlueprintConnection{
+		{
+			BlueprintId:  key.BlueprintId,
+			PluginName:   key.PluginName,
+			ConnectionId: key.ConnectionId,
+			Scopes:       scopes,
+		},
+	}
+	blueprint.SyncPolicy = models.SyncPolicy{SkipOnFail: blueprint.SkipOnFail}
+	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy, 0)
+	if err == ErrEmptyPlan {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sourceWebhookLog.Info("created pipeline #%d of blueprint #%d for %s scopes %v", pipeline.ID, key.BlueprintId, key.PluginName, scopeIds)
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebouncer(t *testing.T) {
+	var lock sync.Mutex
+	runs := make(map[scopedRunKey][][]string)
+	fails := 1
+	d := newDebouncer(50*time.Millisecond, func(key scopedRunKey, scopeIds []string) errors.Error {
+		lock.Lock()
+		defer lock.Unlock()
+		if key.BlueprintId == 2 && fails > 0 {
+			fails--
+			return errors.BadInput.New("there are waiting pipelines of current blueprint already")
+		}
+		runs[key] = append(runs[key], scopeIds)
+		return nil
+	})
+	bp1 := scopedRunKey{BlueprintId: 1, PluginName: "github", ConnectionId: 1}
+	bp2 := scopedRunKey{BlueprintId: 2, PluginName: "github", ConnectionId: 1}
+	d.schedule(bp1, "2")
+	d.schedule(bp1, "1", "2")
+	d.schedule(bp2, "3")
+
+	assert.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(runs[bp1]) == 1 && len(runs[bp2]) == 1
+	}, time.Second, 10*time.Millisecond)
+	lock.Lock()
+	defer lock.Unlock()
+	// coalesced into one run
+	assert.Equal(t, [][]string{{"1", "2"}}, runs[bp1])
+	// retried after the failure
+	assert.Equal(t, [][]string{{"3"}}, runs[bp2])
+}